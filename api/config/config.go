@@ -25,6 +25,17 @@ type Config struct {
 	JWTTTL     time.Duration `mapstructure:"JWT_TTL"`
 	RefreshTTL time.Duration `mapstructure:"REFRESH_TTL"`
 
+	// Exam conflicts in course selection: "error", "warning" or "ignore"
+	ExamOverlapPolicy string `mapstructure:"EXAM_OVERLAP_POLICY"`
+	ExamSameDayPolicy string `mapstructure:"EXAM_SAME_DAY_POLICY"`
+
+	// Font with Persian glyphs for printable timetables
+	TimetableFont string `mapstructure:"TIMETABLE_FONT"`
+
+	// Directory of the engine's column profiles, <name>.json, chosen per
+	// course import by its profile field
+	ImportProfiles string `mapstructure:"IMPORT_PROFILES"`
+
 	// Mailgun
 	MailgunAPIKey string `mapstructure:"MAILGUN_API_KEY"`
 	MailgunDomain string `mapstructure:"MAILGUN_DOMAIN"`
@@ -82,6 +93,22 @@ func LoadConfig(configPath string) (*Config, error) {
 		config.Environment = "development"
 	}
 
+	if config.Timezone == "" {
+		config.Timezone = "Asia/Tehran" // Golestan schedules are in Iran time
+	}
+
+	if config.ExamOverlapPolicy == "" {
+		config.ExamOverlapPolicy = "error"
+	}
+
+	if config.ExamSameDayPolicy == "" {
+		config.ExamSameDayPolicy = "warning"
+	}
+
+	if config.TimetableFont == "" {
+		config.TimetableFont = "/usr/share/fonts/dejavu/DejaVuSans.ttf" // font-dejavu on Alpine
+	}
+
 	if config.JWTTTL == 0 {
 		config.JWTTTL = 48 * time.Hour // Default to 48 hours
 	}
@@ -108,6 +135,12 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("database configuration is incomplete")
 	}
 
+	for _, policy := range []string{config.ExamOverlapPolicy, config.ExamSameDayPolicy} {
+		if policy != "error" && policy != "warning" && policy != "ignore" {
+			return fmt.Errorf("exam conflict policies must be error, warning or ignore")
+		}
+	}
+
 	if config.MailgunAPIKey == "" || config.MailgunDomain == "" {
 		return fmt.Errorf("mailgun configuration is incomplete")
 	}
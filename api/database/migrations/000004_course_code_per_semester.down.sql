@@ -0,0 +1,2 @@
+ALTER TABLE courses DROP CONSTRAINT IF EXISTS courses_university_id_semester_id_code_key;
+ALTER TABLE courses ADD CONSTRAINT courses_university_id_code_key UNIQUE (university_id, code);
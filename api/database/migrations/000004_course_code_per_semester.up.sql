@@ -0,0 +1,3 @@
+-- Course codes are only unique within a semester
+ALTER TABLE courses DROP CONSTRAINT IF EXISTS courses_university_id_code_key;
+ALTER TABLE courses ADD CONSTRAINT courses_university_id_semester_id_code_key UNIQUE (university_id, semester_id, code);
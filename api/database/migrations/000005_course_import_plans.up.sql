@@ -0,0 +1,18 @@
+-- Previewed course imports waiting to be committed
+CREATE TABLE course_import_plans (
+                                     id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                     university_id   UUID NOT NULL REFERENCES universities(id) ON DELETE CASCADE,
+                                     semester_id     UUID NOT NULL REFERENCES semesters(id) ON DELETE CASCADE,
+                                     created_by      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                                     mode            VARCHAR(6) NOT NULL CHECK (mode IN ('create','sync')),
+                                     delete_missing  BOOLEAN NOT NULL DEFAULT false,
+                                     records         JSONB NOT NULL,
+                                     fingerprint     VARCHAR(64) NOT NULL,
+                                     expires_at      TIMESTAMPTZ NOT NULL,
+                                     committed_at    TIMESTAMPTZ,
+                                     created_at      TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_course_import_plans_university_id ON course_import_plans(university_id);
+CREATE INDEX idx_course_import_plans_semester_id   ON course_import_plans(semester_id);
+CREATE INDEX idx_course_import_plans_expires_at    ON course_import_plans(expires_at);
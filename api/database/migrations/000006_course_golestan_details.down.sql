@@ -0,0 +1,8 @@
+ALTER TABLE courses
+    DROP COLUMN IF EXISTS group_number,
+    DROP COLUMN IF EXISTS practical_weight,
+    DROP COLUMN IF EXISTS enrolled,
+    DROP COLUMN IF EXISTS waiting_list,
+    DROP COLUMN IF EXISTS notes,
+    DROP COLUMN IF EXISTS cross_campus,
+    DROP COLUMN IF EXISTS emergency_drop;
@@ -0,0 +1,13 @@
+ALTER TABLE courses
+    ADD COLUMN group_number     VARCHAR(10) NOT NULL DEFAULT '',
+    ADD COLUMN practical_weight INT NOT NULL DEFAULT 0 CHECK (practical_weight >= 0),
+    ADD COLUMN enrolled         INT NOT NULL DEFAULT 0 CHECK (enrolled >= 0),
+    ADD COLUMN waiting_list     INT NOT NULL DEFAULT 0 CHECK (waiting_list >= 0),
+    ADD COLUMN notes            TEXT NOT NULL DEFAULT '',
+    ADD COLUMN cross_campus     BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN emergency_drop   BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Golestan appends the group number to the course code, e.g. 1211003_01
+UPDATE courses
+SET group_number = substring(code FROM '_([^_]+)$')
+WHERE code ~ '_[^_]+$';
@@ -0,0 +1,4 @@
+ALTER TABLE courses DROP CONSTRAINT IF EXISTS courses_catalog_course_id_semester_id_group_number_key;
+DROP INDEX IF EXISTS idx_courses_catalog_course_id;
+ALTER TABLE courses DROP COLUMN IF EXISTS catalog_course_id;
+DROP TABLE IF EXISTS catalog_courses;
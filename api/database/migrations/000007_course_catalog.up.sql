@@ -0,0 +1,36 @@
+-- Catalog Courses Table
+CREATE TABLE catalog_courses (
+                                 id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                 university_id UUID NOT NULL REFERENCES universities(id) ON DELETE CASCADE,
+                                 faculty_id    UUID NOT NULL REFERENCES faculties(id) ON DELETE CASCADE,
+                                 code          VARCHAR(50) NOT NULL,
+                                 name          VARCHAR(255) NOT NULL,
+                                 weight        INT NOT NULL,
+                                 created_at    TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                 updated_at    TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                 CONSTRAINT catalog_courses_university_id_code_key UNIQUE (university_id, code)
+);
+
+CREATE INDEX idx_catalog_courses_faculty_id ON catalog_courses(faculty_id);
+
+-- Existing courses become sections of the catalog entry for their code without the group suffix
+INSERT INTO catalog_courses (university_id, faculty_id, code, name, weight)
+SELECT DISTINCT ON (university_id, base_code) university_id, faculty_id, base_code, name, weight
+FROM (SELECT *, regexp_replace(code, '_[^_]+$', '') AS base_code FROM courses) sections
+ORDER BY university_id, base_code, created_at;
+
+ALTER TABLE courses ADD COLUMN catalog_course_id UUID REFERENCES catalog_courses(id) ON DELETE CASCADE;
+
+UPDATE courses
+SET catalog_course_id = catalog_courses.id
+FROM catalog_courses
+WHERE catalog_courses.university_id = courses.university_id
+  AND catalog_courses.code = regexp_replace(courses.code, '_[^_]+$', '');
+
+ALTER TABLE courses ALTER COLUMN catalog_course_id SET NOT NULL;
+
+CREATE INDEX idx_courses_catalog_course_id ON courses(catalog_course_id);
+
+-- A group number identifies one section of a course per semester
+ALTER TABLE courses ADD CONSTRAINT courses_catalog_course_id_semester_id_group_number_key
+    UNIQUE (catalog_course_id, semester_id, group_number);
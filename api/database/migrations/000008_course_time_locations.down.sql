@@ -0,0 +1,3 @@
+ALTER TABLE course_times
+    DROP COLUMN IF EXISTS room,
+    DROP COLUMN IF EXISTS location;
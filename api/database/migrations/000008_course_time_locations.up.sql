@@ -0,0 +1,3 @@
+ALTER TABLE course_times
+    ADD COLUMN room     VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN location VARCHAR(255) NOT NULL DEFAULT '';
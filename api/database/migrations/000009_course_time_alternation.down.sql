@@ -0,0 +1,3 @@
+ALTER TABLE course_times
+    DROP COLUMN IF EXISTS week_parity,
+    DROP COLUMN IF EXISTS half_term;
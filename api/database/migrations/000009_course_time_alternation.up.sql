@@ -0,0 +1,3 @@
+ALTER TABLE course_times
+    ADD COLUMN week_parity VARCHAR(4) NOT NULL DEFAULT '' CHECK (week_parity IN ('', 'odd', 'even')),
+    ADD COLUMN half_term   INT NOT NULL DEFAULT 0 CHECK (half_term BETWEEN 0 AND 2);
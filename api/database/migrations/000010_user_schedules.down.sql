@@ -0,0 +1,11 @@
+-- Only the active schedules survive as the flat selection
+DELETE FROM user_courses
+WHERE schedule_id IN (SELECT id FROM schedules WHERE NOT is_active);
+
+DROP INDEX IF EXISTS idx_user_courses_schedule_course;
+DROP INDEX IF EXISTS idx_user_courses_schedule_id;
+
+ALTER TABLE user_courses
+    DROP COLUMN IF EXISTS schedule_id;
+
+DROP TABLE IF EXISTS schedules;
@@ -0,0 +1,43 @@
+-- Schedules Table
+CREATE TABLE schedules (
+                           id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                           user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                           semester_id UUID NOT NULL REFERENCES semesters(id),
+                           name        VARCHAR(100) NOT NULL,
+                           position    INT NOT NULL DEFAULT 0,
+                           is_active   BOOLEAN NOT NULL DEFAULT FALSE,
+                           created_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                           updated_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                           CONSTRAINT schedules_user_id_semester_id_name_key UNIQUE (user_id, semester_id, name)
+);
+
+CREATE INDEX idx_schedules_user_semester ON schedules(user_id, semester_id);
+CREATE UNIQUE INDEX idx_schedules_active ON schedules(user_id, semester_id) WHERE is_active;
+
+-- Every existing selection becomes the active schedule of its semester
+INSERT INTO schedules (user_id, semester_id, name, is_active)
+SELECT DISTINCT user_id, semester_id, 'Plan A', TRUE
+FROM user_courses;
+
+-- A course could be selected twice before; keep the first selection
+DELETE FROM user_courses a
+    USING user_courses b
+WHERE a.user_id = b.user_id
+  AND a.course_id = b.course_id
+  AND a.semester_id = b.semester_id
+  AND (a.created_at, a.id) > (b.created_at, b.id);
+
+ALTER TABLE user_courses
+    ADD COLUMN schedule_id UUID REFERENCES schedules(id) ON DELETE CASCADE;
+
+UPDATE user_courses uc
+SET schedule_id = s.id
+FROM schedules s
+WHERE s.user_id = uc.user_id
+  AND s.semester_id = uc.semester_id;
+
+ALTER TABLE user_courses
+    ALTER COLUMN schedule_id SET NOT NULL;
+
+CREATE INDEX idx_user_courses_schedule_id ON user_courses(schedule_id);
+CREATE UNIQUE INDEX idx_user_courses_schedule_course ON user_courses(schedule_id, course_id);
@@ -0,0 +1,12 @@
+-- Read-only public links to schedules
+CREATE TABLE schedule_shares (
+                                 id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                 schedule_id UUID NOT NULL REFERENCES schedules(id) ON DELETE CASCADE,
+                                 token       VARCHAR(64) NOT NULL,
+                                 expires_at  TIMESTAMPTZ,
+                                 revoked     BOOLEAN NOT NULL DEFAULT false,
+                                 created_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_schedule_shares_schedule_id ON schedule_shares(schedule_id);
+CREATE UNIQUE INDEX idx_schedule_shares_token ON schedule_shares(token);
@@ -0,0 +1,4 @@
+ALTER TABLE semesters
+    DROP CONSTRAINT IF EXISTS semesters_dates_check,
+    DROP COLUMN IF EXISTS start_date,
+    DROP COLUMN IF EXISTS end_date;
@@ -0,0 +1,6 @@
+ALTER TABLE semesters
+    ADD COLUMN start_date DATE,
+    ADD COLUMN end_date   DATE,
+    ADD CONSTRAINT semesters_dates_check CHECK (
+        (start_date IS NULL AND end_date IS NULL) OR end_date > start_date
+    );
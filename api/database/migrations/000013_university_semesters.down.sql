@@ -0,0 +1 @@
+DROP TABLE IF EXISTS university_semesters;
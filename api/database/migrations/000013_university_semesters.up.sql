@@ -0,0 +1,28 @@
+-- Per-university semester calendars: selection windows, exam period and the current semester
+CREATE TABLE university_semesters (
+                                      id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                      university_id      UUID NOT NULL REFERENCES universities(id) ON DELETE CASCADE,
+                                      semester_id        UUID NOT NULL REFERENCES semesters(id) ON DELETE CASCADE,
+                                      registration_start TIMESTAMPTZ,
+                                      registration_end   TIMESTAMPTZ,
+                                      add_drop_start     TIMESTAMPTZ,
+                                      add_drop_end       TIMESTAMPTZ,
+                                      exam_start         DATE,
+                                      exam_end           DATE,
+                                      is_current         BOOLEAN NOT NULL DEFAULT false,
+                                      created_at         TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                      updated_at         TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                      CONSTRAINT university_semesters_registration_check CHECK (
+                                          (registration_start IS NULL AND registration_end IS NULL) OR registration_end > registration_start
+                                      ),
+                                      CONSTRAINT university_semesters_add_drop_check CHECK (
+                                          (add_drop_start IS NULL AND add_drop_end IS NULL) OR add_drop_end > add_drop_start
+                                      ),
+                                      CONSTRAINT university_semesters_exam_check CHECK (
+                                          (exam_start IS NULL AND exam_end IS NULL) OR exam_end >= exam_start
+                                      )
+);
+
+CREATE UNIQUE INDEX idx_university_semesters_semester ON university_semesters(university_id, semester_id);
+CREATE INDEX idx_university_semesters_semester_id ON university_semesters(semester_id);
+CREATE UNIQUE INDEX idx_university_semesters_current ON university_semesters(university_id) WHERE is_current;
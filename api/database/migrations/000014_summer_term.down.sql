@@ -0,0 +1,4 @@
+-- Fails while summer semesters exist
+ALTER TABLE semesters
+    DROP CONSTRAINT IF EXISTS semesters_term_check,
+    ADD CONSTRAINT semesters_term_check CHECK (term IN ('spring', 'fall'));
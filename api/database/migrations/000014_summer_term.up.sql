@@ -0,0 +1,4 @@
+-- Summer terms (ترم تابستان) alongside fall and spring
+ALTER TABLE semesters
+    DROP CONSTRAINT IF EXISTS semesters_term_check,
+    ADD CONSTRAINT semesters_term_check CHECK (term IN ('spring', 'fall', 'summer'));
@@ -0,0 +1,10 @@
+ALTER TABLE courses
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS search_key;
+
+ALTER TABLE professors
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS search_key;
+
+DROP FUNCTION IF EXISTS search_key(TEXT);
+DROP FUNCTION IF EXISTS search_normalize(TEXT);
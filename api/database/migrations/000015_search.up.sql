@@ -0,0 +1,32 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Mirrors utils.NormalizeSearch: ZWNJ becomes a space, diacritics and hamza are dropped
+CREATE FUNCTION search_normalize(input TEXT) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+AS $$
+    SELECT btrim(regexp_replace(
+        translate(lower(input),
+                  'ۀۂۃةيىئكأإؤ۰۱۲۳۴۵۶۷۸۹٠١٢٣٤٥٦٧٨٩' || U&'\200C\200F\064B\064C\064D\064E\064F\0650\0651\0652\0670' || 'ء',
+                  'ههههیییکااو01234567890123456789 '),
+        '\s+', ' ', 'g'))
+$$;
+
+-- Mirrors utils.SearchKey: homophones folded, long vowels and spaces dropped, doubled letters merged
+CREATE FUNCTION search_key(input TEXT) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+AS $$
+    SELECT regexp_replace(translate(search_normalize(input), 'ثصذضظطحغآاع ', 'سسزززتهق'), '(.)\1+', '\1', 'g')
+$$;
+
+ALTER TABLE courses
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', search_normalize(name) || ' ' || lower(code))) STORED,
+    ADD COLUMN search_key    TEXT GENERATED ALWAYS AS (search_key(name)) STORED;
+
+ALTER TABLE professors
+    ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', search_normalize(name))) STORED,
+    ADD COLUMN search_key    TEXT GENERATED ALWAYS AS (search_key(name)) STORED;
+
+CREATE INDEX idx_courses_search_vector ON courses USING GIN (search_vector);
+CREATE INDEX idx_courses_search_key ON courses USING GIN (search_key gin_trgm_ops);
+CREATE INDEX idx_professors_search_vector ON professors USING GIN (search_vector);
+CREATE INDEX idx_professors_search_key ON professors USING GIN (search_key gin_trgm_ops);
@@ -0,0 +1,12 @@
+-- Other spellings of merged professors' names, resolved on import
+CREATE TABLE professor_aliases (
+                                   id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                   professor_id     UUID NOT NULL REFERENCES professors(id) ON DELETE CASCADE,
+                                   university_id    UUID NOT NULL REFERENCES universities(id) ON DELETE CASCADE,
+                                   name             VARCHAR(255) NOT NULL,
+                                   normalized_name  VARCHAR(255) NOT NULL,
+                                   created_at       TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_professor_aliases_name ON professor_aliases(university_id, normalized_name);
+CREATE INDEX idx_professor_aliases_professor_id ON professor_aliases(professor_id);
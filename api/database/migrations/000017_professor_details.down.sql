@@ -0,0 +1,5 @@
+ALTER TABLE professors
+    DROP COLUMN IF EXISTS email,
+    DROP COLUMN IF EXISTS faculty_id,
+    DROP COLUMN IF EXISTS academic_rank,
+    DROP COLUMN IF EXISTS website;
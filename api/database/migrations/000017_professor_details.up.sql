@@ -0,0 +1,9 @@
+ALTER TABLE professors
+    ADD COLUMN email         VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN faculty_id    UUID REFERENCES faculties(id) ON DELETE SET NULL,
+    ADD COLUMN academic_rank VARCHAR(20) NOT NULL DEFAULT '' CHECK (
+        academic_rank IN ('', 'lecturer', 'instructor', 'assistant_professor', 'associate_professor', 'professor')
+    ),
+    ADD COLUMN website       VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_professors_faculty_id ON professors(faculty_id);
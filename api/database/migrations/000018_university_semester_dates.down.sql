@@ -0,0 +1,22 @@
+ALTER TABLE semesters
+    ADD COLUMN start_date DATE,
+    ADD COLUMN end_date   DATE,
+    ADD CONSTRAINT semesters_dates_check CHECK (
+        (start_date IS NULL AND end_date IS NULL) OR end_date > start_date
+    );
+
+UPDATE semesters
+SET start_date = dates.start_date,
+    end_date   = dates.end_date
+FROM (
+    SELECT DISTINCT ON (semester_id) semester_id, start_date, end_date
+    FROM university_semesters
+    WHERE start_date IS NOT NULL
+    ORDER BY semester_id, updated_at DESC
+) dates
+WHERE dates.semester_id = semesters.id;
+
+ALTER TABLE university_semesters
+    DROP CONSTRAINT IF EXISTS university_semesters_dates_check,
+    DROP COLUMN IF EXISTS start_date,
+    DROP COLUMN IF EXISTS end_date;
@@ -0,0 +1,18 @@
+-- Class dates differ between universities, so they move to each university's semester calendar
+ALTER TABLE university_semesters
+    ADD COLUMN start_date DATE,
+    ADD COLUMN end_date   DATE,
+    ADD CONSTRAINT university_semesters_dates_check CHECK (
+        (start_date IS NULL AND end_date IS NULL) OR end_date > start_date
+    );
+
+UPDATE university_semesters
+SET start_date = semesters.start_date,
+    end_date   = semesters.end_date
+FROM semesters
+WHERE semesters.id = university_semesters.semester_id;
+
+ALTER TABLE semesters
+    DROP CONSTRAINT IF EXISTS semesters_dates_check,
+    DROP COLUMN IF EXISTS start_date,
+    DROP COLUMN IF EXISTS end_date;
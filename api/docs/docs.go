@@ -46,6 +46,24 @@ const docTemplate = `{
                         "description": "Full‐text search query",
                         "name": "q",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only sections with free seats, by both Golestan's enrolled count and the selections of active schedules",
+                        "name": "has_free_seats",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by availability to other campuses",
+                        "name": "cross_campus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by emergency-drop permission",
+                        "name": "emergency_drop",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -73,7 +91,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a new course in the system",
+                "description": "Creates a new course in the system. date_exam is a Jalali date (e.g. 1404/04/07); Gregorian dates are rejected.",
                 "consumes": [
                     "application/json"
                 ],
@@ -123,11 +141,11 @@ const docTemplate = `{
                 }
             }
         },
-        "/courses/{id}": {
-            "get": {
-                "description": "Retrieves a course by its ID",
+        "/courses/import": {
+            "post": {
+                "description": "Imports courses for a university and semester from a Golestan HTML export or the engine's JSON output. A report with rows that cannot be read is rejected; preview it to see them.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -135,31 +153,74 @@ const docTemplate = `{
                 "tags": [
                     "courses"
                 ],
-                "summary": "Get a course",
+                "summary": "Import courses",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Course ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Semester ID, required without term_code",
+                        "name": "semester_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Golestan term code of the semester, e.g. 4031",
+                        "name": "term_code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Golestan HTML export or engine JSON",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Column profile of an HTML export, default for Golestan's standard layout",
+                        "name": "profile",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "create (default) fails on existing codes; sync creates, updates and optionally deletes",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "In sync mode, delete courses of the semester missing from the file",
+                        "name": "delete_missing",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Sync mode",
                         "schema": {
-                            "$ref": "#/definitions/dto.CourseResponse"
+                            "$ref": "#/definitions/dto.CourseImportDiffResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CourseListResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID format",
+                        "description": "Invalid file, unknown profile or faculty, or invalid course data",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Course not found",
+                    "409": {
+                        "description": "Conflict (e.g. duplicate code)",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -171,9 +232,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates the course identified by its ID",
+            }
+        },
+        "/courses/import/commit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies exactly the plan returned by the preview. Fails if the semester's courses, or the faculties and professors the plan resolves to, changed since the preview.",
                 "consumes": [
                     "application/json"
                 ],
@@ -183,22 +251,15 @@ const docTemplate = `{
                 "tags": [
                     "courses"
                 ],
-                "summary": "Update a course",
+                "summary": "Commit a previewed course import",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Course ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated course payload",
-                        "name": "course",
+                        "description": "Plan to commit",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateCourseDTO"
+                            "$ref": "#/definitions/dto.CommitCourseImportRequest"
                         }
                     }
                 ],
@@ -206,23 +267,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CourseResponse"
+                            "$ref": "#/definitions/dto.CourseImportDiffResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or not found",
+                        "description": "Invalid request or course data",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Course not found",
+                        "description": "Plan not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Conflict",
+                        "description": "Plan already committed or outdated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Plan expired",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -234,11 +301,18 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Deletes the course identified by its ID",
+            }
+        },
+        "/courses/import/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports what importing the file would create, update or delete without writing anything, listing the rows that cannot be read as invalid and the warnings of every row. A valid preview returns a plan ID to commit.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -246,34 +320,62 @@ const docTemplate = `{
                 "tags": [
                     "courses"
                 ],
-                "summary": "Delete a course",
+                "summary": "Preview a course import",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Course ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Semester ID, required without term_code",
+                        "name": "semester_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Golestan term code of the semester, e.g. 4031",
+                        "name": "term_code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Golestan HTML export or engine JSON",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Column profile of an HTML export, default for Golestan's standard layout",
+                        "name": "profile",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "create (default) or sync",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "In sync mode, delete courses of the semester missing from the file",
+                        "name": "delete_missing",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: Course deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.CourseImportPreviewResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid ID format",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Course not found",
+                        "description": "Invalid file, options or profile",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -287,25 +389,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/faculties/{id}/courses": {
+        "/courses/rooms": {
             "get": {
-                "description": "Retrieves all courses under the specified faculty",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Groups the course times of a semester by room",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "courses"
                 ],
-                "summary": "List courses by faculty",
+                "summary": "Room occupancy",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Faculty ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semester_id",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -315,12 +421,12 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.CourseResponse"
+                                "$ref": "#/definitions/dto.RoomOccupancyResponse"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid faculty ID",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -334,14 +440,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/faculties": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Creates a new faculty under a university",
+        "/courses/{id}": {
+            "get": {
+                "description": "Retrieves a course by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -349,112 +450,110 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "faculties"
+                    "courses"
                 ],
-                "summary": "Create Faculty",
+                "summary": "Get a course",
                 "parameters": [
                     {
-                        "description": "Create faculty payload",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateFacultyDTO"
-                        }
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.FacultyResponse"
+                            "$ref": "#/definitions/dto.CourseResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid ID format",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "University not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict (e.g. short code exists)",
+                        "description": "Course not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create faculty",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/admin/faculties/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            },
+            "put": {
+                "description": "Updates the course identified by its ID. date_exam is a Jalali date (e.g. 1404/04/07); Gregorian dates are rejected.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retrieves a faculty by its unique ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "faculties"
+                    "courses"
                 ],
-                "summary": "Get Faculty",
+                "summary": "Update a course",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Faculty ID",
+                        "description": "Course ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Updated course payload",
+                        "name": "course",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCourseDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.FacultyResponse"
+                            "$ref": "#/definitions/dto.CourseResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid faculty ID",
+                        "description": "Invalid request or not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Faculty not found",
+                        "description": "Course not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to get faculty",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Updates the details of an existing faculty",
+            "delete": {
+                "description": "Deletes the course identified by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -462,78 +561,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "faculties"
+                    "courses"
                 ],
-                "summary": "Update Faculty",
+                "summary": "Delete a course",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Faculty ID",
+                        "description": "Course ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update faculty payload",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UpdateFacultyDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "message: Course deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/dto.FacultyResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or ID",
+                        "description": "Invalid ID format",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Faculty not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict updating faculty",
+                        "description": "Course not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update faculty",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Deletes a faculty from the system",
+            }
+        },
+        "/courses/{id}/sections": {
+            "get": {
+                "description": "Lists every section (group) of the same course offered in the course's semester",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "faculties"
+                    "courses"
                 ],
-                "summary": "Delete Faculty",
+                "summary": "List course sections",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Faculty ID",
+                        "description": "Course ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -541,73 +625,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: Faculty deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.CourseSectionsResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid faculty ID",
+                        "description": "Invalid ID format",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Faculty not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to delete faculty",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/admin/professors": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Creates a new professor under a university, or returns existing one",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "professors"
-                ],
-                "summary": "Create Professor",
-                "parameters": [
-                    {
-                        "description": "Create professor payload",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateProfessorRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ProfessorMinimalResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid payload or university not found",
+                        "description": "Course not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -621,25 +651,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/professors/{id}": {
+        "/faculties/{id}/courses": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+                "description": "Retrieves all courses under the specified faculty",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retrieves a professor by their unique ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "professors"
+                    "courses"
                 ],
-                "summary": "Get Professor",
+                "summary": "List courses by faculty",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Professor ID",
+                        "description": "Faculty ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -649,17 +677,14 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.ProfessorDetailResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CourseResponse"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid professor ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Professor not found",
+                        "description": "Invalid faculty ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -673,46 +698,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/semesters": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieves all semesters, ordered by most recent",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "semesters"
-                ],
-                "summary": "List Semesters",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.SemesterResponse"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            },
+        "/v1/admin/faculties": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new semester with year and term",
+                "description": "Creates a new faculty under a university",
                 "consumes": [
                     "application/json"
                 ],
@@ -720,17 +713,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "semesters"
+                    "faculties"
                 ],
-                "summary": "Create Semester",
+                "summary": "Create Faculty",
                 "parameters": [
                     {
-                        "description": "Create semester payload",
+                        "description": "Create faculty payload",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateSemesterRequest"
+                            "$ref": "#/definitions/dto.CreateFacultyDTO"
                         }
                     }
                 ],
@@ -738,23 +731,29 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.SemesterResponse"
+                            "$ref": "#/definitions/dto.FacultyResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input or duplicate year+term",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "University not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Semester already exists",
+                        "description": "Conflict (e.g. short code exists)",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to create faculty",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -762,25 +761,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/semesters/{id}": {
+        "/v1/admin/faculties/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a semester by its ID",
+                "description": "Retrieves a faculty by its unique ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "semesters"
+                    "faculties"
                 ],
-                "summary": "Get Semester",
+                "summary": "Get Faculty",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Semester ID",
+                        "description": "Faculty ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -790,23 +789,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SemesterResponse"
+                            "$ref": "#/definitions/dto.FacultyResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid semester ID",
+                        "description": "Invalid faculty ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Semester not found",
+                        "description": "Faculty not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to get faculty",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -819,7 +818,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the year or term of an existing semester",
+                "description": "Updates the details of an existing faculty",
                 "consumes": [
                     "application/json"
                 ],
@@ -827,24 +826,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "semesters"
+                    "faculties"
                 ],
-                "summary": "Update Semester",
+                "summary": "Update Faculty",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Semester ID",
+                        "description": "Faculty ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update semester payload",
+                        "description": "Update faculty payload",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateSemesterRequest"
+                            "$ref": "#/definitions/dto.UpdateFacultyDTO"
                         }
                     }
                 ],
@@ -852,29 +851,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SemesterResponse"
+                            "$ref": "#/definitions/dto.FacultyResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input or ID",
+                        "description": "Invalid request body or ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Semester not found",
+                        "description": "Faculty not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Duplicate semester",
+                        "description": "Conflict updating faculty",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to update faculty",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -887,18 +886,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a semester by its ID",
+                "description": "Deletes a faculty from the system",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "semesters"
+                    "faculties"
                 ],
-                "summary": "Delete Semester",
+                "summary": "Delete Faculty",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Semester ID",
+                        "description": "Faculty ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -906,7 +905,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: Semester deleted successfully",
+                        "description": "message: Faculty deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -915,19 +914,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid semester ID",
+                        "description": "Invalid faculty ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Semester not found",
+                        "description": "Faculty not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to delete faculty",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -935,33 +934,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/universities": {
+        "/v1/admin/professors": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all universities",
+                "description": "Lists a page of a university's professors. With q, matches names regardless of Arabic letter variants, spacing and misspelled homophones, closest first; otherwise sorts by name.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "universities"
+                    "professors"
                 ],
-                "summary": "List Universities",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.UniversityResponse"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                "summary": "Search Professors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name to search for",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProfessorListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid university ID or query",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -974,7 +1005,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new university",
+                "description": "Creates a new professor under a university. Email, faculty, academic rank and website are optional. A name that already belongs to a professor, as their name or an alias, conflicts; change that professor with Update instead.",
                 "consumes": [
                     "application/json"
                 ],
@@ -982,17 +1013,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "universities"
+                    "professors"
                 ],
-                "summary": "Create University",
+                "summary": "Create Professor",
                 "parameters": [
                     {
-                        "description": "Create university payload",
+                        "description": "Create professor payload",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateUniversityRequest"
+                            "$ref": "#/definitions/dto.CreateProfessorRequest"
                         }
                     }
                 ],
@@ -1000,17 +1031,23 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.UniversityResponse"
+                            "$ref": "#/definitions/dto.ProfessorDetailResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid payload or faculty of another university",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "University not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Conflict (name already exists)",
+                        "description": "Professor with this name or alias exists",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1024,25 +1061,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/universities/{id}": {
+        "/v1/admin/professors/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a university by its ID",
+                "description": "Retrieves a professor by their unique ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "universities"
+                    "professors"
                 ],
-                "summary": "Get University",
+                "summary": "Get Professor",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "University ID",
+                        "description": "Professor ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1052,17 +1089,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UniversityResponse"
+                            "$ref": "#/definitions/dto.ProfessorDetailResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid university ID",
+                        "description": "Invalid professor ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "University not found",
+                        "description": "Professor not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1081,7 +1118,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the specified university’s details",
+                "description": "Replaces a professor's name, email, faculty, academic rank and website. A new name keeps the old one as an alias so later imports still resolve to the professor.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1089,24 +1126,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "universities"
+                    "professors"
                 ],
-                "summary": "Update University",
+                "summary": "Update Professor",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "University ID",
+                        "description": "Professor ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update university payload",
+                        "description": "Update professor payload",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateUniversityRequest"
+                            "$ref": "#/definitions/dto.UpdateProfessorRequest"
                         }
                     }
                 ],
@@ -1114,17 +1151,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UniversityResponse"
+                            "$ref": "#/definitions/dto.ProfessorDetailResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input or ID",
+                        "description": "Invalid payload or faculty of another university",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "University not found",
+                        "description": "Professor not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Name belongs to another professor",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1143,18 +1186,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes the specified university",
+                "description": "Deletes a professor who teaches no courses. Merge a duplicate professor instead of deleting it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "universities"
+                    "professors"
                 ],
-                "summary": "Delete University",
+                "summary": "Delete Professor",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "University ID",
+                        "description": "Professor ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1162,7 +1205,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: University deleted successfully",
+                        "description": "message: Professor deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1171,13 +1214,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid university ID",
+                        "description": "Invalid professor ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "University not found",
+                        "description": "Professor not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Professor still has courses",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1191,25 +1240,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/universities/{id}/faculties": {
+        "/v1/admin/professors/{id}/history": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all faculties under the specified university",
+                "description": "Lists the courses a professor taught, grouped by semester, newest semester first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "faculties"
+                    "professors"
                 ],
-                "summary": "List Faculties",
+                "summary": "Professor Teaching History",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "University ID",
+                        "description": "Professor ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1221,24 +1270,24 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.FacultyResponse"
+                                "$ref": "#/definitions/dto.ProfessorTeachingResponse"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid university ID",
+                        "description": "Invalid professor ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "University not found",
+                        "description": "Professor not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to get faculties",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1246,58 +1295,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/universities/{id}/faculty/{short_code}": {
-            "get": {
+        "/v1/admin/professors/{id}/merge": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a faculty within a university by its short code",
+                "description": "Moves the courses of the source professors to the target, keeps their names as its aliases so later imports resolve to it, and deletes them",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "faculties"
+                    "professors"
                 ],
-                "summary": "Get Faculty by Short Code",
+                "summary": "Merge Professors",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "University ID",
+                        "description": "Target professor ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Faculty short code",
-                        "name": "short_code",
-                        "in": "path",
-                        "required": true
+                        "description": "Professors to merge into the target",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MergeProfessorsRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.FacultyResponse"
+                            "$ref": "#/definitions/dto.ProfessorDetailResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid university ID or short code missing",
+                        "description": "Invalid payload, or professors of another university",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Faculty not found",
+                        "description": "Professor not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to get faculty",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1305,46 +1359,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/universities/{id}/professors": {
+        "/v1/admin/semesters": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all professors associated with a given university",
+                "description": "Retrieves all semesters, ordered by most recent",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "professors"
-                ],
-                "summary": "List Professors",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "University ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "semesters"
                 ],
+                "summary": "List Semesters",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.ProfessorMinimalResponse"
+                                "$ref": "#/definitions/dto.SemesterResponse"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid university ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1352,48 +1391,56 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/admin/users": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a paginated list of all admin users",
+                "description": "Creates a new semester with year and term",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "semesters"
                 ],
-                "summary": "List users",
+                "summary": "Create Semester",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Create semester payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSemesterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "paginated list of AdminUserResponse",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.AdminUserListResponse"
+                            "$ref": "#/definitions/dto.SemesterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or duplicate year+term",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Semester already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to fetch users",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1401,26 +1448,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/users/{id}": {
+        "/v1/admin/semesters/code/{code}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a single admin user by their ID",
+                "description": "Retrieves a semester by its Golestan term code, e.g. 4031 for the fall of 1403-1404",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "semesters"
                 ],
-                "summary": "Get user",
+                "summary": "Get Semester by Code",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Golestan term code",
+                        "name": "code",
                         "in": "path",
                         "required": true
                     }
@@ -1429,61 +1476,113 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.AdminUserResponse"
+                            "$ref": "#/definitions/dto.SemesterResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid term code",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Semester not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to get user",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/admin/semesters/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing admin user's fields (and optionally password)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves a semester by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "semesters"
                 ],
-                "summary": "Update user",
+                "summary": "Get Semester",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Semester ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SemesterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid semester ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Semester not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the year or term of an existing semester",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "semesters"
+                ],
+                "summary": "Update Semester",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated user data",
+                        "description": "Update semester payload",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.AdminUpdateUserRequest"
+                            "$ref": "#/definitions/dto.UpdateSemesterRequest"
                         }
                     }
                 ],
@@ -1491,29 +1590,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.AdminUserResponse"
+                            "$ref": "#/definitions/dto.SemesterResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or user ID",
+                        "description": "Invalid input or ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Semester not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Conflict (e.g. duplicate student ID)",
+                        "description": "Duplicate semester",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update user",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1526,18 +1625,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes an admin user by ID",
+                "description": "Deletes a semester by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "semesters"
                 ],
-                "summary": "Delete user",
+                "summary": "Delete Semester",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Semester ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1545,7 +1644,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: User deleted successfully",
+                        "description": "message: Semester deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1554,19 +1653,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID",
+                        "description": "Invalid semester ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Semester not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete user",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1574,58 +1673,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/forgot-password": {
-            "post": {
-                "description": "Sends a password reset link if the email exists",
-                "consumes": [
-                    "application/json"
+        "/v1/admin/universities": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieves all universities",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
-                ],
-                "summary": "Forgot Password",
-                "parameters": [
-                    {
-                        "description": "Forgot password payload",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
-                        }
-                    }
+                    "universities"
                 ],
+                "summary": "List Universities",
                 "responses": {
                     "200": {
-                        "description": "message: If the email exists, a reset link will be sent",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.UniversityResponse"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid payload",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
                     "500": {
-                        "description": "Failed to process request",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/auth/login": {
+            },
             "post": {
-                "description": "Authenticates user and returns access token in response body and refresh token as HTTP-only cookie",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new university",
                 "consumes": [
                     "application/json"
                 ],
@@ -1633,53 +1720,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "universities"
                 ],
-                "summary": "Login",
+                "summary": "Create University",
                 "parameters": [
                     {
-                        "description": "Login payload",
+                        "description": "Create university payload",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
+                            "$ref": "#/definitions/dto.CreateUniversityRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Contains access_token and expires_in",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
-                        },
-                        "headers": {
-                            "Set-Cookie": {
-                                "type": "string",
-                                "description": "refresh_token=\u003ctoken\u003e; Path=/; HttpOnly; Secure"
-                            }
+                            "$ref": "#/definitions/dto.UniversityResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid payload",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Invalid credentials",
+                        "description": "Invalid input",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Email not verified",
+                    "409": {
+                        "description": "Conflict (name already exists)",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to login",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1687,88 +1762,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/logout": {
-            "post": {
-                "description": "Revokes the current refresh token and clears the cookie",
-                "consumes": [
-                    "application/json"
+        "/v1/admin/universities/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieves a university by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "universities"
+                ],
+                "summary": "Get University",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Logout",
                 "responses": {
                     "200": {
-                        "description": "message: logged out successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        },
-                        "headers": {
-                            "Set-Cookie": {
-                                "type": "string",
-                                "description": "refresh_token=; Path=/; HttpOnly; Secure; MaxAge=0"
-                            }
+                            "$ref": "#/definitions/dto.UniversityResponse"
                         }
                     },
                     "400": {
-                        "description": "Missing refresh token",
+                        "description": "Invalid university ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/auth/refresh": {
-            "post": {
-                "description": "Generate new access token using refresh token from HTTP-only cookie",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Refresh token",
-                "responses": {
-                    "200": {
-                        "description": "Contains access_token and expires_in",
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
-                        },
-                        "headers": {
-                            "Set-Cookie": {
-                                "type": "string",
-                                "description": "refresh_token=\u003ctoken\u003e; Path=/; HttpOnly; Secure"
-                            }
-                        }
                     },
-                    "400": {
-                        "description": "Missing refresh token cookie",
+                    "404": {
+                        "description": "University not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Invalid or expired refresh token",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/auth/register": {
-            "post": {
-                "description": "Creates a new user account and sends a verification email",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the specified university’s details",
                 "consumes": [
                     "application/json"
                 ],
@@ -1776,78 +1827,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "universities"
                 ],
-                "summary": "Register",
+                "summary": "Update University",
                 "parameters": [
                     {
-                        "description": "Registration payload",
+                        "type": "string",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update university payload",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.RegisterRequest"
+                            "$ref": "#/definitions/dto.UpdateUniversityRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "message: Registration successful",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.UniversityResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid payload or IDs",
+                        "description": "Invalid input or ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "email or student ID already exists",
+                    "404": {
+                        "description": "University not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to register user",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/auth/reset-password": {
-            "post": {
-                "description": "Resets the user's password using the provided token",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Deletes the specified university",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "universities"
                 ],
-                "summary": "Reset Password",
+                "summary": "Delete University",
                 "parameters": [
                     {
-                        "description": "Reset password payload",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ResetPasswordRequest"
-                        }
+                        "type": "string",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: Password reset successful",
+                        "description": "message: University deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1856,13 +1909,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid payload or token",
+                        "description": "Invalid university ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "University not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to reset password",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -1870,140 +1929,113 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/verify-email": {
-            "post": {
-                "description": "Verifies a user's email using the provided token",
-                "consumes": [
-                    "application/json"
+        "/v1/admin/universities/{id}/faculties": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieves all faculties under the specified university",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "faculties"
                 ],
-                "summary": "Verify Email",
+                "summary": "List Faculties",
                 "parameters": [
                     {
-                        "description": "Verification payload",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.VerifyEmailRequest"
-                        }
+                        "type": "string",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: Email verified successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.FacultyResponse"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid or expired token",
+                        "description": "Invalid university ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to verify email",
+                    "404": {
+                        "description": "University not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/health": {
-            "get": {
-                "description": "Returns a 200 OK if the service is running",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Health Check",
-                "responses": {
-                    "200": {
-                        "description": "status: ok",
+                    },
+                    "500": {
+                        "description": "Failed to get faculties",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/user/courses/select": {
-            "post": {
+        "/v1/admin/universities/{id}/faculty/{short_code}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Adds a course to the current user's schedule",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves a faculty within a university by its short code",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user-courses"
+                    "faculties"
                 ],
-                "summary": "Select Course",
+                "summary": "Get Faculty by Short Code",
                 "parameters": [
                     {
-                        "description": "course_id and semester_id",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Faculty short code",
+                        "name": "short_code",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: Course added successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/dto.FacultyResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid university ID or short code missing",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Course or semester not found",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict (e.g. already selected)",
+                        "description": "Faculty not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to get faculty",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2011,48 +2043,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/courses/select/{courseId}": {
-            "delete": {
+        "/v1/admin/universities/{id}/professors": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Removes a course from the current user's schedule",
+                "description": "Retrieves all professors associated with a given university",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user-courses"
+                    "professors"
                 ],
-                "summary": "Remove Course",
+                "summary": "List Professors",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Course ID",
-                        "name": "courseId",
+                        "description": "University ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: Course removed successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ProfessorMinimalResponse"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid course ID",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Course not found in user's schedule",
+                        "description": "Invalid university ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2066,28 +2092,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/courses/selected": {
+        "/v1/admin/universities/{id}/professors/duplicates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all courses selected by the user for a given semester",
+                "description": "Lists pairs of a university's professors whose names likely belong to the same person, ignoring titles, spelling variants and word order. Best match first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user-courses"
+                    "professors"
                 ],
-                "summary": "Get User Courses",
+                "summary": "Suggest Duplicate Professors",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Semester ID",
-                        "name": "semester_id",
-                        "in": "query",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "number",
+                        "default": 0.5,
+                        "description": "Minimum similarity from 0 to 1",
+                        "name": "min_score",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2096,12 +2129,12 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dto.CourseResponse"
+                                "$ref": "#/definitions/dto.ProfessorDuplicateResponse"
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid semester ID",
+                        "description": "Invalid university ID or score",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2115,40 +2148,158 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/courses/validate": {
+        "/v1/admin/universities/{id}/semesters": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Checks if adding the selected course causes any time conflicts",
+                "description": "Lists a university's semester calendars, newest semester first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user-courses"
+                    "semesters"
                 ],
-                "summary": "Validate Time Conflicts",
+                "summary": "List University Semesters",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Course ID",
-                        "name": "course_id",
-                        "in": "query",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.UniversitySemesterResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid university ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/universities/{id}/semesters/{semesterId}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets a university's class dates, registration and add/drop windows, exam period and current flag for a semester. Marking it current unmarks the university's other semesters.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "semesters"
+                ],
+                "summary": "Set University Semester",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Semester ID",
-                        "name": "semester_id",
-                        "in": "query",
+                        "name": "semesterId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Semester calendar payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetUniversitySemesterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UniversitySemesterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "University or semester not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a university's calendar for a semester, lifting its selection windows",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "semesters"
+                ],
+                "summary": "Delete University Semester",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "University ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semesterId",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "message: No time conflicts found",
+                        "description": "message: University semester deleted successfully",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2157,13 +2308,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Time conflict exists",
+                    "404": {
+                        "description": "University semester not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -2171,131 +2328,2964 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/me": {
+        "/v1/admin/users": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about the authenticated user, including university and faculty names (if available)",
+                "description": "Returns a paginated list of all admin users",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get Current User Details",
                 "responses": {
                     "200": {
-                        "description": "Detailed user information, university/faculty may be null if lookup failed",
+                        "description": "paginated list of AdminUserResponse",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/dto.AdminUserListResponse"
                         }
                     },
-                    "401": {
-                        "description": "User not authenticated",
+                    "500": {
+                        "description": "Failed to fetch users",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single admin user by their ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdminUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found\" // Only if user lookup fails",
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to get user",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an existing admin user's fields (and optionally password)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated user data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdminUpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AdminUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict (e.g. duplicate student ID)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to update user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes an admin user by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: User deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to delete user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/forgot-password": {
+            "post": {
+                "description": "Sends a password reset link if the email exists",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Forgot Password",
+                "parameters": [
+                    {
+                        "description": "Forgot password payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: If the email exists, a reset link will be sent",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to process request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/login": {
+            "post": {
+                "description": "Authenticates user and returns access token in response body and refresh token as HTTP-only cookie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login",
+                "parameters": [
+                    {
+                        "description": "Login payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Contains access_token and expires_in",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        },
+                        "headers": {
+                            "Set-Cookie": {
+                                "type": "string",
+                                "description": "refresh_token=\u003ctoken\u003e; Path=/; HttpOnly; Secure"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email not verified",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to login",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/logout": {
+            "post": {
+                "description": "Revokes the current refresh token and clears the cookie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "200": {
+                        "description": "message: logged out successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        },
+                        "headers": {
+                            "Set-Cookie": {
+                                "type": "string",
+                                "description": "refresh_token=; Path=/; HttpOnly; Secure; MaxAge=0"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Missing refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/refresh": {
+            "post": {
+                "description": "Generate new access token using refresh token from HTTP-only cookie",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh token",
+                "responses": {
+                    "200": {
+                        "description": "Contains access_token and expires_in",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        },
+                        "headers": {
+                            "Set-Cookie": {
+                                "type": "string",
+                                "description": "refresh_token=\u003ctoken\u003e; Path=/; HttpOnly; Secure"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Missing refresh token cookie",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/register": {
+            "post": {
+                "description": "Creates a new user account and sends a verification email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register",
+                "parameters": [
+                    {
+                        "description": "Registration payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "message: Registration successful",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload or IDs",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "email or student ID already exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to register user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/reset-password": {
+            "post": {
+                "description": "Resets the user's password using the provided token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Reset Password",
+                "parameters": [
+                    {
+                        "description": "Reset password payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: Password reset successful",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload or token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to reset password",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/verify-email": {
+            "post": {
+                "description": "Verifies a user's email using the provided token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Verify Email",
+                "parameters": [
+                    {
+                        "description": "Verification payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: Email verified successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to verify email",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/catalog/courses": {
+            "get": {
+                "description": "Lists a page of the courses a university offers in a semester. Day, from and to keep courses with a session matching all of them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "courses"
+                ],
+                "summary": "Browse the course catalog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semester_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Faculty ID",
+                        "name": "faculty_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Professor ID",
+                        "name": "professor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Day of week, 0 (Saturday) to 6",
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sessions starting at or after, HH:MM",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sessions ending at or before, HH:MM",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by weight",
+                        "name": "weight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sections open to male or female students, or only mixed ones",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only sections with free seats, by both Golestan's enrolled count and the selections of active schedules",
+                        "name": "has_free_seats",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "code (default), name, weight, capacity, free_seats or exam",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc (default) or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CourseCatalogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "University or semester not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/health": {
+            "get": {
+                "description": "Returns a 200 OK if the service is running",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health Check",
+                "responses": {
+                    "200": {
+                        "description": "status: ok",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/search": {
+            "get": {
+                "description": "Ranks a university's courses by name, code and professor, and its professors by name. Persian and Arabic letter variants, ZWNJ, spacing and Finglish (e.g. \"riazi\") are tolerated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search courses and professors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "University ID",
+                        "name": "university_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only courses of this semester",
+                        "name": "semester_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Results per kind, up to 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/semesters/current": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the current semester of the user's university with its class dates, selection windows and exam period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "semesters"
+                ],
+                "summary": "Current Semester",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UniversitySemesterResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No current semester",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shared/schedules/{token}": {
+            "get": {
+                "description": "Returns a schedule published under a public link with its course times, professors and exams. No authentication required.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "View Shared Schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SharedScheduleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown or revoked link",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Expired link",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shared/schedules/{token}/calendar.ics": {
+            "get": {
+                "description": "Serves a shared schedule as an iCalendar feed that calendar apps can subscribe to. No authentication required.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Subscribe to Shared Schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Semester without dates",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown or revoked link",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Expired link",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/courses/exams": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the exams of the user's active schedule in a semester, or of the schedule given by schedule_id, in chronological order, marking overlapping and same-day exams",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-courses"
+                ],
+                "summary": "Exam Calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID, the current semester by default",
+                        "name": "semester_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExamCalendarResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid semester ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/courses/generate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enumerates the section combinations of the given courses free of time and exam conflicts that the user can take, ranked by free days, earliest start, gaps and preferred professors. The semester defaults to the current one of the user's university.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-courses"
+                ],
+                "summary": "Generate Schedules",
+                "parameters": [
+                    {
+                        "description": "Courses and preferences",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GenerateSchedulesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GenerateSchedulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Course or semester not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/courses/select": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a course to the current user's active schedule of the semester, or to the schedule given by schedule_id. The semester defaults to the current one of the user's university.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-courses"
+                ],
+                "summary": "Select Course",
+                "parameters": [
+                    {
+                        "description": "course_id and optional semester_id",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Course added, with any exam clashes the policy only warns about",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CourseSelectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Outside the registration and add/drop windows",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Course or semester not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict (e.g. already selected, time or exam clash)",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/courses/select/swap": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces a selected section with another section (group) of the same course in one step",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-courses"
+                ],
+                "summary": "Swap Section",
+                "parameters": [
+                    {
+                        "description": "Sections to swap",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SwapSectionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID, the active schedule by default",
+                        "name": "schedule_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Section swapped, with any exam clashes the policy only warns about",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CourseSelectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or sections of different courses",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Outside the registration and add/drop windows",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found or not selected",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Time or exam conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/courses/select/{courseId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a course from the current user's active schedule, or from the schedule given by schedule_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-courses"
+                ],
+                "summary": "Remove Course",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: Course removed successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid course ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Outside the registration and add/drop windows",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found in user's schedule",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/courses/select/{courseId}/alternatives": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the other sections of a course the user could take instead, skipping full, gender-restricted and conflicting ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-courses"
+                ],
+                "summary": "Alternative Sections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "courseId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID, the active schedule by default",
+                        "name": "schedule_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CourseResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid course ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/courses/selected": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the courses of the user's active schedule for a given semester, or of the schedule given by schedule_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-courses"
+                ],
+                "summary": "Get User Courses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID, the current semester by default",
+                        "name": "semester_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CourseResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid semester ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/courses/validate": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Checks if adding the selected course causes any class time or exam conflicts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user-courses"
+                ],
+                "summary": "Validate Time Conflicts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Course ID",
+                        "name": "course_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Semester ID, the current semester by default",
+                        "name": "semester_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "No blocking conflicts, with any exam clashes the policy only warns about",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CourseSelectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Time conflict exists, with the course's other sections that would fit",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves detailed information about the authenticated user, including university and faculty names (if available)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get Current User Details",
+                "responses": {
+                    "200": {
+                        "description": "Detailed user information, university/faculty may be null if lookup failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "User not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found\" // Only if user lookup fails",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the current user's schedules of a semester in their order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "List Schedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Semester ID",
+                        "name": "semester_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ScheduleResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid semester ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a named draft schedule for a semester. The first schedule of a semester is always active.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Create Schedule",
+                "parameters": [
+                    {
+                        "description": "Create schedule payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Semester not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule name already used",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/schedules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves one of the current user's schedules with its courses",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Get Schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes a schedule's name or position, or makes it the active schedule of its semester. Drafts can only be activated within the registration and add/drop windows, and only if each of their courses still has a free seat, admits the user's gender and clashes with no other course of the draft.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Update Schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update schedule payload",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Activation outside the registration and add/drop windows",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule name already used, or courses of the draft can't be taken",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a schedule with its courses. Deleting the active schedule, only possible within the registration and add/drop windows, activates the next one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Delete Schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: Schedule deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Active schedule outside the registration and add/drop windows",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/schedules/{id}/calendar.ics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exports a schedule's weekly classes, bounded by the class dates its university set for the semester, and its exams as an iCalendar file",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Export Schedule Calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule ID or semester without dates",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/schedules/{id}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Copies a schedule and its courses under a new name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Clone Schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name of the copy",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CloneScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule name already used",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/schedules/{id}/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compares a schedule with another schedule of the same semester: added and removed courses, changed sections and total weights",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Diff Schedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the schedule to compare with",
+                        "name": "with",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule ID or schedules of different semesters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/schedules/{id}/shares": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the public links of a schedule that haven't been revoked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "List Schedule Shares",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ScheduleShareResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates an unguessable public token under which the schedule can be viewed read-only, optionally expiring",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Share Schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Share options",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateScheduleShareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleShareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or expiry in the past",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/schedules/{id}/shares/{shareId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes a public link so the schedule can no longer be viewed through it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Revoke Schedule Share",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Share ID",
+                        "name": "shareId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: Share revoked successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule or share not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/schedules/{id}/timetable": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renders a schedule as a right-to-left Persian weekly timetable from Saturday to Thursday, with its exam table below",
+                "produces": [
+                    "application/pdf",
+                    "image/png"
+                ],
+                "tags": [
+                    "schedules"
+                ],
+                "summary": "Print Schedule Timetable",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pdf",
+                            "png"
+                        ],
+                        "type": "string",
+                        "default": "pdf",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid schedule ID or format",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AdminUpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "faculty_id": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female"
+                    ]
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "university_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AdminUserListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AdminUserResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AdminUserResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_verified": {
+                    "type": "boolean"
+                },
+                "faculty_id": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_admin": {
+                    "type": "boolean"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "university_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CloneScheduleRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dto.CommitCourseImportRequest": {
+            "type": "object",
+            "required": [
+                "plan_id"
+            ],
+            "properties": {
+                "plan_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CourseCatalogResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CourseDiffEntry": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseFieldChange"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CourseFieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "new": {
+                    "type": "string"
+                },
+                "old": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CourseImportDiffResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseDiffEntry"
+                    }
+                },
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseDiffEntry"
+                    }
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseDiffEntry"
+                    }
+                }
+            }
+        },
+        "dto.CourseImportPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "diff": {
+                    "$ref": "#/definitions/dto.CourseImportDiffResponse"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "new_professors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "plan_id": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseImportPreviewRow"
+                    }
+                },
+                "unmatched_faculties": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.CourseImportPreviewRow": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseFieldChange"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "course": {
+                    "$ref": "#/definitions/schema.Course"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CourseListResponse": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CourseResponse": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "catalog_course_id": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "course_times": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseTimeResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cross_campus": {
+                    "type": "boolean"
+                },
+                "emergency_drop": {
+                    "type": "boolean"
+                },
+                "enrolled": {
+                    "type": "integer"
+                },
+                "exam_date": {
+                    "description": "Gregorian, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "exam_date_jalali": {
+                    "description": "Solar Hijri, YYYY/MM/DD",
+                    "type": "string"
+                },
+                "exam_end": {
+                    "type": "string"
+                },
+                "exam_start": {
+                    "type": "string"
+                },
+                "faculty_id": {
+                    "type": "string"
+                },
+                "faculty_name_en": {
+                    "type": "string"
+                },
+                "faculty_name_fa": {
+                    "type": "string"
+                },
+                "gender_restriction": {
+                    "type": "string"
+                },
+                "group_number": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "practical_weight": {
+                    "type": "integer"
+                },
+                "professor_id": {
+                    "type": "string"
+                },
+                "professor_name": {
+                    "type": "string"
+                },
+                "semester_id": {
+                    "type": "string"
+                },
+                "university_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "waiting_list": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CourseSearchResult": {
+            "type": "object",
+            "properties": {
+                "course": {
+                    "$ref": "#/definitions/dto.CourseResponse"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.CourseSectionsResponse": {
+            "type": "object",
+            "properties": {
+                "catalog_course_id": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
+                },
+                "semester_id": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CourseSelectionResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExamConflict"
+                    }
+                }
+            }
+        },
+        "dto.CourseTimeResponse": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "string"
+                },
+                "day_of_week": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "half_term": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "room": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "week_parity": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateCourseDTO": {
+            "type": "object",
+            "required": [
+                "code",
+                "date_exam",
+                "faculty_id",
+                "gender",
+                "name",
+                "professor_name",
+                "semester_id",
+                "time_exam",
+                "times",
+                "university_id",
+                "weight"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "code": {
+                    "type": "string"
+                },
+                "cross_campus": {
+                    "type": "boolean"
+                },
+                "date_exam": {
+                    "description": "Jalali (Solar Hijri) YYYY/MM/DD, e.g. 1404/04/07; Gregorian dates are rejected",
+                    "type": "string"
+                },
+                "emergency_drop": {
+                    "type": "boolean"
+                },
+                "enrolled": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "faculty_id": {
+                    "type": "string"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "mixed"
+                    ]
+                },
+                "group_number": {
+                    "type": "string"
+                },
+                "locations": {
+                    "description": "location of each entry in Times, may be shorter",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "practical_weight": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "professor_name": {
+                    "type": "string"
+                },
+                "semester_id": {
+                    "type": "string"
+                },
+                "time_exam": {
+                    "description": "HH:MM-HH:MM",
+                    "type": "string"
+                },
+                "times": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "university_id": {
+                    "type": "string"
+                },
+                "waiting_list": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "weight": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.CreateFacultyDTO": {
+            "type": "object",
+            "required": [
+                "name_en",
+                "name_fa",
+                "short_code",
+                "university_id"
+            ],
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name_en": {
+                    "type": "string"
+                },
+                "name_fa": {
+                    "type": "string"
+                },
+                "short_code": {
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "university_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateProfessorRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "university_id"
+            ],
+            "properties": {
+                "academic_rank": {
+                    "type": "string",
+                    "enum": [
+                        "lecturer",
+                        "instructor",
+                        "assistant_professor",
+                        "associate_professor",
+                        "professor"
+                    ]
+                },
+                "email": {
+                    "type": "string"
+                },
+                "faculty_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "university_id": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateScheduleRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "semester_id"
+            ],
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "semester_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateScheduleShareRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateSemesterRequest": {
+            "type": "object",
+            "required": [
+                "term",
+                "year"
+            ],
+            "properties": {
+                "term": {
+                    "type": "string",
+                    "enum": [
+                        "fall",
+                        "spring",
+                        "summer"
+                    ]
+                },
+                "year": {
+                    "type": "integer",
+                    "maximum": 3000,
+                    "minimum": 1000
+                }
             }
-        }
-    },
-    "definitions": {
-        "dto.AdminUpdateUserRequest": {
+        },
+        "dto.CreateUniversityRequest": {
             "type": "object",
+            "required": [
+                "is_active",
+                "name_en",
+                "name_fa"
+            ],
             "properties": {
-                "faculty_id": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name_en": {
                     "type": "string"
                 },
-                "first_name": {
+                "name_fa": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExamCalendarEntry": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "string"
                 },
-                "gender": {
-                    "type": "string",
-                    "enum": [
-                        "male",
-                        "female"
-                    ]
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExamConflict"
+                    }
                 },
-                "last_name": {
+                "course_id": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "exam_date": {
+                    "type": "string"
                 },
-                "university_id": {
+                "exam_date_jalali": {
+                    "type": "string"
+                },
+                "exam_end": {
+                    "type": "string"
+                },
+                "exam_start": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "dto.AdminUserListResponse": {
+        "dto.ExamCalendarResponse": {
             "type": "object",
             "properties": {
-                "items": {
+                "exams": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.AdminUserResponse"
+                        "$ref": "#/definitions/dto.ExamCalendarEntry"
                     }
                 },
-                "limit": {
-                    "type": "integer"
+                "schedule_id": {
+                    "type": "string"
                 },
-                "page": {
-                    "type": "integer"
+                "semester_id": {
+                    "type": "string"
                 },
-                "total": {
-                    "type": "integer"
+                "without_exam": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
                 }
             }
         },
-        "dto.AdminUserResponse": {
+        "dto.ExamConflict": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "email": {
+                "course_id": {
                     "type": "string"
                 },
-                "email_verified": {
-                    "type": "boolean"
-                },
-                "faculty_id": {
+                "course_name": {
                     "type": "string"
                 },
-                "first_name": {
+                "kind": {
                     "type": "string"
-                },
-                "gender": {
+                }
+            }
+        },
+        "dto.FacultyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_admin": {
+                "is_active": {
                     "type": "boolean"
                 },
-                "last_name": {
+                "name_en": {
                     "type": "string"
                 },
-                "student_id": {
+                "name_fa": {
+                    "type": "string"
+                },
+                "short_code": {
                     "type": "string"
                 },
                 "university_id": {
@@ -2306,40 +5296,169 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.CourseResponse": {
+        "dto.ForgotPasswordRequest": {
             "type": "object",
+            "required": [
+                "email"
+            ],
             "properties": {
-                "capacity": {
-                    "type": "integer"
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GenerateSchedulesRequest": {
+            "type": "object",
+            "required": [
+                "course_codes"
+            ],
+            "properties": {
+                "course_codes": {
+                    "type": "array",
+                    "maxItems": 12,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "code": {
+                "limit": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "preferences": {
+                    "$ref": "#/definitions/dto.SchedulePreferences"
+                },
+                "semester_id": {
+                    "description": "The current semester when omitted",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GenerateSchedulesResponse": {
+            "type": "object",
+            "properties": {
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GeneratedSchedule"
+                    }
+                },
+                "semester_id": {
                     "type": "string"
                 },
-                "course_times": {
+                "total": {
+                    "type": "integer"
+                },
+                "truncated": {
+                    "type": "boolean"
+                },
+                "unavailable": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.CourseTimeResponse"
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.GeneratedSchedule": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
                     }
                 },
-                "created_at": {
+                "earliest_start": {
                     "type": "string"
                 },
-                "exam_end": {
+                "free_days": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "gap_minutes": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExamConflict"
+                    }
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
                     "type": "string"
                 },
-                "exam_start": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
                     "type": "string"
                 },
-                "faculty_id": {
+                "expires_in": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MergeProfessorsRequest": {
+            "type": "object",
+            "required": [
+                "source_ids"
+            ],
+            "properties": {
+                "source_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.ProfessorDetailResponse": {
+            "type": "object",
+            "properties": {
+                "academic_rank": {
                     "type": "string"
                 },
-                "faculty_name_en": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "faculty_name_fa": {
+                "email": {
                     "type": "string"
                 },
-                "gender_restriction": {
+                "faculty_id": {
                     "type": "string"
                 },
                 "id": {
@@ -2348,203 +5467,270 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "professor_id": {
+                "normalized_name": {
                     "type": "string"
                 },
-                "professor_name": {
+                "university": {
+                    "$ref": "#/definitions/dto.UniversityResponse"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "website": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.ProfessorDuplicateResponse": {
+            "type": "object",
+            "properties": {
+                "candidate": {
+                    "$ref": "#/definitions/dto.ProfessorMinimalResponse"
                 },
-                "semester_id": {
-                    "type": "string"
+                "professor": {
+                    "$ref": "#/definitions/dto.ProfessorMinimalResponse"
                 },
-                "university_id": {
-                    "type": "string"
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.ProfessorListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProfessorMinimalResponse"
+                    }
                 },
-                "updated_at": {
-                    "type": "string"
+                "limit": {
+                    "type": "integer"
                 },
-                "weight": {
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "dto.CourseTimeResponse": {
+        "dto.ProfessorMinimalResponse": {
             "type": "object",
             "properties": {
-                "course_id": {
+                "id": {
                     "type": "string"
                 },
-                "day_of_week": {
-                    "type": "integer"
-                },
-                "end_time": {
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "normalized_name": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.ProfessorSearchResult": {
+            "type": "object",
+            "properties": {
+                "professor": {
+                    "$ref": "#/definitions/dto.ProfessorMinimalResponse"
                 },
-                "start_time": {
-                    "type": "string"
+                "score": {
+                    "type": "number"
                 }
             }
         },
-        "dto.CreateCourseDTO": {
+        "dto.ProfessorTeachingResponse": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
+                },
+                "semester": {
+                    "$ref": "#/definitions/dto.SemesterResponse"
+                }
+            }
+        },
+        "dto.RegisterRequest": {
             "type": "object",
             "required": [
-                "code",
-                "date_exam",
-                "faculty_id",
+                "email",
+                "first_name",
                 "gender",
-                "name",
-                "professor_name",
-                "semester_id",
-                "time_exam",
-                "times",
-                "university_id",
-                "weight"
+                "last_name",
+                "password",
+                "student_id"
             ],
             "properties": {
-                "capacity": {
-                    "type": "integer",
-                    "minimum": 0
-                },
-                "code": {
+                "email": {
                     "type": "string"
                 },
-                "date_exam": {
+                "faculty_id": {
                     "type": "string"
                 },
-                "faculty_id": {
+                "first_name": {
                     "type": "string"
                 },
                 "gender": {
                     "type": "string",
                     "enum": [
                         "male",
-                        "female",
-                        "mixed"
+                        "female"
                     ]
                 },
-                "name": {
-                    "type": "string"
-                },
-                "professor_name": {
+                "last_name": {
                     "type": "string"
                 },
-                "semester_id": {
-                    "type": "string"
+                "password": {
+                    "type": "string",
+                    "minLength": 8
                 },
-                "time_exam": {
+                "student_id": {
                     "type": "string"
                 },
-                "times": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
                 "university_id": {
                     "type": "string"
-                },
-                "weight": {
-                    "type": "integer",
-                    "minimum": 1
                 }
             }
         },
-        "dto.CreateFacultyDTO": {
+        "dto.ResetPasswordRequest": {
             "type": "object",
             "required": [
-                "name_en",
-                "name_fa",
-                "short_code",
-                "university_id"
+                "password",
+                "token"
             ],
             "properties": {
-                "is_active": {
-                    "type": "boolean"
-                },
-                "name_en": {
-                    "type": "string"
-                },
-                "name_fa": {
-                    "type": "string"
-                },
-                "short_code": {
+                "password": {
                     "type": "string",
-                    "maxLength": 10
+                    "minLength": 8
                 },
-                "university_id": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateProfessorRequest": {
+        "dto.RoomOccupancyResponse": {
             "type": "object",
-            "required": [
-                "name",
-                "university_id"
-            ],
             "properties": {
-                "name": {
+                "location": {
                     "type": "string"
                 },
-                "university_id": {
+                "room": {
                     "type": "string"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RoomSlotResponse"
+                    }
                 }
             }
         },
-        "dto.CreateSemesterRequest": {
+        "dto.RoomSlotResponse": {
             "type": "object",
-            "required": [
-                "term",
-                "year"
-            ],
             "properties": {
-                "term": {
-                    "type": "string",
-                    "enum": [
-                        "spring",
-                        "fall"
-                    ]
+                "code": {
+                    "type": "string"
                 },
-                "year": {
-                    "type": "integer",
-                    "maximum": 3000,
-                    "minimum": 1000
+                "course_id": {
+                    "type": "string"
+                },
+                "day_of_week": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "half_term": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "week_parity": {
+                    "type": "string"
                 }
             }
         },
-        "dto.CreateUniversityRequest": {
+        "dto.ScheduleDiffResponse": {
             "type": "object",
-            "required": [
-                "is_active",
-                "name_en",
-                "name_fa"
-            ],
             "properties": {
-                "is_active": {
-                    "type": "boolean"
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
                 },
-                "name_en": {
+                "common": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
+                },
+                "other_schedule_id": {
                     "type": "string"
                 },
-                "name_fa": {
+                "other_weight": {
+                    "type": "integer"
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
+                },
+                "schedule_id": {
                     "type": "string"
+                },
+                "section_changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SectionChange"
+                    }
+                },
+                "weight": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.ErrorResponse": {
+        "dto.SchedulePreferences": {
             "type": "object",
             "properties": {
-                "error": {
+                "earliest_start": {
                     "type": "string"
+                },
+                "free_days": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "minimize_gaps": {
+                    "type": "boolean"
+                },
+                "preferred_professors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.FacultyResponse": {
+        "dto.ScheduleResponse": {
             "type": "object",
             "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -2554,16 +5740,13 @@ const docTemplate = `{
                 "is_active": {
                     "type": "boolean"
                 },
-                "name_en": {
-                    "type": "string"
-                },
-                "name_fa": {
+                "name": {
                     "type": "string"
                 },
-                "short_code": {
-                    "type": "string"
+                "position": {
+                    "type": "integer"
                 },
-                "university_id": {
+                "semester_id": {
                     "type": "string"
                 },
                 "updated_at": {
@@ -2571,51 +5754,62 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.ForgotPasswordRequest": {
+        "dto.ScheduleShareResponse": {
             "type": "object",
-            "required": [
-                "email"
-            ],
             "properties": {
-                "email": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.SearchResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseSearchResult"
+                    }
                 },
-                "password": {
+                "professors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProfessorSearchResult"
+                    }
+                },
+                "query": {
                     "type": "string"
                 }
             }
         },
-        "dto.LoginResponse": {
+        "dto.SectionChange": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "catalog_course_id": {
                     "type": "string"
                 },
-                "expires_in": {
-                    "type": "integer"
+                "from": {
+                    "$ref": "#/definitions/dto.CourseResponse"
+                },
+                "to": {
+                    "$ref": "#/definitions/dto.CourseResponse"
                 }
             }
         },
-        "dto.ProfessorDetailResponse": {
+        "dto.SemesterResponse": {
             "type": "object",
             "properties": {
-                "courses": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.CourseResponse"
-                    }
+                "code": {
+                    "type": "string"
                 },
                 "created_at": {
                     "type": "string"
@@ -2623,93 +5817,88 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "label": {
                     "type": "string"
                 },
-                "normalized_name": {
+                "term": {
                     "type": "string"
                 },
-                "university": {
-                    "$ref": "#/definitions/dto.UniversityResponse"
-                },
                 "updated_at": {
                     "type": "string"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.ProfessorMinimalResponse": {
+        "dto.SetUniversitySemesterRequest": {
             "type": "object",
             "properties": {
-                "id": {
+                "add_drop_end": {
                     "type": "string"
                 },
-                "name": {
+                "add_drop_start": {
                     "type": "string"
                 },
-                "normalized_name": {
+                "end_date": {
                     "type": "string"
-                }
-            }
-        },
-        "dto.RegisterRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "first_name",
-                "gender",
-                "last_name",
-                "password",
-                "student_id"
-            ],
-            "properties": {
-                "email": {
+                },
+                "exam_end": {
                     "type": "string"
                 },
-                "faculty_id": {
+                "exam_start": {
                     "type": "string"
                 },
-                "first_name": {
+                "is_current": {
+                    "type": "boolean"
+                },
+                "registration_end": {
                     "type": "string"
                 },
-                "gender": {
-                    "type": "string",
-                    "enum": [
-                        "male",
-                        "female"
-                    ]
+                "registration_start": {
+                    "type": "string"
                 },
-                "last_name": {
+                "start_date": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.SharedScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.CourseResponse"
+                    }
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "expires_at": {
+                    "type": "string"
                 },
-                "student_id": {
+                "name": {
                     "type": "string"
                 },
-                "university_id": {
+                "semester_id": {
                     "type": "string"
                 }
             }
         },
-        "dto.ResetPasswordRequest": {
+        "dto.SwapSectionRequest": {
             "type": "object",
             "required": [
-                "password",
-                "token"
+                "from_course_id",
+                "to_course_id"
             ],
             "properties": {
-                "password": {
-                    "type": "string",
-                    "minLength": 8
+                "from_course_id": {
+                    "type": "string"
                 },
-                "token": {
+                "to_course_id": {
                     "type": "string"
                 }
             }
         },
-        "dto.SemesterResponse": {
+        "dto.UniversityResponse": {
             "type": "object",
             "properties": {
                 "created_at": {
@@ -2718,33 +5907,58 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "term": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name_en": {
                     "type": "string"
                 },
-                "updated_at": {
+                "name_fa": {
                     "type": "string"
                 },
-                "year": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "dto.UniversityResponse": {
+        "dto.UniversitySemesterResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "add_drop_end": {
                     "type": "string"
                 },
-                "id": {
+                "add_drop_start": {
                     "type": "string"
                 },
-                "is_active": {
+                "end_date": {
+                    "type": "string"
+                },
+                "exam_end": {
+                    "type": "string"
+                },
+                "exam_start": {
+                    "type": "string"
+                },
+                "is_current": {
                     "type": "boolean"
                 },
-                "name_en": {
+                "registration_end": {
                     "type": "string"
                 },
-                "name_fa": {
+                "registration_start": {
+                    "type": "string"
+                },
+                "selection_open": {
+                    "description": "Whether courses can be added or removed now",
+                    "type": "boolean"
+                },
+                "semester": {
+                    "$ref": "#/definitions/dto.SemesterResponse"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "university_id": {
                     "type": "string"
                 },
                 "updated_at": {
@@ -2775,9 +5989,20 @@ const docTemplate = `{
                 "code": {
                     "type": "string"
                 },
+                "cross_campus": {
+                    "type": "boolean"
+                },
                 "date_exam": {
+                    "description": "Jalali (Solar Hijri) YYYY/MM/DD, e.g. 1404/04/07; Gregorian dates are rejected",
                     "type": "string"
                 },
+                "emergency_drop": {
+                    "type": "boolean"
+                },
+                "enrolled": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "faculty_id": {
                     "type": "string"
                 },
@@ -2789,9 +6014,26 @@ const docTemplate = `{
                         "mixed"
                     ]
                 },
+                "group_number": {
+                    "type": "string"
+                },
+                "locations": {
+                    "description": "location of each entry in Times, may be shorter",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string"
                 },
+                "notes": {
+                    "type": "string"
+                },
+                "practical_weight": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "professor_name": {
                     "type": "string"
                 },
@@ -2799,6 +6041,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "time_exam": {
+                    "description": "HH:MM-HH:MM",
                     "type": "string"
                 },
                 "times": {
@@ -2810,6 +6053,10 @@ const docTemplate = `{
                 "university_id": {
                     "type": "string"
                 },
+                "waiting_list": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "weight": {
                     "type": "integer",
                     "minimum": 1
@@ -2843,6 +6090,52 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.UpdateProfessorRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "academic_rank": {
+                    "type": "string",
+                    "enum": [
+                        "lecturer",
+                        "instructor",
+                        "assistant_professor",
+                        "associate_professor",
+                        "professor"
+                    ]
+                },
+                "email": {
+                    "type": "string"
+                },
+                "faculty_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
         "dto.UpdateSemesterRequest": {
             "type": "object",
             "required": [
@@ -2853,8 +6146,9 @@ const docTemplate = `{
                 "term": {
                     "type": "string",
                     "enum": [
+                        "fall",
                         "spring",
-                        "fall"
+                        "summer"
                     ]
                 },
                 "year": {
@@ -2893,6 +6187,136 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "schema.Course": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "cross_campus": {
+                    "type": "boolean"
+                },
+                "emergency_drop": {
+                    "type": "boolean"
+                },
+                "enrolled": {
+                    "type": "integer"
+                },
+                "exam": {
+                    "$ref": "#/definitions/schema.Exam"
+                },
+                "faculty": {
+                    "description": "Golestan's faculty code or Persian name",
+                    "type": "string"
+                },
+                "gender": {
+                    "$ref": "#/definitions/schema.Gender"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "practical_weight": {
+                    "type": "integer"
+                },
+                "professor": {
+                    "type": "string"
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schema.Session"
+                    }
+                },
+                "waiting_list": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schema.Exam": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Gregorian, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "date_jalali": {
+                    "description": "Solar Hijri, YYYY/MM/DD",
+                    "type": "string"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "start": {
+                    "description": "HH:MM, wall time of the university",
+                    "type": "string"
+                }
+            }
+        },
+        "schema.Gender": {
+            "type": "string",
+            "enum": [
+                "male",
+                "female",
+                "mixed"
+            ],
+            "x-enum-varnames": [
+                "GenderMale",
+                "GenderFemale",
+                "GenderMixed"
+            ]
+        },
+        "schema.Session": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "integer"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "half_term": {
+                    "type": "integer"
+                },
+                "location": {
+                    "description": "e.g. \"109- طبقه همکف سمت راست\"",
+                    "type": "string"
+                },
+                "room": {
+                    "description": "e.g. \"109\"",
+                    "type": "string"
+                },
+                "start": {
+                    "description": "HH:MM",
+                    "type": "string"
+                },
+                "week_parity": {
+                    "$ref": "#/definitions/schema.WeekParity"
+                }
+            }
+        },
+        "schema.WeekParity": {
+            "type": "string",
+            "enum": [
+                "odd",
+                "even"
+            ],
+            "x-enum-varnames": [
+                "WeekParityOdd",
+                "WeekParityEven"
+            ]
         }
     }
 }`
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"github.com/armanjr/termustat/engine/schema"
 	"github.com/google/uuid"
 	"time"
 )
@@ -16,9 +17,17 @@ type CreateCourseDTO struct {
 	Weight            int       `json:"weight" binding:"required,min=1"`
 	Capacity          int       `json:"capacity" binding:"min=0"`
 	GenderRestriction string    `json:"gender" binding:"required,oneof=male female mixed"`
+	GroupNumber       string    `json:"group_number"`
+	PracticalWeight   int       `json:"practical_weight" binding:"min=0"`
+	Enrolled          int       `json:"enrolled" binding:"min=0"`
+	WaitingList       int       `json:"waiting_list" binding:"min=0"`
+	Notes             string    `json:"notes"`
+	CrossCampus       bool      `json:"cross_campus"`
+	EmergencyDrop     bool      `json:"emergency_drop"`
 	Times             []string  `json:"times" binding:"required"`
-	TimeExam          string    `json:"time_exam" binding:"required"`
-	DateExam          string    `json:"date_exam" binding:"required"`
+	Locations         []string  `json:"locations"`                    // location of each entry in Times, may be shorter
+	TimeExam          string    `json:"time_exam" binding:"required"` // HH:MM-HH:MM
+	DateExam          string    `json:"date_exam" binding:"required"` // Jalali (Solar Hijri) YYYY/MM/DD, e.g. 1404/04/07; Gregorian dates are rejected
 }
 
 type UpdateCourseDTO struct {
@@ -31,28 +40,22 @@ type UpdateCourseDTO struct {
 	Weight            int       `json:"weight" binding:"required,min=1"`
 	Capacity          int       `json:"capacity" binding:"min=0"`
 	GenderRestriction string    `json:"gender" binding:"required,oneof=male female mixed"`
+	GroupNumber       string    `json:"group_number"`
+	PracticalWeight   int       `json:"practical_weight" binding:"min=0"`
+	Enrolled          int       `json:"enrolled" binding:"min=0"`
+	WaitingList       int       `json:"waiting_list" binding:"min=0"`
+	Notes             string    `json:"notes"`
+	CrossCampus       bool      `json:"cross_campus"`
+	EmergencyDrop     bool      `json:"emergency_drop"`
 	Times             []string  `json:"times" binding:"required"`
-	TimeExam          string    `json:"time_exam" binding:"required"`
-	DateExam          string    `json:"date_exam" binding:"required"`
-}
-
-type CourseEngineDTO struct {
-	UniversityID uuid.UUID `json:"university_id" binding:"required,uuid4"`
-	SemesterID   uuid.UUID `json:"semester_id" binding:"required,uuid4"`
-	CourseID     string    `json:"course_id" binding:"required"`
-	Name         string    `json:"name" binding:"required"`
-	Weight       int       `json:"weight" binding:"required,min=1"`
-	Capacity     int       `json:"capacity" binding:"min=0"`
-	Gender       string    `json:"gender" binding:"required,oneof=male female mixed"`
-	Professor    string    `json:"professor" binding:"required"`
-	Faculty      string    `json:"faculty" binding:"required"`
-	Time1        string    `json:"time1"`
-	Time2        string    `json:"time2"`
-	Time3        string    `json:"time3"`
-	Time4        string    `json:"time4"`
-	Time5        string    `json:"time5"`
-	TimeExam     string    `json:"time_exam" binding:"required"`
-	DateExam     string    `json:"date_exam" binding:"required"`
+	Locations         []string  `json:"locations"`                    // location of each entry in Times, may be shorter
+	TimeExam          string    `json:"time_exam" binding:"required"` // HH:MM-HH:MM
+	DateExam          string    `json:"date_exam" binding:"required"` // Jalali (Solar Hijri) YYYY/MM/DD, e.g. 1404/04/07; Gregorian dates are rejected
+}
+
+type SwapSectionRequest struct {
+	FromCourseID uuid.UUID `json:"from_course_id" binding:"required"`
+	ToCourseID   uuid.UUID `json:"to_course_id" binding:"required"`
 }
 
 type BatchCreateCoursesDTO struct {
@@ -60,18 +63,22 @@ type BatchCreateCoursesDTO struct {
 }
 
 type BatchEngineCoursesDTO struct {
-	UniversityID uuid.UUID         `json:"university_id" binding:"required,uuid4"`
-	SemesterID   uuid.UUID         `json:"semester_id" binding:"required,uuid4"`
-	Courses      []CourseEngineDTO `json:"courses" binding:"required,min=1,dive"`
+	UniversityID uuid.UUID       `json:"university_id" binding:"required,uuid4"`
+	SemesterID   uuid.UUID       `json:"semester_id" binding:"required,uuid4"`
+	Courses      []schema.Course `json:"courses" binding:"required,min=1"`
 }
 
 // Response DTOs
 type CourseTimeResponse struct {
-	ID        uuid.UUID `json:"id"`
-	CourseID  uuid.UUID `json:"course_id"`
-	DayOfWeek int       `json:"day_of_week"`
-	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
+	ID         uuid.UUID `json:"id"`
+	CourseID   uuid.UUID `json:"course_id"`
+	DayOfWeek  int       `json:"day_of_week"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	Room       string    `json:"room"`
+	Location   string    `json:"location"`
+	WeekParity string    `json:"week_parity,omitempty"`
+	HalfTerm   int       `json:"half_term,omitempty"`
 }
 
 type CourseResponse struct {
@@ -81,6 +88,7 @@ type CourseResponse struct {
 	FacultyNameEn     string               `json:"faculty_name_en"`
 	FacultyNameFa     string               `json:"faculty_name_fa"`
 	ProfessorID       uuid.UUID            `json:"professor_id"`
+	CatalogCourseID   uuid.UUID            `json:"catalog_course_id"`
 	ProfessorName     string               `json:"professor_name"`
 	SemesterID        uuid.UUID            `json:"semester_id"`
 	Code              string               `json:"code"`
@@ -88,21 +96,157 @@ type CourseResponse struct {
 	Weight            int                  `json:"weight"`
 	Capacity          int                  `json:"capacity"`
 	GenderRestriction string               `json:"gender_restriction"`
+	GroupNumber       string               `json:"group_number"`
+	PracticalWeight   int                  `json:"practical_weight"`
+	Enrolled          int                  `json:"enrolled"`
+	WaitingList       int                  `json:"waiting_list"`
+	Notes             string               `json:"notes"`
+	CrossCampus       bool                 `json:"cross_campus"`
+	EmergencyDrop     bool                 `json:"emergency_drop"`
 	ExamStart         time.Time            `json:"exam_start"`
 	ExamEnd           time.Time            `json:"exam_end"`
+	ExamDate          string               `json:"exam_date,omitempty"`        // Gregorian, YYYY-MM-DD
+	ExamDateJalali    string               `json:"exam_date_jalali,omitempty"` // Solar Hijri, YYYY/MM/DD
 	CourseTimes       []CourseTimeResponse `json:"course_times"`
 	CreatedAt         time.Time            `json:"created_at"`
 	UpdatedAt         time.Time            `json:"updated_at"`
 }
 
+// CourseSectionsResponse lists the sections of a course offered in a semester
+type CourseSectionsResponse struct {
+	CatalogCourseID uuid.UUID         `json:"catalog_course_id"`
+	SemesterID      uuid.UUID         `json:"semester_id"`
+	Code            string            `json:"code"`
+	Name            string            `json:"name"`
+	Weight          int               `json:"weight"`
+	Sections        []*CourseResponse `json:"sections"`
+}
+
+// RoomOccupancyResponse lists when a room is in use during a semester
+type RoomOccupancyResponse struct {
+	Room     string             `json:"room"`
+	Location string             `json:"location"`
+	Slots    []RoomSlotResponse `json:"slots"`
+}
+
+type RoomSlotResponse struct {
+	CourseID   uuid.UUID `json:"course_id"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	DayOfWeek  int       `json:"day_of_week"`
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	WeekParity string    `json:"week_parity,omitempty"`
+	HalfTerm   int       `json:"half_term,omitempty"`
+}
+
 // List Response
 type CourseListResponse struct {
 	Courses []*CourseResponse `json:"courses"`
 	Total   int64             `json:"total"`
 }
 
+type CourseFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+type CourseDiffEntry struct {
+	ID      uuid.UUID           `json:"id"`
+	Code    string              `json:"code"`
+	Name    string              `json:"name"`
+	Changes []CourseFieldChange `json:"changes,omitempty"`
+}
+
+type CourseImportDiffResponse struct {
+	Created   []CourseDiffEntry `json:"created"`
+	Updated   []CourseDiffEntry `json:"updated"`
+	Deleted   []CourseDiffEntry `json:"deleted"`
+	Unchanged int               `json:"unchanged"`
+}
+
+type CourseImportOptions struct {
+	UniversityID  uuid.UUID `json:"university_id"`
+	SemesterID    uuid.UUID `json:"semester_id"`
+	Mode          string    `json:"mode"`
+	DeleteMissing bool      `json:"delete_missing"`
+}
+
+// CourseImportRow is a row of an uploaded import file: the course read from
+// it, nil when the row has errors, and the problems found reading it. Index
+// counts from 1: the row of an HTML report, whose header is row 1, or the
+// position of a course in engine JSON.
+type CourseImportRow struct {
+	Index    int
+	Code     string
+	Name     string
+	Course   *schema.Course
+	Errors   []string
+	Warnings []string
+}
+
+type CommitCourseImportRequest struct {
+	PlanID uuid.UUID `json:"plan_id" binding:"required"`
+}
+
+// CourseImportPreviewRow is the outcome of importing a row, numbered by
+// Index as in CourseImportRow. Course is missing for rows that could not be
+// read.
+type CourseImportPreviewRow struct {
+	Index    int                 `json:"index"`
+	Code     string              `json:"code"`
+	Name     string              `json:"name"`
+	Action   string              `json:"action"`
+	Course   *schema.Course      `json:"course,omitempty"`
+	Changes  []CourseFieldChange `json:"changes,omitempty"`
+	Errors   []string            `json:"errors,omitempty"`
+	Warnings []string            `json:"warnings,omitempty"`
+}
+
+type CourseImportPreviewResponse struct {
+	PlanID             *uuid.UUID               `json:"plan_id,omitempty"`
+	ExpiresAt          *time.Time               `json:"expires_at,omitempty"`
+	Valid              bool                     `json:"valid"`
+	Rows               []CourseImportPreviewRow `json:"rows"`
+	NewProfessors      []string                 `json:"new_professors"`
+	UnmatchedFaculties []string                 `json:"unmatched_faculties"`
+	Diff               CourseImportDiffResponse `json:"diff"`
+}
+
+// CourseCatalogFilters narrows the course catalog of a university's semester.
+// Day, From and To keep courses with a session matching all of them; Gender
+// keeps sections open to that gender, mixed sections included.
+type CourseCatalogFilters struct {
+	UniversityID uuid.UUID `form:"-"`
+	SemesterID   uuid.UUID `form:"-"`
+	FacultyID    uuid.UUID `form:"-"`
+	ProfessorID  uuid.UUID `form:"-"`
+	Day          *int      `form:"day" binding:"omitempty,min=0,max=6"`
+	From         string    `form:"from"` // HH:MM
+	To           string    `form:"to"`   // HH:MM
+	Weight       int       `form:"weight" binding:"min=0"`
+	Gender       string    `form:"gender" binding:"omitempty,oneof=male female mixed"`
+	HasFreeSeats bool      `form:"has_free_seats"` // as in CourseSearchFilters
+	Sort         string    `form:"sort" binding:"omitempty,oneof=code name weight capacity free_seats exam"`
+	Order        string    `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type CourseCatalogResponse struct {
+	Items []CourseResponse `json:"items"`
+	Total int64            `json:"total"`
+	Page  int              `json:"page"`
+	Limit int              `json:"limit"`
+}
+
 type CourseSearchFilters struct {
 	FacultyID   uuid.UUID `form:"faculty_id"`
 	ProfessorID uuid.UUID `form:"professor_id"`
 	Query       string    `form:"q"`
+	// HasFreeSeats keeps only sections with fewer students than their
+	// capacity, both as Golestan reports them enrolled and as selected in
+	// active schedules, the count course selection checks
+	HasFreeSeats  bool  `form:"has_free_seats"`
+	CrossCampus   *bool `form:"cross_campus"`
+	EmergencyDrop *bool `form:"emergency_drop"`
 }
@@ -15,13 +15,58 @@ type ProfessorDetailResponse struct {
 	ID             uuid.UUID          `json:"id"`
 	Name           string             `json:"name"`
 	NormalizedName string             `json:"normalized_name"`
+	Email          string             `json:"email,omitempty"`
+	FacultyID      *uuid.UUID         `json:"faculty_id,omitempty"`
+	AcademicRank   string             `json:"academic_rank,omitempty"`
+	Website        string             `json:"website,omitempty"`
 	University     UniversityResponse `json:"university"`
 	Courses        []CourseResponse   `json:"courses"`
+	Aliases        []string           `json:"aliases"`
 	CreatedAt      time.Time          `json:"created_at"`
 	UpdatedAt      time.Time          `json:"updated_at"`
 }
 
 type CreateProfessorRequest struct {
-	UniversityID uuid.UUID `json:"university_id" binding:"required"`
-	Name         string    `json:"name" binding:"required"`
+	UniversityID uuid.UUID  `json:"university_id" binding:"required"`
+	Name         string     `json:"name" binding:"required"`
+	Email        string     `json:"email" binding:"omitempty,email"`
+	FacultyID    *uuid.UUID `json:"faculty_id"`
+	AcademicRank string     `json:"academic_rank" binding:"omitempty,oneof=lecturer instructor assistant_professor associate_professor professor"`
+	Website      string     `json:"website" binding:"omitempty,url"`
+}
+
+// UpdateProfessorRequest replaces a professor's name and details. A new name
+// keeps the old one as an alias, so later imports still resolve to them.
+type UpdateProfessorRequest struct {
+	Name         string     `json:"name" binding:"required"`
+	Email        string     `json:"email" binding:"omitempty,email"`
+	FacultyID    *uuid.UUID `json:"faculty_id"`
+	AcademicRank string     `json:"academic_rank" binding:"omitempty,oneof=lecturer instructor assistant_professor associate_professor professor"`
+	Website      string     `json:"website" binding:"omitempty,url"`
+}
+
+type ProfessorListResponse struct {
+	Items []ProfessorMinimalResponse `json:"items"`
+	Total int64                      `json:"total"`
+	Page  int                        `json:"page"`
+	Limit int                        `json:"limit"`
+}
+
+// ProfessorTeachingResponse lists the courses a professor taught in a semester
+type ProfessorTeachingResponse struct {
+	Semester SemesterResponse  `json:"semester"`
+	Courses  []*CourseResponse `json:"courses"`
+}
+
+// ProfessorDuplicateResponse pairs two professors whose names likely belong to
+// the same person; a Score of 1 means they differ only in titles, spelling
+// variants or word order
+type ProfessorDuplicateResponse struct {
+	Professor ProfessorMinimalResponse `json:"professor"`
+	Candidate ProfessorMinimalResponse `json:"candidate"`
+	Score     float64                  `json:"score"`
+}
+
+type MergeProfessorsRequest struct {
+	SourceIDs []uuid.UUID `json:"source_ids" binding:"required,min=1"`
 }
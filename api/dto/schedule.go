@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CreateScheduleRequest struct {
+	SemesterID uuid.UUID `json:"semester_id" binding:"required"`
+	Name       string    `json:"name" binding:"required,max=100"`
+	IsActive   bool      `json:"is_active"`
+}
+
+// UpdateScheduleRequest changes only the fields that are set. A schedule is
+// deactivated by activating another one.
+type UpdateScheduleRequest struct {
+	Name     string `json:"name" binding:"omitempty,max=100"`
+	Position *int   `json:"position" binding:"omitempty,min=0"`
+	IsActive *bool  `json:"is_active"`
+}
+
+type CloneScheduleRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type ScheduleResponse struct {
+	ID         uuid.UUID        `json:"id"`
+	SemesterID uuid.UUID        `json:"semester_id"`
+	Name       string           `json:"name"`
+	Position   int              `json:"position"`
+	IsActive   bool             `json:"is_active"`
+	Courses    []CourseResponse `json:"courses,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// SectionChange is a course taken in both schedules in different sections
+type SectionChange struct {
+	CatalogCourseID uuid.UUID      `json:"catalog_course_id"`
+	From            CourseResponse `json:"from"`
+	To              CourseResponse `json:"to"`
+}
+
+// ScheduleDiffResponse compares a schedule with another one: Added and
+// Removed are the courses only the other or only this schedule has.
+type ScheduleDiffResponse struct {
+	ScheduleID      uuid.UUID        `json:"schedule_id"`
+	OtherScheduleID uuid.UUID        `json:"other_schedule_id"`
+	Added           []CourseResponse `json:"added"`
+	Removed         []CourseResponse `json:"removed"`
+	SectionChanges  []SectionChange  `json:"section_changes"`
+	Common          []CourseResponse `json:"common"`
+	Weight          int              `json:"weight"`
+	OtherWeight     int              `json:"other_weight"`
+}
+
+// CreateScheduleShareRequest publishes a schedule; without ExpiresAt the link
+// stays valid until revoked
+type CreateScheduleShareRequest struct {
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type ScheduleShareResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Token     string     `json:"token"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// SharedScheduleResponse is the public view of a shared schedule. It carries
+// nothing about the schedule's owner.
+type SharedScheduleResponse struct {
+	Name       string           `json:"name"`
+	SemesterID uuid.UUID        `json:"semester_id"`
+	Courses    []CourseResponse `json:"courses"`
+	ExpiresAt  *time.Time       `json:"expires_at"`
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/google/uuid"
+
+type SearchRequest struct {
+	Query        string    `form:"q" binding:"required"`
+	UniversityID uuid.UUID `form:"-"`
+	SemesterID   uuid.UUID `form:"-"`
+	Limit        int       `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+type CourseSearchResult struct {
+	Course CourseResponse `json:"course"`
+	Score  float64        `json:"score"`
+}
+
+type ProfessorSearchResult struct {
+	Professor ProfessorMinimalResponse `json:"professor"`
+	Score     float64                  `json:"score"`
+}
+
+// SearchResponse lists courses and professors matching a query, best first
+type SearchResponse struct {
+	Query      string                  `json:"query"`
+	Courses    []CourseSearchResult    `json:"courses"`
+	Professors []ProfessorSearchResult `json:"professors"`
+}
@@ -7,18 +7,53 @@ import (
 
 type CreateSemesterRequest struct {
 	Year int    `json:"year" binding:"required,min=1000,max=3000"`
-	Term string `json:"term" binding:"required,oneof=spring fall"`
+	Term string `json:"term" binding:"required,oneof=fall spring summer"`
 }
 
 type UpdateSemesterRequest struct {
 	Year int    `json:"year" binding:"required,min=1000,max=3000"`
-	Term string `json:"term" binding:"required,oneof=spring fall"`
+	Term string `json:"term" binding:"required,oneof=fall spring summer"`
 }
 
+// Year is the Jalali academic year the semester starts, e.g. 1403 for
+// 1403-1404; Code is its Golestan term code, e.g. 4031
 type SemesterResponse struct {
 	ID        uuid.UUID `json:"id"`
 	Year      int       `json:"year"`
 	Term      string    `json:"term"`
+	Code      string    `json:"code,omitempty"`
+	Label     string    `json:"label"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// SetUniversitySemesterRequest sets a university's calendar for a semester.
+// Each window and date range is optional but needs both ends; class and exam
+// dates are Gregorian, formatted YYYY-MM-DD.
+type SetUniversitySemesterRequest struct {
+	StartDate         string     `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate           string     `json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	RegistrationStart *time.Time `json:"registration_start"`
+	RegistrationEnd   *time.Time `json:"registration_end"`
+	AddDropStart      *time.Time `json:"add_drop_start"`
+	AddDropEnd        *time.Time `json:"add_drop_end"`
+	ExamStart         string     `json:"exam_start" binding:"omitempty,datetime=2006-01-02"`
+	ExamEnd           string     `json:"exam_end" binding:"omitempty,datetime=2006-01-02"`
+	IsCurrent         bool       `json:"is_current"`
+}
+
+type UniversitySemesterResponse struct {
+	UniversityID      uuid.UUID        `json:"university_id"`
+	Semester          SemesterResponse `json:"semester"`
+	StartDate         string           `json:"start_date,omitempty"`
+	EndDate           string           `json:"end_date,omitempty"`
+	RegistrationStart *time.Time       `json:"registration_start,omitempty"`
+	RegistrationEnd   *time.Time       `json:"registration_end,omitempty"`
+	AddDropStart      *time.Time       `json:"add_drop_start,omitempty"`
+	AddDropEnd        *time.Time       `json:"add_drop_end,omitempty"`
+	ExamStart         string           `json:"exam_start,omitempty"`
+	ExamEnd           string           `json:"exam_end,omitempty"`
+	IsCurrent         bool             `json:"is_current"`
+	SelectionOpen     bool             `json:"selection_open"` // Whether courses can be added or removed now
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ExamConflict is a clash between an exam and the exam of another selected
+// course. Kind is "overlap" or "same_day".
+type ExamConflict struct {
+	CourseID   uuid.UUID `json:"course_id"`
+	CourseName string    `json:"course_name"`
+	Kind       string    `json:"kind"`
+}
+
+type CourseSelectionResponse struct {
+	Message  string         `json:"message"`
+	Warnings []ExamConflict `json:"warnings,omitempty"`
+}
+
+type ExamCalendarEntry struct {
+	CourseID       uuid.UUID      `json:"course_id"`
+	Code           string         `json:"code"`
+	Name           string         `json:"name"`
+	ExamStart      time.Time      `json:"exam_start"`
+	ExamEnd        time.Time      `json:"exam_end"`
+	ExamDate       string         `json:"exam_date"`
+	ExamDateJalali string         `json:"exam_date_jalali"`
+	Conflicts      []ExamConflict `json:"conflicts"`
+}
+
+// ExamCalendarResponse lists the exams of a schedule in chronological order.
+// WithoutExam holds selected courses with no exam, such as projects.
+type ExamCalendarResponse struct {
+	SemesterID  uuid.UUID           `json:"semester_id"`
+	ScheduleID  uuid.UUID           `json:"schedule_id"`
+	Exams       []ExamCalendarEntry `json:"exams"`
+	WithoutExam []CourseResponse    `json:"without_exam"`
+}
+
+// SchedulePreferences rank generated schedules. FreeDays are days of the week
+// (0 is Saturday) to keep free of classes, EarliestStart is the HH:MM time
+// before which classes are unwelcome.
+type SchedulePreferences struct {
+	FreeDays            []int       `json:"free_days" binding:"omitempty,dive,min=0,max=6"`
+	EarliestStart       string      `json:"earliest_start"`
+	MinimizeGaps        bool        `json:"minimize_gaps"`
+	PreferredProfessors []uuid.UUID `json:"preferred_professors"`
+}
+
+type GenerateSchedulesRequest struct {
+	SemesterID  uuid.UUID           `json:"semester_id"` // The current semester when omitted
+	CourseCodes []string            `json:"course_codes" binding:"required,min=1,max=12"`
+	Preferences SchedulePreferences `json:"preferences"`
+	Limit       int                 `json:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// GeneratedSchedule is one conflict-free choice of sections. Warnings hold
+// the exam clashes the policy allows, one per pair of clashing courses.
+type GeneratedSchedule struct {
+	Score         int              `json:"score"`
+	Courses       []CourseResponse `json:"courses"`
+	FreeDays      []int            `json:"free_days"`
+	EarliestStart string           `json:"earliest_start"`
+	GapMinutes    int              `json:"gap_minutes"`
+	Warnings      []ExamConflict   `json:"warnings,omitempty"`
+}
+
+// GenerateSchedulesResponse holds the best ranked schedules out of Total.
+// Truncated means the search stopped at its limit before trying every
+// combination. Unavailable lists requested codes with no section the user can
+// take, in which case no schedule is possible.
+type GenerateSchedulesResponse struct {
+	SemesterID  uuid.UUID           `json:"semester_id"`
+	Total       int                 `json:"total"`
+	Truncated   bool                `json:"truncated"`
+	Schedules   []GeneratedSchedule `json:"schedules"`
+	Unavailable []string            `json:"unavailable"`
+}
@@ -38,6 +38,10 @@ type ExpiredTokenError struct {
 	Entity string
 	err    error
 }
+type ForbiddenError struct {
+	Entity string
+	err    error
+}
 
 func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("%s with ID %s not found", e.Entity, e.ID)
@@ -67,6 +71,13 @@ func (e *ExpiredTokenError) Unwrap() error {
 	return e.err
 }
 
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("%s is not allowed", e.Entity)
+}
+func (e *ForbiddenError) Unwrap() error {
+	return e.err
+}
+
 // Error constructors
 func NewNotFoundError(entity, id string) error {
 	return &NotFoundError{
@@ -93,6 +104,12 @@ func NewExpiredTokenError(entity string) error {
 		err:    ErrExpiredToken,
 	}
 }
+func NewForbiddenError(entity string) error {
+	return &ForbiddenError{
+		Entity: entity,
+		err:    ErrForbidden,
+	}
+}
 
 // Wrap wraps an error with additional context
 func Wrap(err error, message string) error {
@@ -40,18 +40,7 @@ func (h *AdminUserHandler) GetAll(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
 	defer cancel()
 
-	pagination := &dto.PaginationQuery{
-		Page:  parseInt(c.DefaultQuery("page", "1")),
-		Limit: parseInt(c.DefaultQuery("limit", "10")),
-	}
-
-	if pagination.Page < 1 {
-		pagination.Page = 1
-	}
-	if pagination.Limit < 1 || pagination.Limit > 100 {
-		pagination.Limit = 10
-	}
-	pagination.Offset = (pagination.Page - 1) * pagination.Limit
+	pagination := parsePagination(c)
 
 	result, err := h.adminUserService.GetAll(ctx, pagination)
 	if err != nil {
@@ -225,6 +214,24 @@ func (h *AdminUserHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
 }
 
+// parsePagination reads the page and limit query parameters, defaulting to the
+// first page of 10 items
+func parsePagination(c *gin.Context) *dto.PaginationQuery {
+	pagination := &dto.PaginationQuery{
+		Page:  parseInt(c.DefaultQuery("page", "1")),
+		Limit: parseInt(c.DefaultQuery("limit", "10")),
+	}
+
+	if pagination.Page < 1 {
+		pagination.Page = 1
+	}
+	if pagination.Limit < 1 || pagination.Limit > 100 {
+		pagination.Limit = 10
+	}
+	pagination.Offset = (pagination.Page - 1) * pagination.Limit
+	return pagination
+}
+
 func parseInt(str string) int {
 	val, err := strconv.Atoi(str)
 	if err != nil {
@@ -1,30 +1,42 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/armanjr/termustat/api/dto"
 	"github.com/armanjr/termustat/api/errors"
 	"github.com/armanjr/termustat/api/services"
+	"github.com/armanjr/termustat/engine/parser"
+	"github.com/armanjr/termustat/engine/schema"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"mime/multipart"
 	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
 )
 
 type CourseHandler struct {
-	service services.CourseService
-	logger  *zap.Logger
+	service   services.CourseService
+	semesters services.SemesterService
+	profiles  string // directory of the column profiles of Golestan reports
+	logger    *zap.Logger
 }
 
-func NewCourseHandler(service services.CourseService, logger *zap.Logger) *CourseHandler {
+func NewCourseHandler(service services.CourseService, semesters services.SemesterService, profiles string, logger *zap.Logger) *CourseHandler {
 	return &CourseHandler{
-		service: service,
-		logger:  logger,
+		service:   service,
+		semesters: semesters,
+		profiles:  profiles,
+		logger:    logger,
 	}
 }
 
 // Create a new course
 // @Summary      Create a course
-// @Description  Creates a new course in the system
+// @Description  Creates a new course in the system. date_exam is a Jalali date (e.g. 1404/04/07); Gregorian dates are rejected.
 // @Tags         courses
 // @Accept       json
 // @Produce      json
@@ -101,6 +113,80 @@ func (h *CourseHandler) Get(c *gin.Context) {
 	c.JSON(http.StatusOK, course)
 }
 
+// GetSections lists the sections of a course
+// @Summary      List course sections
+// @Description  Lists every section (group) of the same course offered in the course's semester
+// @Tags         courses
+// @Produce      json
+// @Param        id   path      string  true  "Course ID"
+// @Success      200  {object}  dto.CourseSectionsResponse
+// @Failure      400  {object}  dto.ErrorResponse  "Invalid ID format"
+// @Failure      404  {object}  dto.ErrorResponse  "Course not found"
+// @Failure      500  {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /courses/{id}/sections [get]
+func (h *CourseHandler) GetSections(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Warn("Invalid course ID format",
+			zap.String("id", c.Param("id")))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	sections, err := h.service.GetSections(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Course not found"})
+		default:
+			h.logger.Error("Failed to get course sections",
+				zap.String("id", id.String()),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, sections)
+}
+
+// GetRoomOccupancy lists when each room is in use
+// @Summary      Room occupancy
+// @Description  Groups the course times of a semester by room
+// @Tags         courses
+// @Produce      json
+// @Param        university_id  query     string  true  "University ID"
+// @Param        semester_id    query     string  true  "Semester ID"
+// @Success      200            {array}   dto.RoomOccupancyResponse
+// @Failure      400            {object}  dto.ErrorResponse  "Invalid query parameters"
+// @Failure      500            {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /courses/rooms [get]
+func (h *CourseHandler) GetRoomOccupancy(c *gin.Context) {
+	universityID, err := uuid.Parse(c.Query("university_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid university ID"})
+		return
+	}
+
+	semesterID, err := uuid.Parse(c.Query("semester_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid semester ID"})
+		return
+	}
+
+	rooms, err := h.service.GetRoomOccupancy(universityID, semesterID)
+	if err != nil {
+		h.logger.Error("Failed to get room occupancy",
+			zap.String("university_id", universityID.String()),
+			zap.String("semester_id", semesterID.String()),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, rooms)
+}
+
 // GetByFaculty retrieves all courses for a faculty
 // @Summary      List courses by faculty
 // @Description  Retrieves all courses under the specified faculty
@@ -135,7 +221,7 @@ func (h *CourseHandler) GetByFaculty(c *gin.Context) {
 
 // Update handles course updates
 // @Summary      Update a course
-// @Description  Updates the course identified by its ID
+// @Description  Updates the course identified by its ID. date_exam is a Jalali date (e.g. 1404/04/07); Gregorian dates are rejected.
 // @Tags         courses
 // @Accept       json
 // @Produce      json
@@ -231,6 +317,9 @@ func (h *CourseHandler) Delete(c *gin.Context) {
 // @Param        faculty_id    query     string  false  "Filter by Faculty ID"
 // @Param        professor_id  query     string  false  "Filter by Professor ID"
 // @Param        q             query     string  false  "Full‐text search query"
+// @Param        has_free_seats query    bool    false  "Only sections with free seats, by both Golestan's enrolled count and the selections of active schedules"
+// @Param        cross_campus  query     bool    false  "Filter by availability to other campuses"
+// @Param        emergency_drop query    bool    false  "Filter by emergency-drop permission"
 // @Success      200           {array}   dto.CourseResponse
 // @Failure      400           {object}  dto.ErrorResponse  "Invalid query parameters"
 // @Failure      500           {object}  dto.ErrorResponse  "Internal server error"
@@ -253,3 +342,428 @@ func (h *CourseHandler) Search(c *gin.Context) {
 
 	c.JSON(http.StatusOK, courses)
 }
+
+// Catalog lists the courses a university offers in a semester
+// @Summary      Browse the course catalog
+// @Description  Lists a page of the courses a university offers in a semester. Day, from and to keep courses with a session matching all of them.
+// @Tags         courses
+// @Produce      json
+// @Param        university_id   query     string  true   "University ID"
+// @Param        semester_id     query     string  true   "Semester ID"
+// @Param        faculty_id      query     string  false  "Filter by Faculty ID"
+// @Param        professor_id    query     string  false  "Filter by Professor ID"
+// @Param        day             query     int     false  "Day of week, 0 (Saturday) to 6"
+// @Param        from            query     string  false  "Sessions starting at or after, HH:MM"
+// @Param        to              query     string  false  "Sessions ending at or before, HH:MM"
+// @Param        weight          query     int     false  "Filter by weight"
+// @Param        gender          query     string  false  "Sections open to male or female students, or only mixed ones"
+// @Param        has_free_seats  query     bool    false  "Only sections with free seats, by both Golestan's enrolled count and the selections of active schedules"
+// @Param        sort            query     string  false  "code (default), name, weight, capacity, free_seats or exam"
+// @Param        order           query     string  false  "asc (default) or desc"
+// @Param        page            query     int     false  "Page number"     default(1)
+// @Param        limit           query     int     false  "Items per page"  default(10)
+// @Success      200             {object}  dto.CourseCatalogResponse
+// @Failure      400             {object}  dto.ErrorResponse  "Invalid query parameters"
+// @Failure      404             {object}  dto.ErrorResponse  "University or semester not found"
+// @Failure      500             {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/catalog/courses [get]
+func (h *CourseHandler) Catalog(c *gin.Context) {
+	var filters dto.CourseCatalogFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		h.logger.Warn("Invalid catalog filters",
+			zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid catalog parameters"})
+		return
+	}
+
+	ids := map[string]*uuid.UUID{
+		"university_id": &filters.UniversityID,
+		"semester_id":   &filters.SemesterID,
+		"faculty_id":    &filters.FacultyID,
+		"professor_id":  &filters.ProfessorID,
+	}
+	for param, id := range ids {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+			return
+		}
+		*id = parsed
+	}
+
+	catalog, err := h.service.Catalog(&filters, parsePagination(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to fetch course catalog", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, catalog)
+}
+
+// Import bulk-imports courses from a Golestan export
+// @Summary      Import courses
+// @Description  Imports courses for a university and semester from a Golestan HTML export or the engine's JSON output. A report with rows that cannot be read is rejected; preview it to see them.
+// @Tags         courses
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        university_id  formData  string  true  "University ID"
+// @Param        semester_id    formData  string  false "Semester ID, required without term_code"
+// @Param        term_code      formData  string  false "Golestan term code of the semester, e.g. 4031"
+// @Param        file           formData  file    true  "Golestan HTML export or engine JSON"
+// @Param        profile        formData  string  false "Column profile of an HTML export, default for Golestan's standard layout"
+// @Param        mode           formData  string  false "create (default) fails on existing codes; sync creates, updates and optionally deletes"
+// @Param        delete_missing formData  bool    false "In sync mode, delete courses of the semester missing from the file"
+// @Success      200            {object}  dto.CourseImportDiffResponse  "Sync mode"
+// @Success      201            {object}  dto.CourseListResponse
+// @Failure      400            {object}  dto.ErrorResponse  "Invalid file, unknown profile or faculty, or invalid course data"
+// @Failure      409            {object}  dto.ErrorResponse  "Conflict (e.g. duplicate code)"
+// @Failure      500            {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /courses/import [post]
+func (h *CourseHandler) Import(c *gin.Context) {
+	opts, rows, ok := h.bindImport(c)
+	if !ok {
+		return
+	}
+	universityID, semesterID := opts.UniversityID, opts.SemesterID
+
+	courses, err := importCourses(rows)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if opts.Mode == "sync" {
+		h.sync(c, universityID, semesterID, courses, opts.DeleteMissing)
+		return
+	}
+
+	created, err := h.service.ImportFromEngine(universityID, semesterID, courses)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to import courses",
+				zap.String("university_id", universityID.String()),
+				zap.String("semester_id", semesterID.String()),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.CourseListResponse{
+		Courses: created,
+		Total:   int64(len(created)),
+	})
+}
+
+func (h *CourseHandler) sync(c *gin.Context, universityID, semesterID uuid.UUID, courses []schema.Course, deleteMissing bool) {
+	report, err := h.service.SyncFromEngine(universityID, semesterID, courses, deleteMissing)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to sync courses",
+				zap.String("university_id", universityID.String()),
+				zap.String("semester_id", semesterID.String()),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// PreviewImport godoc
+// @Summary      Preview a course import
+// @Description  Reports what importing the file would create, update or delete without writing anything, listing the rows that cannot be read as invalid and the warnings of every row. A valid preview returns a plan ID to commit.
+// @Tags         courses
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        university_id  formData  string  true  "University ID"
+// @Param        semester_id    formData  string  false "Semester ID, required without term_code"
+// @Param        term_code      formData  string  false "Golestan term code of the semester, e.g. 4031"
+// @Param        file           formData  file    true  "Golestan HTML export or engine JSON"
+// @Param        profile        formData  string  false "Column profile of an HTML export, default for Golestan's standard layout"
+// @Param        mode           formData  string  false "create (default) or sync"
+// @Param        delete_missing formData  bool    false "In sync mode, delete courses of the semester missing from the file"
+// @Success      200            {object}  dto.CourseImportPreviewResponse
+// @Failure      400            {object}  dto.ErrorResponse  "Invalid file, options or profile"
+// @Failure      500            {object}  dto.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
+// @Router       /courses/import/preview [post]
+func (h *CourseHandler) PreviewImport(c *gin.Context) {
+	opts, rows, ok := h.bindImport(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	preview, err := h.service.PreviewImport(opts, rows, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to preview course import",
+				zap.String("university_id", opts.UniversityID.String()),
+				zap.String("semester_id", opts.SemesterID.String()),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// CommitImport godoc
+// @Summary      Commit a previewed course import
+// @Description  Applies exactly the plan returned by the preview. Fails if the semester's courses, or the faculties and professors the plan resolves to, changed since the preview.
+// @Tags         courses
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CommitCourseImportRequest  true  "Plan to commit"
+// @Success      200      {object}  dto.CourseImportDiffResponse
+// @Failure      400      {object}  dto.ErrorResponse  "Invalid request or course data"
+// @Failure      404      {object}  dto.ErrorResponse  "Plan not found"
+// @Failure      409      {object}  dto.ErrorResponse  "Plan already committed or outdated"
+// @Failure      410      {object}  dto.ErrorResponse  "Plan expired"
+// @Failure      500      {object}  dto.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
+// @Router       /courses/import/commit [post]
+func (h *CourseHandler) CommitImport(c *gin.Context) {
+	var req dto.CommitCourseImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.service.CommitImport(req.PlanID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrExpiredToken):
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to commit course import",
+				zap.String("plan_id", req.PlanID.String()),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// bindImport reads the multipart import form shared by Import and PreviewImport,
+// writing the error response itself when the form is invalid
+func (h *CourseHandler) bindImport(c *gin.Context) (dto.CourseImportOptions, []dto.CourseImportRow, bool) {
+	var opts dto.CourseImportOptions
+
+	universityID, err := uuid.Parse(c.PostForm("university_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid university ID"})
+		return opts, nil, false
+	}
+
+	semesterID, ok := h.importSemester(c)
+	if !ok {
+		return opts, nil, false
+	}
+
+	mode := c.DefaultPostForm("mode", "create")
+	if mode != "create" && mode != "sync" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import mode"})
+		return opts, nil, false
+	}
+
+	deleteMissing, err := strconv.ParseBool(c.DefaultPostForm("delete_missing", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delete_missing value"})
+		return opts, nil, false
+	}
+
+	profile, err := parser.OpenProfile(h.profiles, c.PostForm("profile"))
+	if err != nil {
+		if errors.Is(err, parser.ErrUnknownProfile) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			h.logger.Error("Failed to load column profile",
+				zap.String("profile", c.PostForm("profile")),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return opts, nil, false
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return opts, nil, false
+	}
+
+	rows, err := readImportRows(file, profile)
+	if err != nil {
+		h.logger.Warn("Invalid course import file",
+			zap.String("filename", file.Filename),
+			zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return opts, nil, false
+	}
+
+	opts = dto.CourseImportOptions{
+		UniversityID:  universityID,
+		SemesterID:    semesterID,
+		Mode:          mode,
+		DeleteMissing: deleteMissing,
+	}
+	return opts, rows, true
+}
+
+// importSemester resolves the import's semester from semester_id, or from
+// term_code when the ID is not given
+func (h *CourseHandler) importSemester(c *gin.Context) (uuid.UUID, bool) {
+	code := c.PostForm("term_code")
+	if c.PostForm("semester_id") != "" || code == "" {
+		semesterID, err := uuid.Parse(c.PostForm("semester_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid semester ID"})
+			return uuid.Nil, false
+		}
+		return semesterID, true
+	}
+
+	semester, err := h.semesters.GetByCode(code)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid term code"})
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to resolve term code",
+				zap.String("term_code", code),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return uuid.Nil, false
+	}
+	return semester.ID, true
+}
+
+// readImportRows reads the rows of an uploaded Golestan HTML export with the
+// column profile of its layout, or the courses of the engine's JSON output
+// (the document of the process command or the /process response), which
+// must be of the schema version this API speaks
+func readImportRows(file *multipart.FileHeader, profile *parser.Profile) ([]dto.CourseImportRow, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file")
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".html", ".htm":
+		report, err := parser.ParseHTML(f, profile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process HTML file: %w", err)
+		}
+		rows := make([]dto.CourseImportRow, 0, len(report.Rows))
+		for _, row := range report.Rows {
+			rows = append(rows, reportRow(row))
+		}
+		return rows, nil
+	case ".json":
+		var document schema.Document
+		if err := json.NewDecoder(f).Decode(&document); err != nil {
+			return nil, fmt.Errorf("invalid engine JSON")
+		}
+		if err := document.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid engine JSON: %w", err)
+		}
+		// numbered from 1, as report rows are
+		rows := make([]dto.CourseImportRow, 0, len(document.Courses))
+		for i := range document.Courses {
+			course := &document.Courses[i]
+			rows = append(rows, dto.CourseImportRow{Index: i + 1, Code: course.Code, Name: course.Name, Course: course})
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("only HTML and JSON files are allowed")
+	}
+}
+
+// reportRow is a row of a report with its issues split by severity
+func reportRow(row parser.Row) dto.CourseImportRow {
+	importRow := dto.CourseImportRow{Index: row.Index, Course: row.Course}
+	if row.Record != nil {
+		importRow.Code = strings.TrimSpace(row.Record.CourseID)
+		importRow.Name = strings.TrimSpace(row.Record.Name)
+	}
+	for _, issue := range row.Issues {
+		if issue.Severity == parser.SeverityError {
+			importRow.Errors = append(importRow.Errors, issue.Message)
+		} else {
+			importRow.Warnings = append(importRow.Warnings, issue.Message)
+		}
+	}
+	return importRow
+}
+
+// importCourses returns the courses of the rows, or describes the rows that
+// could not be read
+func importCourses(rows []dto.CourseImportRow) ([]schema.Course, error) {
+	courses := make([]schema.Course, 0, len(rows))
+	var invalid []string
+	for _, row := range rows {
+		if row.Course == nil {
+			invalid = append(invalid, fmt.Sprintf("row %d: %s", row.Index, strings.Join(row.Errors, ", ")))
+			continue
+		}
+		courses = append(courses, *row.Course)
+	}
+	if len(invalid) == 0 {
+		return courses, nil
+	}
+
+	count := len(invalid)
+	if count > 5 {
+		invalid = append(invalid[:5], fmt.Sprintf("and %d more", count-5))
+	}
+	return nil, fmt.Errorf("%d rows of the report have errors (%s)", count, strings.Join(invalid, "; "))
+}
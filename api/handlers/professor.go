@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"net/http"
+	"strconv"
 )
 
 type ProfessorHandler struct {
@@ -90,15 +91,17 @@ func (h *ProfessorHandler) Get(c *gin.Context) {
 	c.JSON(http.StatusOK, professor)
 }
 
-// Create adds a new professor (or returns existing)
+// Create adds a new professor
 // @Summary      Create Professor
-// @Description  Creates a new professor under a university, or returns existing one
+// @Description  Creates a new professor under a university. Email, faculty, academic rank and website are optional. A name that already belongs to a professor, as their name or an alias, conflicts; change that professor with Update instead.
 // @Tags         professors
 // @Accept       json
 // @Produce      json
 // @Param        body  body      dto.CreateProfessorRequest  true  "Create professor payload"
-// @Success      201   {object}  dto.ProfessorMinimalResponse
-// @Failure      400   {object}  dto.ErrorResponse           "Invalid payload or university not found"
+// @Success      201   {object}  dto.ProfessorDetailResponse
+// @Failure      400   {object}  dto.ErrorResponse           "Invalid payload or faculty of another university"
+// @Failure      404   {object}  dto.ErrorResponse           "University not found"
+// @Failure      409   {object}  dto.ErrorResponse           "Professor with this name or alias exists"
 // @Failure      500   {object}  dto.ErrorResponse           "Internal server error"
 // @Router       /v1/admin/professors [post]
 // @Security     BearerAuth
@@ -110,12 +113,17 @@ func (h *ProfessorHandler) Create(c *gin.Context) {
 		return
 	}
 
-	professor, err := h.professorService.GetOrCreateByName(req.UniversityID, req.Name)
+	professor, err := h.professorService.Create(&req)
 	if err != nil {
 		switch {
 		case errors.Is(err, errors.ErrNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": "University not found"})
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		default:
+			h.logger.Error("Create professor error", zap.Error(err))
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 		}
 		return
@@ -123,3 +131,227 @@ func (h *ProfessorHandler) Create(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, professor)
 }
+
+// Search lists a university's professors by name
+// @Summary      Search Professors
+// @Description  Lists a page of a university's professors. With q, matches names regardless of Arabic letter variants, spacing and misspelled homophones, closest first; otherwise sorts by name.
+// @Tags         professors
+// @Produce      json
+// @Param        university_id  query     string                      true   "University ID"
+// @Param        q              query     string                      false  "Name to search for"
+// @Param        page           query     int                         false  "Page number"     default(1)
+// @Param        limit          query     int                         false  "Items per page"  default(10)
+// @Success      200            {object}  dto.ProfessorListResponse
+// @Failure      400            {object}  dto.ErrorResponse           "Invalid university ID or query"
+// @Failure      500            {object}  dto.ErrorResponse           "Internal server error"
+// @Router       /v1/admin/professors [get]
+// @Security     BearerAuth
+func (h *ProfessorHandler) Search(c *gin.Context) {
+	universityID, err := uuid.Parse(c.Query("university_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid university ID"})
+		return
+	}
+
+	professors, err := h.professorService.Search(universityID, c.Query("q"), parsePagination(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Search professors error", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, professors)
+}
+
+// Update replaces a professor's name and details
+// @Summary      Update Professor
+// @Description  Replaces a professor's name, email, faculty, academic rank and website. A new name keeps the old one as an alias so later imports still resolve to the professor.
+// @Tags         professors
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                      true  "Professor ID"
+// @Param        body  body      dto.UpdateProfessorRequest  true  "Update professor payload"
+// @Success      200   {object}  dto.ProfessorDetailResponse
+// @Failure      400   {object}  dto.ErrorResponse           "Invalid payload or faculty of another university"
+// @Failure      404   {object}  dto.ErrorResponse           "Professor not found"
+// @Failure      409   {object}  dto.ErrorResponse           "Name belongs to another professor"
+// @Failure      500   {object}  dto.ErrorResponse           "Internal server error"
+// @Router       /v1/admin/professors/{id} [put]
+// @Security     BearerAuth
+func (h *ProfessorHandler) Update(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid professor ID"})
+		return
+	}
+
+	var req dto.UpdateProfessorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request format", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	professor, err := h.professorService.Update(id, &req)
+	if err != nil {
+		h.handleError(c, "Update professor error", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, professor)
+}
+
+// Delete removes a professor
+// @Summary      Delete Professor
+// @Description  Deletes a professor who teaches no courses. Merge a duplicate professor instead of deleting it.
+// @Tags         professors
+// @Produce      json
+// @Param        id   path      string             true  "Professor ID"
+// @Success      200  {object}  map[string]string  "message: Professor deleted successfully"
+// @Failure      400  {object}  dto.ErrorResponse  "Invalid professor ID"
+// @Failure      404  {object}  dto.ErrorResponse  "Professor not found"
+// @Failure      409  {object}  dto.ErrorResponse  "Professor still has courses"
+// @Failure      500  {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/admin/professors/{id} [delete]
+// @Security     BearerAuth
+func (h *ProfessorHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid professor ID"})
+		return
+	}
+
+	if err := h.professorService.Delete(id); err != nil {
+		h.handleError(c, "Delete professor error", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Professor deleted successfully"})
+}
+
+// History lists the courses a professor taught per semester
+// @Summary      Professor Teaching History
+// @Description  Lists the courses a professor taught, grouped by semester, newest semester first
+// @Tags         professors
+// @Produce      json
+// @Param        id   path      string             true  "Professor ID"
+// @Success      200  {array}   dto.ProfessorTeachingResponse
+// @Failure      400  {object}  dto.ErrorResponse  "Invalid professor ID"
+// @Failure      404  {object}  dto.ErrorResponse  "Professor not found"
+// @Failure      500  {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/admin/professors/{id}/history [get]
+// @Security     BearerAuth
+func (h *ProfessorHandler) History(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid professor ID"})
+		return
+	}
+
+	history, err := h.professorService.History(id)
+	if err != nil {
+		h.handleError(c, "Professor history error", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// FindDuplicates suggests professors to merge
+// @Summary      Suggest Duplicate Professors
+// @Description  Lists pairs of a university's professors whose names likely belong to the same person, ignoring titles, spelling variants and word order. Best match first.
+// @Tags         professors
+// @Produce      json
+// @Param        id         path      string                  true   "University ID"
+// @Param        min_score  query     number                  false  "Minimum similarity from 0 to 1"  default(0.5)
+// @Success      200        {array}   dto.ProfessorDuplicateResponse
+// @Failure      400        {object}  dto.ErrorResponse       "Invalid university ID or score"
+// @Failure      500        {object}  dto.ErrorResponse       "Internal server error"
+// @Router       /v1/admin/universities/{id}/professors/duplicates [get]
+// @Security     BearerAuth
+func (h *ProfessorHandler) FindDuplicates(c *gin.Context) {
+	universityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid university ID"})
+		return
+	}
+
+	minScore, err := strconv.ParseFloat(c.DefaultQuery("min_score", "0.5"), 64)
+	if err != nil || minScore < 0 || minScore > 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_score"})
+		return
+	}
+
+	duplicates, err := h.professorService.FindDuplicates(universityID, minScore)
+	if err != nil {
+		h.logger.Error("Find duplicate professors error", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, duplicates)
+}
+
+// Merge folds duplicate professors into one
+// @Summary      Merge Professors
+// @Description  Moves the courses of the source professors to the target, keeps their names as its aliases so later imports resolve to it, and deletes them
+// @Tags         professors
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                      true  "Target professor ID"
+// @Param        body  body      dto.MergeProfessorsRequest  true  "Professors to merge into the target"
+// @Success      200   {object}  dto.ProfessorDetailResponse
+// @Failure      400   {object}  dto.ErrorResponse           "Invalid payload, or professors of another university"
+// @Failure      404   {object}  dto.ErrorResponse           "Professor not found"
+// @Failure      500   {object}  dto.ErrorResponse           "Internal server error"
+// @Router       /v1/admin/professors/{id}/merge [post]
+// @Security     BearerAuth
+func (h *ProfessorHandler) Merge(c *gin.Context) {
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid professor ID"})
+		return
+	}
+
+	var req dto.MergeProfessorsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid request format", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	professor, err := h.professorService.Merge(targetID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Merge professors error", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, professor)
+}
+
+func (h *ProfessorHandler) handleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, errors.ErrInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errors.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	}
+}
@@ -0,0 +1,517 @@
+package handlers
+
+import (
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+type ScheduleHandler struct {
+	service services.ScheduleService
+	logger  *zap.Logger
+}
+
+func NewScheduleHandler(service services.ScheduleService, logger *zap.Logger) *ScheduleHandler {
+	return &ScheduleHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Create adds a draft schedule
+// @Summary      Create Schedule
+// @Description  Creates a named draft schedule for a semester. The first schedule of a semester is always active.
+// @Tags         schedules
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.CreateScheduleRequest  true  "Create schedule payload"
+// @Success      201   {object}  dto.ScheduleResponse
+// @Failure      400   {object}  dto.ErrorResponse  "Invalid input"
+// @Failure      404   {object}  dto.ErrorResponse  "Semester not found"
+// @Failure      409   {object}  dto.ErrorResponse  "Schedule name already used"
+// @Failure      500   {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules [post]
+// @Security     BearerAuth
+func (h *ScheduleHandler) Create(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+
+	var req dto.CreateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid schedule request",
+			zap.Error(err),
+			zap.String("handler", "CreateSchedule"))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	schedule, err := h.service.Create(userID, &req)
+	if err != nil {
+		h.handleError(c, "Failed to create schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// GetAll lists the user's schedules of a semester
+// @Summary      List Schedules
+// @Description  Lists the current user's schedules of a semester in their order
+// @Tags         schedules
+// @Produce      json
+// @Param        semester_id  query     string  true  "Semester ID"
+// @Success      200          {array}   dto.ScheduleResponse
+// @Failure      400          {object}  dto.ErrorResponse  "Invalid semester ID"
+// @Failure      500          {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules [get]
+// @Security     BearerAuth
+func (h *ScheduleHandler) GetAll(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	semesterID, err := uuid.Parse(c.Query("semester_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid semester ID"})
+		return
+	}
+
+	schedules, err := h.service.GetAll(userID, semesterID)
+	if err != nil {
+		h.handleError(c, "Failed to fetch schedules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+// Get returns a schedule with its courses
+// @Summary      Get Schedule
+// @Description  Retrieves one of the current user's schedules with its courses
+// @Tags         schedules
+// @Produce      json
+// @Param        id   path      string  true  "Schedule ID"
+// @Success      200  {object}  dto.ScheduleResponse
+// @Failure      400  {object}  dto.ErrorResponse  "Invalid schedule ID"
+// @Failure      404  {object}  dto.ErrorResponse  "Schedule not found"
+// @Failure      500  {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules/{id} [get]
+// @Security     BearerAuth
+func (h *ScheduleHandler) Get(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	id, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.Get(userID, id)
+	if err != nil {
+		h.handleError(c, "Failed to fetch schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// Update renames, reorders or activates a schedule
+// @Summary      Update Schedule
+// @Description  Changes a schedule's name or position, or makes it the active schedule of its semester. Drafts can only be activated within the registration and add/drop windows, and only if each of their courses still has a free seat, admits the user's gender and clashes with no other course of the draft.
+// @Tags         schedules
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                     true  "Schedule ID"
+// @Param        body  body      dto.UpdateScheduleRequest  true  "Update schedule payload"
+// @Success      200   {object}  dto.ScheduleResponse
+// @Failure      400   {object}  dto.ErrorResponse  "Invalid input"
+// @Failure      403   {object}  dto.ErrorResponse  "Activation outside the registration and add/drop windows"
+// @Failure      404   {object}  dto.ErrorResponse  "Schedule not found"
+// @Failure      409   {object}  dto.ErrorResponse  "Schedule name already used, or courses of the draft can't be taken"
+// @Failure      500   {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules/{id} [put]
+// @Security     BearerAuth
+func (h *ScheduleHandler) Update(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	id, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid schedule request",
+			zap.Error(err),
+			zap.String("handler", "UpdateSchedule"))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	schedule, err := h.service.Update(userID, id, &req)
+	if err != nil {
+		h.handleError(c, "Failed to update schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// Delete removes a schedule
+// @Summary      Delete Schedule
+// @Description  Deletes a schedule with its courses. Deleting the active schedule, only possible within the registration and add/drop windows, activates the next one.
+// @Tags         schedules
+// @Produce      json
+// @Param        id   path      string  true  "Schedule ID"
+// @Success      200  {object}  map[string]string  "message: Schedule deleted successfully"
+// @Failure      400  {object}  dto.ErrorResponse  "Invalid schedule ID"
+// @Failure      403  {object}  dto.ErrorResponse  "Active schedule outside the registration and add/drop windows"
+// @Failure      404  {object}  dto.ErrorResponse  "Schedule not found"
+// @Failure      500  {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules/{id} [delete]
+// @Security     BearerAuth
+func (h *ScheduleHandler) Delete(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	id, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(userID, id); err != nil {
+		h.handleError(c, "Failed to delete schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Schedule deleted successfully"})
+}
+
+// Clone copies a schedule
+// @Summary      Clone Schedule
+// @Description  Copies a schedule and its courses under a new name
+// @Tags         schedules
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                    true  "Schedule ID"
+// @Param        body  body      dto.CloneScheduleRequest  true  "Name of the copy"
+// @Success      201   {object}  dto.ScheduleResponse
+// @Failure      400   {object}  dto.ErrorResponse  "Invalid input"
+// @Failure      404   {object}  dto.ErrorResponse  "Schedule not found"
+// @Failure      409   {object}  dto.ErrorResponse  "Schedule name already used"
+// @Failure      500   {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules/{id}/clone [post]
+// @Security     BearerAuth
+func (h *ScheduleHandler) Clone(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	id, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CloneScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid schedule request",
+			zap.Error(err),
+			zap.String("handler", "CloneSchedule"))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	schedule, err := h.service.Clone(userID, id, &req)
+	if err != nil {
+		h.handleError(c, "Failed to clone schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// Diff compares two schedules
+// @Summary      Diff Schedules
+// @Description  Compares a schedule with another schedule of the same semester: added and removed courses, changed sections and total weights
+// @Tags         schedules
+// @Produce      json
+// @Param        id    path      string  true  "Schedule ID"
+// @Param        with  query     string  true  "ID of the schedule to compare with"
+// @Success      200   {object}  dto.ScheduleDiffResponse
+// @Failure      400   {object}  dto.ErrorResponse  "Invalid schedule ID or schedules of different semesters"
+// @Failure      404   {object}  dto.ErrorResponse  "Schedule not found"
+// @Failure      500   {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules/{id}/diff [get]
+// @Security     BearerAuth
+func (h *ScheduleHandler) Diff(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	id, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+
+	otherID, err := uuid.Parse(c.Query("with"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return
+	}
+
+	diff, err := h.service.Diff(userID, id, otherID)
+	if err != nil {
+		h.handleError(c, "Failed to diff schedules", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// Share publishes a schedule under a public link
+// @Summary      Share Schedule
+// @Description  Creates an unguessable public token under which the schedule can be viewed read-only, optionally expiring
+// @Tags         schedules
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                          true  "Schedule ID"
+// @Param        body  body      dto.CreateScheduleShareRequest  false  "Share options"
+// @Success      201   {object}  dto.ScheduleShareResponse
+// @Failure      400   {object}  dto.ErrorResponse  "Invalid input or expiry in the past"
+// @Failure      404   {object}  dto.ErrorResponse  "Schedule not found"
+// @Failure      500   {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules/{id}/shares [post]
+// @Security     BearerAuth
+func (h *ScheduleHandler) Share(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	id, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional; an empty one shares without expiry
+	var req dto.CreateScheduleShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("Invalid schedule share request",
+			zap.Error(err),
+			zap.String("handler", "ShareSchedule"))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	share, err := h.service.Share(userID, id, &req)
+	if err != nil {
+		h.handleError(c, "Failed to share schedule", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, share)
+}
+
+// GetShares lists a schedule's public links
+// @Summary      List Schedule Shares
+// @Description  Lists the public links of a schedule that haven't been revoked
+// @Tags         schedules
+// @Produce      json
+// @Param        id   path      string  true  "Schedule ID"
+// @Success      200  {array}   dto.ScheduleShareResponse
+// @Failure      400  {object}  dto.ErrorResponse  "Invalid schedule ID"
+// @Failure      404  {object}  dto.ErrorResponse  "Schedule not found"
+// @Failure      500  {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules/{id}/shares [get]
+// @Security     BearerAuth
+func (h *ScheduleHandler) GetShares(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	id, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+
+	shares, err := h.service.GetShares(userID, id)
+	if err != nil {
+		h.handleError(c, "Failed to fetch schedule shares", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shares)
+}
+
+// RevokeShare disables a public link
+// @Summary      Revoke Schedule Share
+// @Description  Revokes a public link so the schedule can no longer be viewed through it
+// @Tags         schedules
+// @Produce      json
+// @Param        id       path      string  true  "Schedule ID"
+// @Param        shareId  path      string  true  "Share ID"
+// @Success      200      {object}  map[string]string  "message: Share revoked successfully"
+// @Failure      400      {object}  dto.ErrorResponse  "Invalid ID"
+// @Failure      404      {object}  dto.ErrorResponse  "Schedule or share not found"
+// @Failure      500      {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules/{id}/shares/{shareId} [delete]
+// @Security     BearerAuth
+func (h *ScheduleHandler) RevokeShare(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	id, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+
+	shareID, err := uuid.Parse(c.Param("shareId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid share ID"})
+		return
+	}
+
+	if err := h.service.RevokeShare(userID, id, shareID); err != nil {
+		h.handleError(c, "Failed to revoke schedule share", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Share revoked successfully"})
+}
+
+// GetShared returns a shared schedule
+// @Summary      View Shared Schedule
+// @Description  Returns a schedule published under a public link with its course times, professors and exams. No authentication required.
+// @Tags         schedules
+// @Produce      json
+// @Param        token  path      string  true  "Share token"
+// @Success      200    {object}  dto.SharedScheduleResponse
+// @Failure      404    {object}  dto.ErrorResponse  "Unknown or revoked link"
+// @Failure      410    {object}  dto.ErrorResponse  "Expired link"
+// @Failure      500    {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/shared/schedules/{token} [get]
+func (h *ScheduleHandler) GetShared(c *gin.Context) {
+	schedule, err := h.service.GetShared(c.Param("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shared schedule not found"})
+		case errors.Is(err, errors.ErrExpiredToken):
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to fetch shared schedule", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// Calendar exports a schedule as an iCalendar file
+// @Summary      Export Schedule Calendar
+// @Description  Exports a schedule's weekly classes, bounded by the class dates its university set for the semester, and its exams as an iCalendar file
+// @Tags         schedules
+// @Produce      text/calendar
+// @Param        id   path      string  true  "Schedule ID"
+// @Success      200  {file}    file
+// @Failure      400  {object}  dto.ErrorResponse  "Invalid schedule ID or semester without dates"
+// @Failure      404  {object}  dto.ErrorResponse  "Schedule not found"
+// @Failure      500  {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules/{id}/calendar.ics [get]
+// @Security     BearerAuth
+func (h *ScheduleHandler) Calendar(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	id, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+
+	calendar, err := h.service.Calendar(userID, id)
+	if err != nil {
+		h.handleError(c, "Failed to export schedule calendar", err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="schedule.ics"`)
+	c.Data(http.StatusOK, calendarContentType, calendar)
+}
+
+// Timetable renders a schedule as a printable timetable
+// @Summary      Print Schedule Timetable
+// @Description  Renders a schedule as a right-to-left Persian weekly timetable from Saturday to Thursday, with its exam table below
+// @Tags         schedules
+// @Produce      application/pdf,image/png
+// @Param        id      path      string  true   "Schedule ID"
+// @Param        format  query     string  false  "Output format"  Enums(pdf, png)  default(pdf)
+// @Success      200     {file}    file
+// @Failure      400     {object}  dto.ErrorResponse  "Invalid schedule ID or format"
+// @Failure      404     {object}  dto.ErrorResponse  "Schedule not found"
+// @Failure      500     {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/schedules/{id}/timetable [get]
+// @Security     BearerAuth
+func (h *ScheduleHandler) Timetable(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	id, ok := h.scheduleID(c)
+	if !ok {
+		return
+	}
+
+	format := c.DefaultQuery("format", "pdf")
+	data, err := h.service.Timetable(userID, id, format)
+	if err != nil {
+		h.handleError(c, "Failed to render timetable", err)
+		return
+	}
+
+	contentType := "application/pdf"
+	if format == "png" {
+		contentType = "image/png"
+	}
+	c.Header("Content-Disposition", `attachment; filename="timetable.`+format+`"`)
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// SharedCalendar serves a shared schedule as an iCalendar feed
+// @Summary      Subscribe to Shared Schedule
+// @Description  Serves a shared schedule as an iCalendar feed that calendar apps can subscribe to. No authentication required.
+// @Tags         schedules
+// @Produce      text/calendar
+// @Param        token  path      string  true  "Share token"
+// @Success      200    {file}    file
+// @Failure      400    {object}  dto.ErrorResponse  "Semester without dates"
+// @Failure      404    {object}  dto.ErrorResponse  "Unknown or revoked link"
+// @Failure      410    {object}  dto.ErrorResponse  "Expired link"
+// @Failure      500    {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/shared/schedules/{token}/calendar.ics [get]
+func (h *ScheduleHandler) SharedCalendar(c *gin.Context) {
+	calendar, err := h.service.SharedCalendar(c.Param("token"))
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Shared schedule not found"})
+		case errors.Is(err, errors.ErrExpiredToken):
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to export shared schedule calendar", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.Data(http.StatusOK, calendarContentType, calendar)
+}
+
+func (h *ScheduleHandler) scheduleID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.logger.Warn("Invalid schedule ID format",
+			zap.String("id", c.Param("id")))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (h *ScheduleHandler) handleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, errors.ErrInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errors.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, errors.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message,
+			zap.String("user_id", c.GetString("userID")),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	}
+}
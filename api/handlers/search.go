@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type SearchHandler struct {
+	service services.SearchService
+	logger  *zap.Logger
+}
+
+func NewSearchHandler(service services.SearchService, logger *zap.Logger) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Search finds courses and professors of a university
+// @Summary      Search courses and professors
+// @Description  Ranks a university's courses by name, code and professor, and its professors by name. Persian and Arabic letter variants, ZWNJ, spacing and Finglish (e.g. "riazi") are tolerated.
+// @Tags         search
+// @Produce      json
+// @Param        q              query     string  true   "Search text"
+// @Param        university_id  query     string  true   "University ID"
+// @Param        semester_id    query     string  false  "Only courses of this semester"
+// @Param        limit          query     int     false  "Results per kind, up to 50"  default(20)
+// @Success      200            {object}  dto.SearchResponse
+// @Failure      400            {object}  dto.ErrorResponse  "Invalid query parameters"
+// @Failure      500            {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var req dto.SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warn("Invalid search request",
+			zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid search parameters"})
+		return
+	}
+
+	universityID, err := uuid.Parse(c.Query("university_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid university ID"})
+		return
+	}
+	req.UniversityID = universityID
+
+	if raw := c.Query("semester_id"); raw != "" {
+		semesterID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid semester ID"})
+			return
+		}
+		req.SemesterID = semesterID
+	}
+
+	results, err := h.service.Search(&req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to search", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -101,6 +101,40 @@ func (h *SemesterHandler) Get(c *gin.Context) {
 	c.JSON(http.StatusOK, semester)
 }
 
+// GetByCode retrieves a semester by its Golestan term code
+// @Summary      Get Semester by Code
+// @Description  Retrieves a semester by its Golestan term code, e.g. 4031 for the fall of 1403-1404
+// @Tags         semesters
+// @Produce      json
+// @Param        code  path      string              true  "Golestan term code"
+// @Success      200   {object}  dto.SemesterResponse
+// @Failure      400   {object}  dto.ErrorResponse   "Invalid term code"
+// @Failure      404   {object}  dto.ErrorResponse   "Semester not found"
+// @Failure      500   {object}  dto.ErrorResponse   "Internal server error"
+// @Router       /v1/admin/semesters/code/{code} [get]
+// @Security     BearerAuth
+func (h *SemesterHandler) GetByCode(c *gin.Context) {
+	code := c.Param("code")
+
+	semester, err := h.service.GetByCode(code)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid term code"})
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Semester not found"})
+		default:
+			h.logger.Error("Failed to get semester by code",
+				zap.String("code", code),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, semester)
+}
+
 // GetAll lists all semesters
 // @Summary      List Semesters
 // @Description  Retrieves all semesters, ordered by most recent
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"net/http"
+)
+
+type UniversitySemesterHandler struct {
+	service services.UniversitySemesterService
+	logger  *zap.Logger
+}
+
+func NewUniversitySemesterHandler(service services.UniversitySemesterService, logger *zap.Logger) *UniversitySemesterHandler {
+	return &UniversitySemesterHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Set creates or replaces a university's calendar for a semester
+// @Summary      Set University Semester
+// @Description  Sets a university's class dates, registration and add/drop windows, exam period and current flag for a semester. Marking it current unmarks the university's other semesters.
+// @Tags         semesters
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                            true  "University ID"
+// @Param        semesterId  path      string                            true  "Semester ID"
+// @Param        body        body      dto.SetUniversitySemesterRequest  true  "Semester calendar payload"
+// @Success      200         {object}  dto.UniversitySemesterResponse
+// @Failure      400         {object}  dto.ErrorResponse  "Invalid input"
+// @Failure      404         {object}  dto.ErrorResponse  "University or semester not found"
+// @Failure      500         {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/admin/universities/{id}/semesters/{semesterId} [put]
+// @Security     BearerAuth
+func (h *UniversitySemesterHandler) Set(c *gin.Context) {
+	universityID, semesterID, ok := h.ids(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SetUniversitySemesterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid university semester request",
+			zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	calendar, err := h.service.Set(universityID, semesterID, &req)
+	if err != nil {
+		h.handleError(c, "Failed to set university semester", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// GetAll lists a university's semester calendars
+// @Summary      List University Semesters
+// @Description  Lists a university's semester calendars, newest semester first
+// @Tags         semesters
+// @Produce      json
+// @Param        id   path      string  true  "University ID"
+// @Success      200  {array}   dto.UniversitySemesterResponse
+// @Failure      400  {object}  dto.ErrorResponse  "Invalid university ID"
+// @Failure      500  {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/admin/universities/{id}/semesters [get]
+// @Security     BearerAuth
+func (h *UniversitySemesterHandler) GetAll(c *gin.Context) {
+	universityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid university ID"})
+		return
+	}
+
+	calendars, err := h.service.GetAll(universityID)
+	if err != nil {
+		h.handleError(c, "Failed to fetch university semesters", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendars)
+}
+
+// Delete removes a university's calendar for a semester
+// @Summary      Delete University Semester
+// @Description  Removes a university's calendar for a semester, lifting its selection windows
+// @Tags         semesters
+// @Produce      json
+// @Param        id          path      string  true  "University ID"
+// @Param        semesterId  path      string  true  "Semester ID"
+// @Success      200         {object}  map[string]string  "message: University semester deleted successfully"
+// @Failure      400         {object}  dto.ErrorResponse  "Invalid ID"
+// @Failure      404         {object}  dto.ErrorResponse  "University semester not found"
+// @Failure      500         {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/admin/universities/{id}/semesters/{semesterId} [delete]
+// @Security     BearerAuth
+func (h *UniversitySemesterHandler) Delete(c *gin.Context) {
+	universityID, semesterID, ok := h.ids(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(universityID, semesterID); err != nil {
+		h.handleError(c, "Failed to delete university semester", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "University semester deleted successfully"})
+}
+
+// GetCurrent returns the current semester of the user's university
+// @Summary      Current Semester
+// @Description  Returns the current semester of the user's university with its class dates, selection windows and exam period
+// @Tags         semesters
+// @Produce      json
+// @Success      200  {object}  dto.UniversitySemesterResponse
+// @Failure      404  {object}  dto.ErrorResponse  "No current semester"
+// @Failure      500  {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/semesters/current [get]
+// @Security     BearerAuth
+func (h *UniversitySemesterHandler) GetCurrent(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+
+	calendar, err := h.service.GetCurrentForUser(userID)
+	if err != nil {
+		h.handleError(c, "Failed to fetch current semester", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+func (h *UniversitySemesterHandler) ids(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	universityID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid university ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	semesterID, err := uuid.Parse(c.Param("semesterId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid semester ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return universityID, semesterID, true
+}
+
+func (h *UniversitySemesterHandler) handleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, errors.ErrInvalid):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"github.com/armanjr/termustat/api/dto"
 	"github.com/armanjr/termustat/api/errors"
 	"github.com/armanjr/termustat/api/services"
 	"github.com/gin-gonic/gin"
@@ -10,29 +11,89 @@ import (
 )
 
 type UserCourseHandler struct {
-	service services.UserCourseService
-	logger  *zap.Logger
+	service   services.UserCourseService
+	schedules services.ScheduleService
+	calendars services.UniversitySemesterService
+	logger    *zap.Logger
 }
 
-func NewUserCourseHandler(service services.UserCourseService, logger *zap.Logger) *UserCourseHandler {
+func NewUserCourseHandler(
+	service services.UserCourseService,
+	schedules services.ScheduleService,
+	calendars services.UniversitySemesterService,
+	logger *zap.Logger,
+) *UserCourseHandler {
 	return &UserCourseHandler{
-		service: service,
-		logger:  logger,
+		service:   service,
+		schedules: schedules,
+		calendars: calendars,
+		logger:    logger,
 	}
 }
 
+// semesterID parses raw, defaulting to the current semester of the user's
+// university when it is empty
+func (h *UserCourseHandler) semesterID(userID uuid.UUID, raw string) (uuid.UUID, error) {
+	if raw != "" {
+		semesterID, err := uuid.Parse(raw)
+		if err != nil {
+			return uuid.Nil, errors.NewValidationError("semester ID")
+		}
+		return semesterID, nil
+	}
+
+	current, err := h.calendars.GetCurrentForUser(userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return current.Semester.ID, nil
+}
+
+// targetSchedule returns the schedule a request works on: the one in the
+// schedule_id query parameter, or else the user's active schedule. It writes
+// the error response itself when it fails.
+func (h *UserCourseHandler) targetSchedule(c *gin.Context, active func() (*dto.ScheduleResponse, error)) (uuid.UUID, bool) {
+	if raw := c.Query("schedule_id"); raw != "" {
+		scheduleID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schedule ID"})
+			return uuid.Nil, false
+		}
+		return scheduleID, true
+	}
+
+	schedule, err := active()
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to find active schedule",
+				zap.String("user_id", c.GetString("userID")),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to find schedule"})
+		}
+		return uuid.Nil, false
+	}
+	return schedule.ID, true
+}
+
 // AddCourse handles course selection
 // @Summary      Select Course
-// @Description  Adds a course to the current user's schedule
+// @Description  Adds a course to the current user's active schedule of the semester, or to the schedule given by schedule_id. The semester defaults to the current one of the user's university.
 // @Tags         user-courses
 // @Accept       json
 // @Produce      json
-// @Param        body  body      map[string]string  true  "course_id and semester_id"
-// @Success      200   {object}  map[string]string  "message: Course added successfully"
-// @Failure      400   {object}  dto.ErrorResponse  "Invalid input"
-// @Failure      404   {object}  dto.ErrorResponse  "Course or semester not found"
-// @Failure      409   {object}  dto.ErrorResponse  "Conflict (e.g. already selected)"
-// @Failure      500   {object}  dto.ErrorResponse  "Internal server error"
+// @Param        body         body      map[string]string  true   "course_id and optional semester_id"
+// @Param        schedule_id  query     string             false  "Schedule ID"
+// @Success      200          {object}  dto.CourseSelectionResponse  "Course added, with any exam clashes the policy only warns about"
+// @Failure      400          {object}  dto.ErrorResponse  "Invalid input"
+// @Failure      403          {object}  dto.ErrorResponse  "Outside the registration and add/drop windows"
+// @Failure      404          {object}  dto.ErrorResponse  "Course or semester not found"
+// @Failure      409          {object}  dto.ErrorResponse  "Conflict (e.g. already selected, time or exam clash)"
+// @Failure      500          {object}  dto.ErrorResponse  "Internal server error"
 // @Router       /v1/user/courses/select [post]
 // @Security     BearerAuth
 func (h *UserCourseHandler) AddCourse(c *gin.Context) {
@@ -40,7 +101,7 @@ func (h *UserCourseHandler) AddCourse(c *gin.Context) {
 
 	var req struct {
 		CourseID   uuid.UUID `json:"course_id" binding:"required"`
-		SemesterID uuid.UUID `json:"semester_id" binding:"required"`
+		SemesterID string    `json:"semester_id"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -50,10 +111,26 @@ func (h *UserCourseHandler) AddCourse(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.AddCourse(userID, req.CourseID, req.SemesterID); err != nil {
+	scheduleID, ok := h.targetSchedule(c, func() (*dto.ScheduleResponse, error) {
+		semesterID, err := h.semesterID(userID, req.SemesterID)
+		if err != nil {
+			return nil, err
+		}
+		return h.schedules.GetActive(userID, semesterID)
+	})
+	if !ok {
+		return
+	}
+
+	warnings, err := h.service.AddCourse(userID, scheduleID, req.CourseID)
+	if err != nil {
 		switch {
 		case errors.Is(err, errors.ErrNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		case errors.Is(err, errors.ErrConflict):
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		default:
@@ -66,19 +143,24 @@ func (h *UserCourseHandler) AddCourse(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Course added successfully"})
+	c.JSON(http.StatusOK, dto.CourseSelectionResponse{
+		Message:  "Course added successfully",
+		Warnings: warnings,
+	})
 }
 
 // RemoveCourse handles course removal
 // @Summary      Remove Course
-// @Description  Removes a course from the current user's schedule
+// @Description  Removes a course from the current user's active schedule, or from the schedule given by schedule_id
 // @Tags         user-courses
 // @Produce      json
-// @Param        courseId  path      string              true  "Course ID"
-// @Success      200       {object}  map[string]string   "message: Course removed successfully"
-// @Failure      400       {object}  dto.ErrorResponse   "Invalid course ID"
-// @Failure      404       {object}  dto.ErrorResponse   "Course not found in user's schedule"
-// @Failure      500       {object}  dto.ErrorResponse   "Internal server error"
+// @Param        courseId     path      string              true   "Course ID"
+// @Param        schedule_id  query     string              false  "Schedule ID"
+// @Success      200          {object}  map[string]string   "message: Course removed successfully"
+// @Failure      400          {object}  dto.ErrorResponse   "Invalid course ID"
+// @Failure      403          {object}  dto.ErrorResponse   "Outside the registration and add/drop windows"
+// @Failure      404          {object}  dto.ErrorResponse   "Course not found in user's schedule"
+// @Failure      500          {object}  dto.ErrorResponse   "Internal server error"
 // @Router       /v1/user/courses/select/{courseId} [delete]
 // @Security     BearerAuth
 func (h *UserCourseHandler) RemoveCourse(c *gin.Context) {
@@ -91,10 +173,19 @@ func (h *UserCourseHandler) RemoveCourse(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.RemoveCourse(userID, courseID); err != nil {
+	scheduleID, ok := h.targetSchedule(c, func() (*dto.ScheduleResponse, error) {
+		return h.schedules.GetActiveForCourse(userID, courseID)
+	})
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveCourse(userID, scheduleID, courseID); err != nil {
 		switch {
 		case errors.Is(err, errors.ErrNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": "Course not found in user's schedule"})
+		case errors.Is(err, errors.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		default:
 			h.logger.Error("Failed to remove course",
 				zap.String("user_id", userID.String()),
@@ -110,10 +201,11 @@ func (h *UserCourseHandler) RemoveCourse(c *gin.Context) {
 
 // GetUserCourses returns all courses for a user in a specific semester
 // @Summary      Get User Courses
-// @Description  Retrieves all courses selected by the user for a given semester
+// @Description  Retrieves the courses of the user's active schedule for a given semester, or of the schedule given by schedule_id
 // @Tags         user-courses
 // @Produce      json
-// @Param        semester_id  query     string  true  "Semester ID"
+// @Param        semester_id  query     string  false  "Semester ID, the current semester by default"
+// @Param        schedule_id  query     string  false  "Schedule ID"
 // @Success      200          {array}   dto.CourseResponse
 // @Failure      400          {object}  dto.ErrorResponse  "Invalid semester ID"
 // @Failure      500          {object}  dto.ErrorResponse  "Internal server error"
@@ -121,19 +213,26 @@ func (h *UserCourseHandler) RemoveCourse(c *gin.Context) {
 // @Security     BearerAuth
 func (h *UserCourseHandler) GetUserCourses(c *gin.Context) {
 	userID, _ := uuid.Parse(c.GetString("userID"))
-	semesterID, err := uuid.Parse(c.Query("semester_id"))
-	if err != nil {
-		h.logger.Warn("Invalid semester ID format",
-			zap.String("semester_id", c.Query("semester_id")))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid semester ID"})
+	scheduleID, ok := h.targetSchedule(c, func() (*dto.ScheduleResponse, error) {
+		semesterID, err := h.semesterID(userID, c.Query("semester_id"))
+		if err != nil {
+			return nil, err
+		}
+		return h.schedules.GetActive(userID, semesterID)
+	})
+	if !ok {
 		return
 	}
 
-	courses, err := h.service.GetUserCourses(userID, semesterID)
+	courses, err := h.service.GetUserCourses(userID, scheduleID)
 	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Error("Failed to fetch user courses",
 			zap.String("user_id", userID.String()),
-			zap.String("semester_id", semesterID.String()),
+			zap.String("schedule_id", scheduleID.String()),
 			zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch courses"})
 		return
@@ -142,16 +241,17 @@ func (h *UserCourseHandler) GetUserCourses(c *gin.Context) {
 	c.JSON(http.StatusOK, courses)
 }
 
-// ValidateTimeConflicts checks for time conflicts with existing courses
+// ValidateTimeConflicts checks for time and exam conflicts with existing courses
 // @Summary      Validate Time Conflicts
-// @Description  Checks if adding the selected course causes any time conflicts
+// @Description  Checks if adding the selected course causes any class time or exam conflicts
 // @Tags         user-courses
 // @Produce      json
-// @Param        course_id    query     string  true  "Course ID"
-// @Param        semester_id  query     string  true  "Semester ID"
-// @Success      200          {object}  map[string]string  "message: No time conflicts found"
+// @Param        course_id    query     string  true   "Course ID"
+// @Param        semester_id  query     string  false  "Semester ID, the current semester by default"
+// @Param        schedule_id  query     string  false  "Schedule ID"
+// @Success      200          {object}  dto.CourseSelectionResponse  "No blocking conflicts, with any exam clashes the policy only warns about"
 // @Failure      400          {object}  dto.ErrorResponse  "Invalid input"
-// @Failure      409          {object}  dto.ErrorResponse  "Time conflict exists"
+// @Failure      409          {object}  map[string]interface{}  "Time conflict exists, with the course's other sections that would fit"
 // @Router       /v1/user/courses/validate [get]
 // @Security     BearerAuth
 func (h *UserCourseHandler) ValidateTimeConflicts(c *gin.Context) {
@@ -162,16 +262,267 @@ func (h *UserCourseHandler) ValidateTimeConflicts(c *gin.Context) {
 		return
 	}
 
-	semesterID, err := uuid.Parse(c.Query("semester_id"))
+	scheduleID, ok := h.targetSchedule(c, func() (*dto.ScheduleResponse, error) {
+		semesterID, err := h.semesterID(userID, c.Query("semester_id"))
+		if err != nil {
+			return nil, err
+		}
+		return h.schedules.GetActive(userID, semesterID)
+	})
+	if !ok {
+		return
+	}
+
+	if err := h.service.ValidateTimeConflicts(userID, scheduleID, courseID); err != nil {
+		if !errors.Is(err, errors.ErrConflict) {
+			h.handleValidationError(c, userID, courseID, err)
+			return
+		}
+		alternatives, altErr := h.service.AlternativeSections(userID, scheduleID, courseID)
+		if altErr != nil {
+			h.logger.Warn("Failed to find alternative sections",
+				zap.String("course_id", courseID.String()),
+				zap.Error(altErr))
+		}
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "alternatives": alternatives})
+		return
+	}
+
+	warnings, err := h.service.ValidateExamConflicts(userID, scheduleID, courseID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid semester ID"})
+		h.handleValidationError(c, userID, courseID, err)
 		return
 	}
 
-	if err := h.service.ValidateTimeConflicts(userID, semesterID, courseID); err != nil {
+	c.JSON(http.StatusOK, dto.CourseSelectionResponse{
+		Message:  "No time conflicts found",
+		Warnings: warnings,
+	})
+}
+
+func (h *UserCourseHandler) handleValidationError(c *gin.Context, userID, courseID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, errors.ErrConflict):
 		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, errors.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error("Failed to validate conflicts",
+			zap.String("user_id", userID.String()),
+			zap.String("course_id", courseID.String()),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate conflicts"})
+	}
+}
+
+// SwapSection replaces a selected section with another section of the same course
+// @Summary      Swap Section
+// @Description  Replaces a selected section with another section (group) of the same course in one step
+// @Tags         user-courses
+// @Accept       json
+// @Produce      json
+// @Param        body         body      dto.SwapSectionRequest  true   "Sections to swap"
+// @Param        schedule_id  query     string                  false  "Schedule ID, the active schedule by default"
+// @Success      200          {object}  dto.CourseSelectionResponse  "Section swapped, with any exam clashes the policy only warns about"
+// @Failure      400          {object}  dto.ErrorResponse  "Invalid input or sections of different courses"
+// @Failure      403          {object}  dto.ErrorResponse  "Outside the registration and add/drop windows"
+// @Failure      404          {object}  dto.ErrorResponse  "Course not found or not selected"
+// @Failure      409          {object}  dto.ErrorResponse  "Time or exam conflict"
+// @Failure      500          {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/courses/select/swap [post]
+// @Security     BearerAuth
+func (h *UserCourseHandler) SwapSection(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+
+	var req dto.SwapSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid section swap request",
+			zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	scheduleID, ok := h.targetSchedule(c, func() (*dto.ScheduleResponse, error) {
+		return h.schedules.GetActiveForCourse(userID, req.FromCourseID)
+	})
+	if !ok {
+		return
+	}
+
+	warnings, err := h.service.SwapSection(userID, scheduleID, req.FromCourseID, req.ToCourseID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to swap sections",
+				zap.String("user_id", userID.String()),
+				zap.String("from_course_id", req.FromCourseID.String()),
+				zap.String("to_course_id", req.ToCourseID.String()),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to swap sections"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.CourseSelectionResponse{
+		Message:  "Section swapped successfully",
+		Warnings: warnings,
+	})
+}
+
+// GetAlternativeSections lists other sections of a course that fit the user's schedule
+// @Summary      Alternative Sections
+// @Description  Lists the other sections of a course the user could take instead, skipping full, gender-restricted and conflicting ones
+// @Tags         user-courses
+// @Produce      json
+// @Param        courseId     path      string  true   "Course ID"
+// @Param        schedule_id  query     string  false  "Schedule ID, the active schedule by default"
+// @Success      200          {array}   dto.CourseResponse
+// @Failure      400          {object}  dto.ErrorResponse  "Invalid course ID"
+// @Failure      404          {object}  dto.ErrorResponse  "Course not found"
+// @Failure      500          {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/courses/select/{courseId}/alternatives [get]
+// @Security     BearerAuth
+func (h *UserCourseHandler) GetAlternativeSections(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	courseID, err := uuid.Parse(c.Param("courseId"))
+	if err != nil {
+		h.logger.Warn("Invalid course ID format",
+			zap.String("course_id", c.Param("courseId")))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	scheduleID, ok := h.targetSchedule(c, func() (*dto.ScheduleResponse, error) {
+		return h.schedules.GetActiveForCourse(userID, courseID)
+	})
+	if !ok {
+		return
+	}
+
+	alternatives, err := h.service.AlternativeSections(userID, scheduleID, courseID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Course not found"})
+		default:
+			h.logger.Error("Failed to fetch alternative sections",
+				zap.String("user_id", userID.String()),
+				zap.String("course_id", courseID.String()),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch sections"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, alternatives)
+}
+
+// GetExamCalendar returns the exams of the user's selected courses
+// @Summary      Exam Calendar
+// @Description  Lists the exams of the user's active schedule in a semester, or of the schedule given by schedule_id, in chronological order, marking overlapping and same-day exams
+// @Tags         user-courses
+// @Produce      json
+// @Param        semester_id  query     string  false  "Semester ID, the current semester by default"
+// @Param        schedule_id  query     string  false  "Schedule ID"
+// @Success      200          {object}  dto.ExamCalendarResponse
+// @Failure      400          {object}  dto.ErrorResponse  "Invalid semester ID"
+// @Failure      500          {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/courses/exams [get]
+// @Security     BearerAuth
+func (h *UserCourseHandler) GetExamCalendar(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+	scheduleID, ok := h.targetSchedule(c, func() (*dto.ScheduleResponse, error) {
+		semesterID, err := h.semesterID(userID, c.Query("semester_id"))
+		if err != nil {
+			return nil, err
+		}
+		return h.schedules.GetActive(userID, semesterID)
+	})
+	if !ok {
+		return
+	}
+
+	calendar, err := h.service.GetExamCalendar(userID, scheduleID)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Error("Failed to build exam calendar",
+			zap.String("user_id", userID.String()),
+			zap.String("schedule_id", scheduleID.String()),
+			zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch exams"})
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// GenerateSchedules builds conflict-free schedules from a list of courses
+// @Summary      Generate Schedules
+// @Description  Enumerates the section combinations of the given courses free of time and exam conflicts that the user can take, ranked by free days, earliest start, gaps and preferred professors. The semester defaults to the current one of the user's university.
+// @Tags         user-courses
+// @Accept       json
+// @Produce      json
+// @Param        body  body      dto.GenerateSchedulesRequest  true  "Courses and preferences"
+// @Success      200   {object}  dto.GenerateSchedulesResponse
+// @Failure      400   {object}  dto.ErrorResponse  "Invalid input"
+// @Failure      404   {object}  dto.ErrorResponse  "Course or semester not found"
+// @Failure      500   {object}  dto.ErrorResponse  "Internal server error"
+// @Router       /v1/user/courses/generate [post]
+// @Security     BearerAuth
+func (h *UserCourseHandler) GenerateSchedules(c *gin.Context) {
+	userID, _ := uuid.Parse(c.GetString("userID"))
+
+	var req dto.GenerateSchedulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("Invalid schedule generation request",
+			zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if req.SemesterID == uuid.Nil {
+		semesterID, err := h.semesterID(userID, "")
+		if err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			h.logger.Error("Failed to find current semester",
+				zap.String("user_id", userID.String()),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate schedules"})
+			return
+		}
+		req.SemesterID = semesterID
+	}
+
+	schedules, err := h.service.GenerateSchedules(userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, errors.ErrInvalid):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to generate schedules",
+				zap.String("user_id", userID.String()),
+				zap.String("semester_id", req.SemesterID.String()),
+				zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate schedules"})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "No time conflicts found"})
+	c.JSON(http.StatusOK, schedules)
 }
@@ -0,0 +1,396 @@
+package timetable
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/utils"
+	"github.com/armanjr/termustat/engine/jalali"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Layout in pixels; the canvas is a landscape A4 page at 150 DPI
+const (
+	canvasWidth    = 1754
+	margin         = 56
+	dayColumnWidth = 140
+	hourRowHeight  = 44
+	dayRowHeight   = 150
+	examRowHeight  = 46
+	blockPadding   = 4
+	sectionGap     = 40
+
+	// The grid spans at least 8:00 to 18:00, in minutes
+	defaultFirstMinute = 8 * 60
+	defaultLastMinute  = 18 * 60
+)
+
+// Golestan numbers days from Saturday
+var dayNames = []string{"شنبه", "یکشنبه", "دوشنبه", "سه‌شنبه", "چهارشنبه", "پنجشنبه", "جمعه"}
+
+var (
+	textColor   = color.RGBA{R: 0x21, G: 0x21, B: 0x21, A: 0xff}
+	mutedColor  = color.RGBA{R: 0x5f, G: 0x63, B: 0x68, A: 0xff}
+	lineColor   = color.RGBA{R: 0xc8, G: 0xcc, B: 0xd0, A: 0xff}
+	headerColor = color.RGBA{R: 0xe8, G: 0xea, B: 0xed, A: 0xff}
+	stripeColor = color.RGBA{R: 0xf6, G: 0xf7, B: 0xf8, A: 0xff}
+	blockColors = []color.RGBA{
+		{R: 0xbb, G: 0xde, B: 0xfb, A: 0xff},
+		{R: 0xc8, G: 0xe6, B: 0xc9, A: 0xff},
+		{R: 0xff, G: 0xe0, B: 0xb2, A: 0xff},
+		{R: 0xe1, G: 0xbe, B: 0xe7, A: 0xff},
+		{R: 0xff, G: 0xcd, B: 0xd2, A: 0xff},
+		{R: 0xb2, G: 0xeb, B: 0xf2, A: 0xff},
+		{R: 0xf0, G: 0xf4, B: 0xc3, A: 0xff},
+		{R: 0xd7, G: 0xcc, B: 0xc8, A: 0xff},
+	}
+)
+
+type alignment int
+
+const (
+	alignRight alignment = iota
+	alignCenter
+)
+
+// session is a class time placed in a lane of its day's row, so overlapping
+// sessions such as odd and even week classes sit on top of each other
+type session struct {
+	course *dto.CourseResponse
+	time   dto.CourseTimeResponse
+	color  color.RGBA
+	start  int
+	end    int
+	lane   int
+}
+
+type faces struct {
+	title    font.Face
+	subtitle font.Face
+	text     font.Face
+	small    font.Face
+}
+
+func newFaces(f *opentype.Font) (*faces, error) {
+	sizes := []float64{34, 22, 19, 16}
+	loaded := make([]font.Face, len(sizes))
+	for i, size := range sizes {
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create timetable font face: %w", err)
+		}
+		loaded[i] = face
+	}
+	return &faces{title: loaded[0], subtitle: loaded[1], text: loaded[2], small: loaded[3]}, nil
+}
+
+type canvas struct {
+	img   *image.RGBA
+	faces *faces
+}
+
+// drawTimetable lays out the weekly grid, days running top to bottom and
+// hours right to left, followed by the exam table
+func drawTimetable(timetable *Timetable, f *opentype.Font, loc *time.Location) (*image.RGBA, error) {
+	fs, err := newFaces(f)
+	if err != nil {
+		return nil, err
+	}
+
+	days := 6
+	first, last := defaultFirstMinute, defaultLastMinute
+	var sessions []session
+	for i := range timetable.Courses {
+		course := &timetable.Courses[i]
+		for _, courseTime := range course.CourseTimes {
+			if courseTime.DayOfWeek < 0 || courseTime.DayOfWeek >= len(dayNames) {
+				continue
+			}
+			if courseTime.DayOfWeek >= days {
+				days = courseTime.DayOfWeek + 1
+			}
+
+			s := session{
+				course: course,
+				time:   courseTime,
+				color:  blockColors[i%len(blockColors)],
+				start:  minuteOfDay(courseTime.StartTime),
+				end:    minuteOfDay(courseTime.EndTime),
+			}
+			first = min(first, s.start/60*60)
+			last = max(last, (s.end+59)/60*60)
+			sessions = append(sessions, s)
+		}
+	}
+
+	exams := make([]*dto.CourseResponse, 0, len(timetable.Courses))
+	for i := range timetable.Courses {
+		if !timetable.Courses[i].ExamStart.IsZero() {
+			exams = append(exams, &timetable.Courses[i])
+		}
+	}
+	sort.SliceStable(exams, func(i, j int) bool {
+		return exams[i].ExamStart.Before(exams[j].ExamStart)
+	})
+
+	headerHeight := 56
+	if timetable.Subtitle != "" {
+		headerHeight += 36
+	}
+	gridTop := margin + headerHeight
+	gridBottom := gridTop + hourRowHeight + days*dayRowHeight
+	examTop := gridBottom + sectionGap
+	height := examTop + 44 + (max(len(exams), 1)+1)*examRowHeight + margin
+
+	c := &canvas{img: image.NewRGBA(image.Rect(0, 0, canvasWidth, height)), faces: fs}
+	c.fill(c.img.Bounds(), color.White)
+
+	c.text(fs.title, timetable.Title, margin, canvasWidth-margin, margin+34, alignCenter, textColor)
+	if timetable.Subtitle != "" {
+		c.text(fs.subtitle, timetable.Subtitle, margin, canvasWidth-margin, margin+76, alignCenter, mutedColor)
+	}
+
+	c.drawGrid(sessions, days, first, last, gridTop)
+	c.drawExams(exams, examTop, loc)
+
+	return c.img, nil
+}
+
+func (c *canvas) drawGrid(sessions []session, days, first, last, top int) {
+	right := canvasWidth - margin
+	hoursRight := right - dayColumnWidth
+	hourWidth := float64(hoursRight-margin) / float64(last-first) * 60
+	xAt := func(minute int) int {
+		return hoursRight - int(float64(minute-first)/60*hourWidth)
+	}
+	rowsTop := top + hourRowHeight
+	bottom := rowsTop + days*dayRowHeight
+
+	c.fill(image.Rect(margin, top, right, rowsTop), headerColor)
+	for day := 0; day < days; day++ {
+		rowTop := rowsTop + day*dayRowHeight
+		if day%2 == 1 {
+			c.fill(image.Rect(margin, rowTop, right, rowTop+dayRowHeight), stripeColor)
+		}
+		c.fill(image.Rect(hoursRight, rowTop, right, rowTop+dayRowHeight), headerColor)
+		c.text(c.faces.text, dayNames[day], hoursRight, right, rowTop+dayRowHeight/2+7, alignCenter, textColor)
+		c.fill(image.Rect(margin, rowTop, right, rowTop+1), lineColor)
+	}
+
+	for minute := first; minute <= last; minute += 60 {
+		x := xAt(minute)
+		c.fill(image.Rect(x, top, x+1, bottom), lineColor)
+		if minute < last {
+			label := utils.PersianDigits(fmt.Sprintf("%d:00", minute/60))
+			c.text(c.faces.small, label, x-int(hourWidth), x, top+hourRowHeight/2+6, alignCenter, textColor)
+		}
+	}
+	c.stroke(image.Rect(margin, top, right, bottom), mutedColor)
+
+	for day := 0; day < days; day++ {
+		var daySessions []*session
+		for i := range sessions {
+			if sessions[i].time.DayOfWeek == day {
+				daySessions = append(daySessions, &sessions[i])
+			}
+		}
+		lanes := assignLanes(daySessions)
+		if lanes == 0 {
+			continue
+		}
+
+		laneHeight := dayRowHeight / lanes
+		rowTop := rowsTop + day*dayRowHeight
+		for _, s := range daySessions {
+			y := rowTop + s.lane*laneHeight
+			block := image.Rect(xAt(s.end)+blockPadding, y+blockPadding, xAt(s.start)-blockPadding, y+laneHeight-blockPadding)
+			c.drawSession(s, block)
+		}
+	}
+}
+
+// assignLanes puts each session of a day in the first lane free at its start
+// and returns the number of lanes used
+func assignLanes(sessions []*session) int {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		if sessions[i].start != sessions[j].start {
+			return sessions[i].start < sessions[j].start
+		}
+		return sessions[i].end < sessions[j].end
+	})
+
+	var laneEnds []int
+	for _, s := range sessions {
+		s.lane = -1
+		for lane, end := range laneEnds {
+			if end <= s.start {
+				s.lane = lane
+				break
+			}
+		}
+		if s.lane == -1 {
+			s.lane = len(laneEnds)
+			laneEnds = append(laneEnds, 0)
+		}
+		laneEnds[s.lane] = s.end
+	}
+	return len(laneEnds)
+}
+
+func (c *canvas) drawSession(s *session, block image.Rectangle) {
+	c.fill(block, s.color)
+	c.stroke(block, darken(s.color))
+
+	var details []string
+	if s.course.GroupNumber != "" {
+		details = append(details, "گروه "+utils.PersianDigits(s.course.GroupNumber))
+	}
+	if room := s.time.Room; room != "" {
+		details = append(details, room)
+	} else if s.time.Location != "" {
+		details = append(details, s.time.Location)
+	}
+	switch s.time.WeekParity {
+	case "odd":
+		details = append(details, "هفته فرد")
+	case "even":
+		details = append(details, "هفته زوج")
+	}
+	switch s.time.HalfTerm {
+	case 1:
+		details = append(details, "نیمه اول")
+	case 2:
+		details = append(details, "نیمه دوم")
+	}
+
+	lines := []struct {
+		face   font.Face
+		text   string
+		height int
+		color  color.Color
+	}{
+		{c.faces.text, s.course.Name, 26, textColor},
+		{c.faces.small, s.course.ProfessorName, 22, textColor},
+		{c.faces.small, strings.Join(details, "، "), 22, mutedColor},
+	}
+
+	left, right := block.Min.X+8, block.Max.X-8
+	y := block.Min.Y + 6
+	for _, line := range lines {
+		if y+line.height > block.Max.Y || line.text == "" {
+			continue
+		}
+		y += line.height
+		c.text(line.face, line.text, left, right, y-6, alignRight, line.color)
+	}
+}
+
+func (c *canvas) drawExams(exams []*dto.CourseResponse, top int, loc *time.Location) {
+	right := canvasWidth - margin
+	c.text(c.faces.subtitle, "برنامه امتحانات", margin, right, top+28, alignRight, textColor)
+
+	tableTop := top + 44
+	headers := []string{"درس", "کد", "استاد", "تاریخ", "ساعت"}
+	widths := []float64{0.34, 0.14, 0.24, 0.16, 0.12}
+	aligns := []alignment{alignRight, alignCenter, alignRight, alignCenter, alignCenter}
+
+	row := func(y int, cells []string, face font.Face) {
+		x := right
+		for i, cell := range cells {
+			width := int(widths[i] * float64(right-margin))
+			if i == len(cells)-1 {
+				width = x - margin
+			}
+			c.text(face, cell, x-width+10, x-10, y+examRowHeight/2+7, aligns[i], textColor)
+			x -= width
+			if i < len(cells)-1 {
+				c.fill(image.Rect(x, tableTop, x+1, y+examRowHeight), lineColor)
+			}
+		}
+		c.fill(image.Rect(margin, y+examRowHeight, right, y+examRowHeight+1), lineColor)
+	}
+
+	c.fill(image.Rect(margin, tableTop, right, tableTop+examRowHeight), headerColor)
+	row(tableTop, headers, c.faces.text)
+
+	bottom := tableTop + examRowHeight
+	if len(exams) == 0 {
+		bottom += examRowHeight
+		c.text(c.faces.text, "امتحانی ثبت نشده است", margin, right, bottom-examRowHeight/2+7, alignCenter, mutedColor)
+	}
+	for _, exam := range exams {
+		start, end := exam.ExamStart.In(loc), exam.ExamEnd.In(loc)
+		date := dayNames[(int(start.Weekday())+1)%7] + " " + utils.PersianDigits(jalali.FromTime(start).String())
+		hours := utils.PersianDigits(start.Format("15:04"))
+		if !exam.ExamEnd.IsZero() {
+			hours = utils.PersianDigits(start.Format("15:04") + " - " + end.Format("15:04"))
+		}
+		row(bottom, []string{exam.Name, exam.Code, exam.ProfessorName, date, hours}, c.faces.small)
+		bottom += examRowHeight
+	}
+	c.stroke(image.Rect(margin, tableTop, right, bottom), mutedColor)
+}
+
+func (c *canvas) fill(rect image.Rectangle, col color.Color) {
+	draw.Draw(c.img, rect, image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+// stroke draws a 2px border inside rect
+func (c *canvas) stroke(rect image.Rectangle, col color.Color) {
+	const width = 2
+	c.fill(image.Rect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Min.Y+width), col)
+	c.fill(image.Rect(rect.Min.X, rect.Max.Y-width, rect.Max.X, rect.Max.Y), col)
+	c.fill(image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+width, rect.Max.Y), col)
+	c.fill(image.Rect(rect.Max.X-width, rect.Min.Y, rect.Max.X, rect.Max.Y), col)
+}
+
+// text draws a line between left and right on baseline, cut short with an
+// ellipsis if it does not fit
+func (c *canvas) text(face font.Face, text string, left, right, baseline int, align alignment, col color.Color) {
+	width := fixed.I(right - left)
+	line := visual(text)
+	if font.MeasureString(face, line) > width {
+		line = ""
+		runes := []rune(text)
+		for n := len(runes) - 1; n > 0; n-- {
+			candidate := visual(strings.TrimSpace(string(runes[:n])) + "…")
+			if font.MeasureString(face, candidate) <= width {
+				line = candidate
+				break
+			}
+		}
+	}
+	if line == "" {
+		return
+	}
+
+	advance := font.MeasureString(face, line)
+	x := fixed.I(right) - advance
+	if align == alignCenter {
+		x = fixed.I(left) + (width-advance)/2
+	}
+
+	d := font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(col),
+		Face: face,
+		Dot:  fixed.Point26_6{X: x, Y: fixed.I(baseline)},
+	}
+	d.DrawString(line)
+}
+
+func darken(c color.RGBA) color.RGBA {
+	return color.RGBA{R: c.R / 10 * 7, G: c.G / 10 * 7, B: c.B / 10 * 7, A: c.A}
+}
+
+func minuteOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
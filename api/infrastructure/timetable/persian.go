@@ -0,0 +1,254 @@
+package timetable
+
+import "unicode"
+
+// joining holds the isolated, final, initial and medial presentation forms of
+// a letter. Right-joining letters have no initial or medial form.
+type joining [4]rune
+
+func (j joining) dual() bool {
+	return j[2] != 0
+}
+
+var letterForms = map[rune]joining{
+	'ء': {0xFE80, 0, 0, 0},
+	'آ': {0xFE81, 0xFE82, 0, 0},
+	'أ': {0xFE83, 0xFE84, 0, 0},
+	'ؤ': {0xFE85, 0xFE86, 0, 0},
+	'إ': {0xFE87, 0xFE88, 0, 0},
+	'ئ': {0xFE89, 0xFE8A, 0xFE8B, 0xFE8C},
+	'ا': {0xFE8D, 0xFE8E, 0, 0},
+	'ب': {0xFE8F, 0xFE90, 0xFE91, 0xFE92},
+	'ة': {0xFE93, 0xFE94, 0, 0},
+	'ت': {0xFE95, 0xFE96, 0xFE97, 0xFE98},
+	'ث': {0xFE99, 0xFE9A, 0xFE9B, 0xFE9C},
+	'ج': {0xFE9D, 0xFE9E, 0xFE9F, 0xFEA0},
+	'ح': {0xFEA1, 0xFEA2, 0xFEA3, 0xFEA4},
+	'خ': {0xFEA5, 0xFEA6, 0xFEA7, 0xFEA8},
+	'د': {0xFEA9, 0xFEAA, 0, 0},
+	'ذ': {0xFEAB, 0xFEAC, 0, 0},
+	'ر': {0xFEAD, 0xFEAE, 0, 0},
+	'ز': {0xFEAF, 0xFEB0, 0, 0},
+	'س': {0xFEB1, 0xFEB2, 0xFEB3, 0xFEB4},
+	'ش': {0xFEB5, 0xFEB6, 0xFEB7, 0xFEB8},
+	'ص': {0xFEB9, 0xFEBA, 0xFEBB, 0xFEBC},
+	'ض': {0xFEBD, 0xFEBE, 0xFEBF, 0xFEC0},
+	'ط': {0xFEC1, 0xFEC2, 0xFEC3, 0xFEC4},
+	'ظ': {0xFEC5, 0xFEC6, 0xFEC7, 0xFEC8},
+	'ع': {0xFEC9, 0xFECA, 0xFECB, 0xFECC},
+	'غ': {0xFECD, 0xFECE, 0xFECF, 0xFED0},
+	'ف': {0xFED1, 0xFED2, 0xFED3, 0xFED4},
+	'ق': {0xFED5, 0xFED6, 0xFED7, 0xFED8},
+	'ك': {0xFED9, 0xFEDA, 0xFEDB, 0xFEDC},
+	'ل': {0xFEDD, 0xFEDE, 0xFEDF, 0xFEE0},
+	'م': {0xFEE1, 0xFEE2, 0xFEE3, 0xFEE4},
+	'ن': {0xFEE5, 0xFEE6, 0xFEE7, 0xFEE8},
+	'ه': {0xFEE9, 0xFEEA, 0xFEEB, 0xFEEC},
+	'و': {0xFEED, 0xFEEE, 0, 0},
+	'ى': {0xFEEF, 0xFEF0, 0, 0},
+	'ي': {0xFEF1, 0xFEF2, 0xFEF3, 0xFEF4},
+	'پ': {0xFB56, 0xFB57, 0xFB58, 0xFB59},
+	'چ': {0xFB7A, 0xFB7B, 0xFB7C, 0xFB7D},
+	'ژ': {0xFB8A, 0xFB8B, 0, 0},
+	'ک': {0xFB8E, 0xFB8F, 0xFB90, 0xFB91},
+	'گ': {0xFB92, 0xFB93, 0xFB94, 0xFB95},
+	'ی': {0xFBFC, 0xFBFD, 0xFBFE, 0xFBFF},
+}
+
+// lamAlef holds the isolated and final forms of the lam-alef ligatures
+var lamAlef = map[rune][2]rune{
+	'آ': {0xFEF5, 0xFEF6},
+	'أ': {0xFEF7, 0xFEF8},
+	'إ': {0xFEF9, 0xFEFA},
+	'ا': {0xFEFB, 0xFEFC},
+}
+
+const (
+	lam     = 'ل'
+	tatweel = 'ـ'
+	zwj     = '\u200d'
+)
+
+// visual shapes Persian text and puts it in display order, as fonts are drawn
+// left to right glyph by glyph without any shaping of their own
+func visual(text string) string {
+	return reorder(shape([]rune(text)))
+}
+
+// shape replaces Arabic script letters with the presentation form matching
+// how they join their neighbours
+func shape(text []rune) []rune {
+	shaped := make([]rune, 0, len(text))
+	for i := 0; i < len(text); i++ {
+		r := text[i]
+		forms, ok := letterForms[r]
+		if !ok {
+			shaped = append(shaped, r)
+			continue
+		}
+
+		prev, next := neighbour(text, i, -1), neighbour(text, i, 1)
+		joinsPrev := prev >= 0 && joinsForward(text[prev])
+
+		if r == lam && next >= 0 {
+			if ligature, ok := lamAlef[text[next]]; ok {
+				if joinsPrev {
+					shaped = append(shaped, ligature[1])
+				} else {
+					shaped = append(shaped, ligature[0])
+				}
+				shaped = append(shaped, text[i+1:next]...)
+				i = next
+				continue
+			}
+		}
+
+		joinsNext := forms.dual() && next >= 0 && joinsBackward(text[next])
+		switch {
+		case joinsPrev && joinsNext:
+			shaped = append(shaped, forms[3])
+		case joinsNext:
+			shaped = append(shaped, forms[2])
+		case joinsPrev && forms[1] != 0:
+			shaped = append(shaped, forms[1])
+		default:
+			shaped = append(shaped, forms[0])
+		}
+	}
+	return shaped
+}
+
+// neighbour returns the index of the closest rune in direction step that is
+// not a combining mark, or -1
+func neighbour(text []rune, i, step int) int {
+	for j := i + step; j >= 0 && j < len(text); j += step {
+		if !unicode.Is(unicode.Mn, text[j]) {
+			return j
+		}
+	}
+	return -1
+}
+
+// joinsForward reports whether r connects to the letter after it
+func joinsForward(r rune) bool {
+	if r == tatweel || r == zwj {
+		return true
+	}
+	forms, ok := letterForms[r]
+	return ok && forms.dual()
+}
+
+// joinsBackward reports whether r connects to the letter before it
+func joinsBackward(r rune) bool {
+	if r == tatweel || r == zwj {
+		return true
+	}
+	forms, ok := letterForms[r]
+	return ok && forms[1] != 0
+}
+
+type direction int
+
+const (
+	neutral direction = iota
+	leftToRight
+	rightToLeft
+)
+
+func directionOf(r rune) direction {
+	switch {
+	case r >= '0' && r <= '9', r >= '۰' && r <= '۹', r >= '٠' && r <= '٩':
+		return leftToRight
+	case r >= 0x0600 && r <= 0x06FF, r >= 0xFB50 && r <= 0xFDFF, r >= 0xFE70 && r <= 0xFEFF:
+		return rightToLeft
+	case unicode.IsLetter(r):
+		return leftToRight
+	}
+	return neutral
+}
+
+var mirrored = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'«': '»', '»': '«',
+}
+
+// reorder is a reduced form of the Unicode bidirectional algorithm for single
+// lines: numbers and Latin runs keep their order, everything else follows the
+// paragraph direction, which is taken from the first strong letter and
+// defaults to right to left
+func reorder(text []rune) string {
+	base := rightToLeft
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			base = directionOf(r)
+			break
+		}
+	}
+
+	levels := make([]direction, len(text))
+	for i, r := range text {
+		levels[i] = directionOf(r)
+	}
+	// Neutrals take the direction of their surroundings when both sides
+	// agree and the paragraph direction otherwise
+	for i := 0; i < len(levels); {
+		if levels[i] != neutral {
+			i++
+			continue
+		}
+		j := i
+		for j < len(levels) && levels[j] == neutral {
+			j++
+		}
+		resolved := base
+		if i > 0 && j < len(levels) && levels[i-1] == levels[j] {
+			resolved = levels[j]
+		}
+		for k := i; k < j; k++ {
+			levels[k] = resolved
+		}
+		i = j
+	}
+
+	out := make([]rune, len(text))
+	copy(out, text)
+	for i, r := range out {
+		if m, ok := mirrored[r]; ok && levels[i] == rightToLeft {
+			out[i] = m
+		}
+	}
+
+	if base == rightToLeft {
+		reverse(out)
+		reverse(levels)
+		reverseRuns(out, levels, leftToRight)
+	} else {
+		reverseRuns(out, levels, rightToLeft)
+	}
+	return string(out)
+}
+
+// reverseRuns reverses every run of runes with direction d in place
+func reverseRuns(text []rune, levels []direction, d direction) {
+	for i := 0; i < len(text); {
+		if levels[i] != d {
+			i++
+			continue
+		}
+		j := i
+		for j < len(text) && levels[j] == d {
+			j++
+		}
+		reverse(text[i:j])
+		i = j
+	}
+}
+
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
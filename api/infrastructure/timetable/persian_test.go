@@ -0,0 +1,45 @@
+package timetable
+
+import "testing"
+
+func TestVisual(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []rune
+	}{
+		{
+			name: "joins letters and forms lam-alef",
+			text: "سلام",
+			want: []rune{0xFEE1, 0xFEFC, 0xFEB3},
+		},
+		{
+			name: "breaks after right-joining letters",
+			text: "گروه",
+			want: []rune{0xFEE9, 0xFEED, 0xFEAE, 0xFB94},
+		},
+		{
+			name: "keeps numbers left to right",
+			text: "کلاس 203",
+			want: []rune{'2', '0', '3', ' ', 0xFEB1, 0xFEFC, 0xFB90},
+		},
+		{
+			name: "mirrors brackets in right-to-left text",
+			text: "(ریاضی)",
+			want: []rune{'(', 0xFBFD, 0xFEBF, 0xFE8E, 0xFBFE, 0xFEAD, ')'},
+		},
+		{
+			name: "leaves Latin text alone",
+			text: "Plan (A)",
+			want: []rune("Plan (A)"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := visual(tt.text); got != string(tt.want) {
+				t.Errorf("visual(%q) = %U, want %U", tt.text, []rune(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package timetable
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/armanjr/termustat/api/dto"
+	"golang.org/x/image/font/opentype"
+)
+
+// Format is an output format of a rendered timetable
+type Format string
+
+const (
+	FormatPDF Format = "pdf"
+	FormatPNG Format = "png"
+)
+
+// Timetable is a weekly schedule to render with the exams of its courses
+type Timetable struct {
+	Title    string
+	Subtitle string
+	Courses  []dto.CourseResponse
+}
+
+// Renderer draws printable, right-to-left Persian timetables.
+type Renderer interface {
+	// Render draws the Saturday to Thursday grid of the timetable's classes
+	// with its exam table below, as a PDF or PNG file.
+	Render(timetable *Timetable, format Format) ([]byte, error)
+}
+
+// RendererConfig holds configuration values for the renderer.
+type RendererConfig struct {
+	// FontPath is a TrueType font with Arabic presentation forms, such as DejaVu Sans
+	FontPath string
+	// Location is the timezone exam times are shown in
+	Location *time.Location
+}
+
+type rendererImpl struct {
+	fontPath string
+	location *time.Location
+
+	fontOnce sync.Once
+	font     *opentype.Font
+	fontErr  error
+}
+
+// NewRenderer creates a Renderer. The font is loaded on first use so a
+// missing font only disables rendering.
+func NewRenderer(cfg RendererConfig) Renderer {
+	return &rendererImpl{
+		fontPath: cfg.FontPath,
+		location: cfg.Location,
+	}
+}
+
+// Render draws the timetable and encodes it in format.
+func (r *rendererImpl) Render(timetable *Timetable, format Format) ([]byte, error) {
+	if format != FormatPDF && format != FormatPNG {
+		return nil, fmt.Errorf("unsupported timetable format %q", format)
+	}
+
+	f, err := r.loadFont()
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := drawTimetable(timetable, f, r.location)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == FormatPDF {
+		return encodePDF(img)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode timetable: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *rendererImpl) loadFont() (*opentype.Font, error) {
+	r.fontOnce.Do(func() {
+		data, err := os.ReadFile(r.fontPath)
+		if err != nil {
+			r.fontErr = fmt.Errorf("failed to read timetable font: %w", err)
+			return
+		}
+		r.font, r.fontErr = opentype.Parse(data)
+		if r.fontErr != nil {
+			r.fontErr = fmt.Errorf("failed to parse timetable font: %w", r.fontErr)
+		}
+	})
+	return r.font, r.fontErr
+}
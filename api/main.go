@@ -9,12 +9,14 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/armanjr/termustat/api/app"
 	"github.com/armanjr/termustat/api/config"
 	"github.com/armanjr/termustat/api/database"
 	"github.com/armanjr/termustat/api/handlers"
 	"github.com/armanjr/termustat/api/infrastructure/mailer"
+	"github.com/armanjr/termustat/api/infrastructure/timetable"
 	"github.com/armanjr/termustat/api/logger"
 	"github.com/armanjr/termustat/api/repositories"
 	"github.com/armanjr/termustat/api/routes"
@@ -41,6 +43,11 @@ func main() {
 		stdLog.Fatal("Failed to set timezone:", err)
 	}
 
+	location, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		stdLog.Fatal("Failed to load timezone:", err)
+	}
+
 	// Initialize logger
 	log := logger.NewLogger()
 	defer log.Sync()
@@ -59,6 +66,11 @@ func main() {
 	}
 	mailerService := mailer.NewMailer(mailerConfig, log)
 
+	timetableRenderer := timetable.NewRenderer(timetable.RendererConfig{
+		FontPath: cfg.TimetableFont,
+		Location: location,
+	})
+
 	// Initialize database
 	db, err := database.NewDatabase(cfg.GetDatabaseConfig())
 	if err != nil {
@@ -78,8 +90,13 @@ func main() {
 	semesterRepo := repositories.NewSemesterRepository(db)
 	facultyRepo := repositories.NewFacultyRepository(db)
 	courseRepo := repositories.NewCourseRepository(db)
+	courseImportPlanRepo := repositories.NewCourseImportPlanRepository(db)
 	adminUserRepo := repositories.NewAdminUserRepository(db)
 	userCourseRepo := repositories.NewUserCourseRepository(db)
+	scheduleRepo := repositories.NewScheduleRepository(db)
+	scheduleShareRepo := repositories.NewScheduleShareRepository(db)
+	universitySemesterRepo := repositories.NewUniversitySemesterRepository(db)
+	searchRepo := repositories.NewSearchRepository(db)
 
 	// Internal services
 	authService := services.NewAuthService(
@@ -93,12 +110,24 @@ func main() {
 		cfg.FrontendURL,
 	)
 	universityService := services.NewUniversityService(universityRepo, log)
-	professorService := services.NewProfessorService(professorRepo, universityService, log)
 	semesterService := services.NewSemesterService(semesterRepo, log)
 	facultyService := services.NewFacultyService(facultyRepo, universityService, log)
-	courseService := services.NewCourseService(courseRepo, universityService, facultyService, professorService, semesterService, log)
+	professorService := services.NewProfessorService(professorRepo, courseRepo, universityService, facultyService, semesterService, location, log)
+	courseService := services.NewCourseService(courseRepo, courseImportPlanRepo, universityService, facultyService, professorService, semesterService, location, log)
 	adminUserService := services.NewAdminUserService(adminUserRepo, universityService, facultyService, log)
-	userCourseService := services.NewUserCourseService(userCourseRepo, courseService, adminUserService, semesterService, log)
+	universitySemesterService := services.NewUniversitySemesterService(universitySemesterRepo, universityService, semesterService, adminUserService, log)
+	userCourseService := services.NewUserCourseService(
+		userCourseRepo,
+		scheduleRepo,
+		courseService,
+		adminUserService,
+		semesterService,
+		universitySemesterService,
+		services.ExamConflictPolicy{Overlap: cfg.ExamOverlapPolicy, SameDay: cfg.ExamSameDayPolicy},
+		log,
+	)
+	searchService := services.NewSearchService(searchRepo, facultyService, location, log)
+	scheduleService := services.NewScheduleService(scheduleRepo, scheduleShareRepo, userCourseService, courseService, semesterService, universitySemesterService, timetableRenderer, location, log)
 
 	// Initialize router
 	router := gin.New()
@@ -130,15 +159,18 @@ func main() {
 
 	// Initialize handlers
 	ginHandlers := &routes.Handlers{
-		Auth:       handlers.NewAuthHandler(authService, universityService, facultyService, log),
-		Professor:  handlers.NewProfessorHandler(professorService, log),
-		University: handlers.NewUniversityHandler(universityService, log),
-		Semester:   handlers.NewSemesterHandler(semesterService, log),
-		Faculty:    handlers.NewFacultyHandler(facultyService, log),
-		Course:     handlers.NewCourseHandler(courseService, log),
-		AdminUser:  handlers.NewAdminUserHandler(adminUserService, log),
-		UserCourse: handlers.NewUserCourseHandler(userCourseService, log),
-		Health:     handlers.NewHealthHandler(log),
+		Auth:               handlers.NewAuthHandler(authService, universityService, facultyService, log),
+		Professor:          handlers.NewProfessorHandler(professorService, log),
+		University:         handlers.NewUniversityHandler(universityService, log),
+		Semester:           handlers.NewSemesterHandler(semesterService, log),
+		Faculty:            handlers.NewFacultyHandler(facultyService, log),
+		Course:             handlers.NewCourseHandler(courseService, semesterService, cfg.ImportProfiles, log),
+		AdminUser:          handlers.NewAdminUserHandler(adminUserService, log),
+		UserCourse:         handlers.NewUserCourseHandler(userCourseService, scheduleService, universitySemesterService, log),
+		Schedule:           handlers.NewScheduleHandler(scheduleService, log),
+		Health:             handlers.NewHealthHandler(log),
+		UniversitySemester: handlers.NewUniversitySemesterHandler(universitySemesterService, log),
+		Search:             handlers.NewSearchHandler(searchService, log),
 	}
 
 	// Setup routes
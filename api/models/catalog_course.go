@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// CatalogCourse is a course as the university defines it, independent of any
+// semester. The Course rows pointing at it are its sections (group numbers).
+type CatalogCourse struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UniversityID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:catalog_courses_university_id_code_key"`
+	FacultyID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Code         string    `gorm:"not null;size:50;uniqueIndex:catalog_courses_university_id_code_key"`
+	Name         string    `gorm:"not null;size:255"`
+	Weight       int       `gorm:"not null"`
+	Sections     []Course
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+}
+
+func (CatalogCourse) TableName() string {
+	return "catalog_courses"
+}
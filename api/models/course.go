@@ -10,12 +10,20 @@ type Course struct {
 	UniversityID      uuid.UUID `gorm:"type:uuid;not null;index"`
 	FacultyID         uuid.UUID `gorm:"type:uuid;not null;index"`
 	ProfessorID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	CatalogCourseID   uuid.UUID `gorm:"type:uuid;not null;index"`
 	SemesterID        uuid.UUID `gorm:"type:uuid;not null;index"`
 	Code              string    `gorm:"not null;size:50"`
 	Name              string    `gorm:"not null;size:255"`
 	Weight            int       `gorm:"not null"`
 	Capacity          int
 	GenderRestriction string `gorm:"size:6;check:gender_restriction IN ('male', 'female', 'mixed')"`
+	GroupNumber       string `gorm:"not null;size:10"`
+	PracticalWeight   int    `gorm:"not null;default:0"`
+	Enrolled          int    `gorm:"not null;default:0"`
+	WaitingList       int    `gorm:"not null;default:0"`
+	Notes             string `gorm:"type:text"`
+	CrossCampus       bool   `gorm:"not null;default:false"`
+	EmergencyDrop     bool   `gorm:"not null;default:false"`
 	ExamStart         time.Time
 	ExamEnd           time.Time
 	Professor         Professor
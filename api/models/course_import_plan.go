@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CourseImportPlan struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UniversityID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	SemesterID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	CreatedBy     uuid.UUID `gorm:"type:uuid;not null"`
+	Mode          string    `gorm:"not null;size:6;check:mode IN ('create', 'sync')"`
+	DeleteMissing bool      `gorm:"not null;default:false"`
+	Records       string    `gorm:"type:jsonb;not null"`
+	Fingerprint   string    `gorm:"not null;size:64"`
+	ExpiresAt     time.Time `gorm:"not null"`
+	CommittedAt   *time.Time
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+func (CourseImportPlan) TableName() string {
+	return "course_import_plans"
+}
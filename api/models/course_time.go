@@ -5,14 +5,21 @@ import (
 	"time"
 )
 
+// CourseTime is a weekly session of a course. WeekParity is "odd" or "even"
+// for sessions held every other week, HalfTerm is 1 or 2 for sessions held in
+// only one half of the term.
 type CourseTime struct {
-	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	CourseID  uuid.UUID `gorm:"type:uuid;not null;index"`
-	DayOfWeek int       `gorm:"check:day_of_week BETWEEN 0 AND 6"`
-	StartTime time.Time `gorm:"type:time;not null"`
-	EndTime   time.Time `gorm:"type:time;not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CourseID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	DayOfWeek  int       `gorm:"check:day_of_week BETWEEN 0 AND 6"`
+	StartTime  time.Time `gorm:"type:time;not null"`
+	EndTime    time.Time `gorm:"type:time;not null"`
+	Room       string    `gorm:"not null;size:50"`
+	Location   string    `gorm:"not null;size:255"`
+	WeekParity string    `gorm:"not null;size:4;check:week_parity IN ('', 'odd', 'even')"`
+	HalfTerm   int       `gorm:"not null;default:0;check:half_term BETWEEN 0 AND 2"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
 }
 
 func (CourseTime) TableName() string {
@@ -5,13 +5,21 @@ import (
 	"time"
 )
 
+// Professor teaches courses at a university. AcademicRank is one of lecturer,
+// instructor, assistant_professor, associate_professor or professor, or empty
+// when unknown.
 type Professor struct {
-	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	UniversityID   uuid.UUID  `gorm:"type:uuid;not null;index"`
-	Name           string     `gorm:"not null;size:255"`
-	NormalizedName string     `gorm:"not null;size:255;index"`
-	University     University `gorm:"foreignKey:UniversityID"`
-	Courses        []Course   `gorm:"foreignKey:ProfessorID"`
-	CreatedAt      time.Time  `gorm:"autoCreateTime"`
-	UpdatedAt      time.Time  `gorm:"autoUpdateTime"`
+	ID             uuid.UUID        `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UniversityID   uuid.UUID        `gorm:"type:uuid;not null;index"`
+	Name           string           `gorm:"not null;size:255"`
+	NormalizedName string           `gorm:"not null;size:255;index"`
+	Email          string           `gorm:"not null;default:'';size:255"`
+	FacultyID      *uuid.UUID       `gorm:"type:uuid;index"`
+	AcademicRank   string           `gorm:"not null;default:'';size:20;check:academic_rank IN ('', 'lecturer', 'instructor', 'assistant_professor', 'associate_professor', 'professor')"`
+	Website        string           `gorm:"not null;default:'';size:255"`
+	University     University       `gorm:"foreignKey:UniversityID"`
+	Courses        []Course         `gorm:"foreignKey:ProfessorID"`
+	Aliases        []ProfessorAlias `gorm:"foreignKey:ProfessorID"`
+	CreatedAt      time.Time        `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time        `gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ProfessorAlias is another spelling of a professor's name, kept when
+// duplicates are merged so later imports resolve to the same professor
+type ProfessorAlias struct {
+	ID             uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	ProfessorID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	UniversityID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_professor_aliases_name"`
+	Name           string    `gorm:"not null;size:255"`
+	NormalizedName string    `gorm:"not null;size:255;uniqueIndex:idx_professor_aliases_name"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Schedule is one of a user's draft plans for a semester. The active schedule
+// is the one course selection works on by default and the one that counts
+// towards course capacity.
+type Schedule struct {
+	ID         uuid.UUID    `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     uuid.UUID    `gorm:"type:uuid;not null;index"`
+	SemesterID uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Name       string       `gorm:"not null;size:100"`
+	Position   int          `gorm:"not null;default:0"`
+	IsActive   bool         `gorm:"not null;default:false"`
+	Courses    []UserCourse `gorm:"foreignKey:ScheduleID"`
+	CreatedAt  time.Time    `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time    `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ScheduleShare publishes a schedule read-only under an unguessable token.
+// A nil ExpiresAt never expires.
+type ScheduleShare struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	ScheduleID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Schedule   Schedule
+	Token      string `gorm:"uniqueIndex;not null"`
+	ExpiresAt  *time.Time
+	Revoked    bool      `gorm:"default:false"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
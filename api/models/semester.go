@@ -8,7 +8,7 @@ import (
 type Semester struct {
 	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
 	Year      int       `gorm:"not null;index"`
-	Term      string    `gorm:"not null;size:6;check:term IN ('spring', 'fall');index"`
+	Term      string    `gorm:"not null;size:6;check:term IN ('spring', 'fall', 'summer');index"`
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
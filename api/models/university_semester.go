@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// UniversitySemester is a university's calendar for a semester. Unset windows
+// leave course selection unrestricted; at most one semester per university is
+// current.
+type UniversitySemester struct {
+	ID                uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UniversityID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_university_semesters_semester"`
+	SemesterID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_university_semesters_semester"`
+	Semester          Semester
+	StartDate         *time.Time `gorm:"type:date"`
+	EndDate           *time.Time `gorm:"type:date"`
+	RegistrationStart *time.Time
+	RegistrationEnd   *time.Time
+	AddDropStart      *time.Time
+	AddDropEnd        *time.Time
+	ExamStart         *time.Time `gorm:"type:date"`
+	ExamEnd           *time.Time `gorm:"type:date"`
+	IsCurrent         bool       `gorm:"not null;default:false"`
+	CreatedAt         time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `gorm:"autoUpdateTime"`
+}
@@ -10,6 +10,8 @@ type UserCourse struct {
 	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
 	CourseID   uuid.UUID `gorm:"type:uuid;not null;index"`
 	SemesterID uuid.UUID `gorm:"type:uuid;not null;index"`
+	ScheduleID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Course     Course
 	CreatedAt  time.Time `gorm:"autoCreateTime"`
 	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
 }
@@ -4,8 +4,12 @@ import (
 	"github.com/armanjr/termustat/api/dto"
 	"github.com/armanjr/termustat/api/errors"
 	"github.com/armanjr/termustat/api/models"
+	"github.com/armanjr/termustat/api/utils"
+	"github.com/armanjr/termustat/engine/schema"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
 )
 
 type CourseRepository interface {
@@ -14,11 +18,17 @@ type CourseRepository interface {
 	FindAllBySemester(semesterID uuid.UUID) ([]*models.Course, error)
 	FindAllByFaculty(facultyID uuid.UUID) ([]*models.Course, error)
 	FindAllByProfessor(professorID uuid.UUID) ([]*models.Course, error)
-	FindByUniversityAndCode(universityID uuid.UUID, code string) (*models.Course, error)
+	FindAllByUniversityAndSemester(universityID, semesterID uuid.UUID) ([]*models.Course, error)
+	FindBySemesterAndCode(universityID, semesterID uuid.UUID, code string) (*models.Course, error)
+	FindCatalogCourse(id uuid.UUID) (*models.CatalogCourse, error)
+	FindCatalogCourseByCode(universityID uuid.UUID, code string) (*models.CatalogCourse, error)
+	FindSections(catalogCourseID, semesterID uuid.UUID) ([]*models.Course, error)
 	Update(course *models.Course) (*models.Course, error)
 	Delete(id uuid.UUID) error
 	BatchCreate(courses []*models.Course) ([]*models.Course, error)
+	Sync(professors []*models.Professor, created, updated []*models.Course, deletedIDs []uuid.UUID) error
 	Search(filters *dto.CourseSearchFilters) ([]models.Course, error)
+	FindCatalog(filters *dto.CourseCatalogFilters, pagination *dto.PaginationQuery) (*dto.PaginatedList[models.Course], error)
 }
 
 type courseRepository struct {
@@ -37,16 +47,19 @@ func (r *courseRepository) Create(course *models.Course) (*models.Course, error)
 		}
 	}()
 
-	if err := tx.Create(course).Error; err != nil {
+	if err := attachCatalogCourse(tx, course); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Omit(clause.Associations).Create(course).Error; err != nil {
 		tx.Rollback()
 		return nil, errors.Wrap(err, "failed to create course")
 	}
 
-	if len(course.CourseTimes) > 0 {
-		if err := tx.Create(&course.CourseTimes).Error; err != nil {
-			tx.Rollback()
-			return nil, errors.Wrap(err, "failed to create course times")
-		}
+	if err := createCourseTimes(tx, course); err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -63,7 +76,7 @@ func (r *courseRepository) Create(course *models.Course) (*models.Course, error)
 
 func (r *courseRepository) Find(id uuid.UUID) (*models.Course, error) {
 	var course models.Course
-	err := r.db.Preload("CourseTimes").First(&course, "id = ?", id).Error
+	err := r.db.Preload("CourseTimes").Preload("Professor").First(&course, "id = ?", id).Error
 	if err != nil {
 		switch {
 		case errors.Is(err, gorm.ErrRecordNotFound):
@@ -108,10 +121,21 @@ func (r *courseRepository) FindAllByProfessor(professorID uuid.UUID) ([]*models.
 	return courses, nil
 }
 
-func (r *courseRepository) FindByUniversityAndCode(universityID uuid.UUID, code string) (*models.Course, error) {
+func (r *courseRepository) FindAllByUniversityAndSemester(universityID, semesterID uuid.UUID) ([]*models.Course, error) {
+	var courses []*models.Course
+	err := r.db.Preload("CourseTimes").Preload("Professor").
+		Where("university_id = ? AND semester_id = ?", universityID, semesterID).
+		Find(&courses).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find courses by university and semester")
+	}
+	return courses, nil
+}
+
+func (r *courseRepository) FindBySemesterAndCode(universityID, semesterID uuid.UUID, code string) (*models.Course, error) {
 	var course models.Course
 	err := r.db.Preload("CourseTimes").
-		Where("university_id = ? AND code = ?", universityID, code).
+		Where("university_id = ? AND semester_id = ? AND code = ?", universityID, semesterID, code).
 		First(&course).Error
 	if err != nil {
 		switch {
@@ -124,6 +148,47 @@ func (r *courseRepository) FindByUniversityAndCode(universityID uuid.UUID, code
 	return &course, nil
 }
 
+func (r *courseRepository) FindCatalogCourse(id uuid.UUID) (*models.CatalogCourse, error) {
+	var catalogCourse models.CatalogCourse
+	err := r.db.First(&catalogCourse, "id = ?", id).Error
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, errors.NewNotFoundError("catalog course", id.String())
+		default:
+			return nil, errors.Wrap(err, "failed to find catalog course")
+		}
+	}
+	return &catalogCourse, nil
+}
+
+func (r *courseRepository) FindCatalogCourseByCode(universityID uuid.UUID, code string) (*models.CatalogCourse, error) {
+	var catalogCourse models.CatalogCourse
+	err := r.db.First(&catalogCourse, "university_id = ? AND code = ?", universityID, code).Error
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, errors.NewNotFoundError("catalog course", code)
+		default:
+			return nil, errors.Wrap(err, "failed to find catalog course by code")
+		}
+	}
+	return &catalogCourse, nil
+}
+
+// FindSections returns every section of a catalog course offered in a semester
+func (r *courseRepository) FindSections(catalogCourseID, semesterID uuid.UUID) ([]*models.Course, error) {
+	var courses []*models.Course
+	err := r.db.Preload("CourseTimes").
+		Where("catalog_course_id = ? AND semester_id = ?", catalogCourseID, semesterID).
+		Order("group_number").
+		Find(&courses).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find course sections")
+	}
+	return courses, nil
+}
+
 func (r *courseRepository) Update(course *models.Course) (*models.Course, error) {
 	tx := r.db.Begin()
 	defer func() {
@@ -138,18 +203,22 @@ func (r *courseRepository) Update(course *models.Course) (*models.Course, error)
 		return nil, errors.Wrap(err, "failed to delete existing course times")
 	}
 
+	// The code may have moved the section to another catalog course
+	if err := attachCatalogCourse(tx, course); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	// Update course
-	if err := tx.Save(course).Error; err != nil {
+	if err := tx.Omit(clause.Associations).Save(course).Error; err != nil {
 		tx.Rollback()
 		return nil, errors.Wrap(err, "failed to update course")
 	}
 
 	// Create new course times
-	if len(course.CourseTimes) > 0 {
-		if err := tx.Create(&course.CourseTimes).Error; err != nil {
-			tx.Rollback()
-			return nil, errors.Wrap(err, "failed to create new course times")
-		}
+	if err := createCourseTimes(tx, course); err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 
 	if err := tx.Commit().Error; err != nil {
@@ -198,17 +267,21 @@ func (r *courseRepository) BatchCreate(courses []*models.Course) ([]*models.Cour
 		}
 	}()
 
-	for _, course := range courses {
-		if err := tx.Create(course).Error; err != nil {
+	ids := make([]uuid.UUID, len(courses))
+	for i, course := range courses {
+		if err := attachCatalogCourse(tx, course); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err := tx.Omit(clause.Associations).Create(course).Error; err != nil {
 			tx.Rollback()
 			return nil, errors.Wrap(err, "failed to create course in batch")
 		}
+		ids[i] = course.ID
 
-		if len(course.CourseTimes) > 0 {
-			if err := tx.Create(&course.CourseTimes).Error; err != nil {
-				tx.Rollback()
-				return nil, errors.Wrap(err, "failed to create course times in batch")
-			}
+		if err := createCourseTimes(tx, course); err != nil {
+			tx.Rollback()
+			return nil, errors.Wrap(err, "failed to create course times in batch")
 		}
 	}
 
@@ -217,13 +290,116 @@ func (r *courseRepository) BatchCreate(courses []*models.Course) ([]*models.Cour
 	}
 
 	var created []*models.Course
-	if err := r.db.Preload("CourseTimes").Find(&created).Error; err != nil {
+	if err := r.db.Preload("CourseTimes").Where("id IN ?", ids).Find(&created).Error; err != nil {
 		return nil, errors.Wrap(err, "failed to fetch created courses")
 	}
 
 	return created, nil
 }
 
+// Sync applies an import diff in a single transaction, creating the
+// professors it introduces first
+func (r *courseRepository) Sync(professors []*models.Professor, created, updated []*models.Course, deletedIDs []uuid.UUID) error {
+	tx := r.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for _, professor := range professors {
+		if err := tx.Omit(clause.Associations).Create(professor).Error; err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "failed to create professor in sync")
+		}
+	}
+
+	for _, course := range created {
+		if err := attachCatalogCourse(tx, course); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(course).Error; err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "failed to create course in sync")
+		}
+		if err := createCourseTimes(tx, course); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for _, course := range updated {
+		if err := tx.Where("course_id = ?", course.ID).Delete(&models.CourseTime{}).Error; err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "failed to delete existing course times in sync")
+		}
+		if err := attachCatalogCourse(tx, course); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(course).Error; err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "failed to update course in sync")
+		}
+		if err := createCourseTimes(tx, course); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if len(deletedIDs) > 0 {
+		if err := tx.Where("course_id IN ?", deletedIDs).Delete(&models.CourseTime{}).Error; err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "failed to delete course times in sync")
+		}
+		if err := tx.Where("id IN ?", deletedIDs).Delete(&models.Course{}).Error; err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "failed to delete courses in sync")
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return errors.Wrap(err, "failed to commit sync")
+	}
+
+	return nil
+}
+
+// attachCatalogCourse links a section to the catalog course of its code,
+// creating the catalog course the first time the code is seen
+func attachCatalogCourse(tx *gorm.DB, course *models.Course) error {
+	code, group := schema.SplitCourseCode(course.Code)
+	if course.GroupNumber == "" {
+		course.GroupNumber = group
+	}
+
+	var catalogCourse models.CatalogCourse
+	err := tx.Where(models.CatalogCourse{UniversityID: course.UniversityID, Code: code}).
+		Attrs(models.CatalogCourse{FacultyID: course.FacultyID, Name: course.Name, Weight: course.Weight}).
+		FirstOrCreate(&catalogCourse).Error
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve catalog course")
+	}
+
+	course.CatalogCourseID = catalogCourse.ID
+	return nil
+}
+
+func createCourseTimes(tx *gorm.DB, course *models.Course) error {
+	if len(course.CourseTimes) == 0 {
+		return nil
+	}
+	for i := range course.CourseTimes {
+		course.CourseTimes[i].ID = uuid.Nil
+		course.CourseTimes[i].CourseID = course.ID
+	}
+	if err := tx.Create(&course.CourseTimes).Error; err != nil {
+		return errors.Wrap(err, "failed to create course times")
+	}
+	return nil
+}
+
 func (r *courseRepository) Search(filters *dto.CourseSearchFilters) ([]models.Course, error) {
 	var courses []models.Course
 
@@ -231,18 +407,31 @@ func (r *courseRepository) Search(filters *dto.CourseSearchFilters) ([]models.Co
 
 	query = query.Joins("Faculty").Joins("Professor")
 
+	// professors have a faculty too, so columns of courses are qualified
 	if filters.FacultyID != uuid.Nil {
-		query = query.Where("faculty_id = ?", filters.FacultyID)
+		query = query.Where("courses.faculty_id = ?", filters.FacultyID)
 	}
 
 	if filters.ProfessorID != uuid.Nil {
-		query = query.Where("professor_id = ?", filters.ProfessorID)
+		query = query.Where("courses.professor_id = ?", filters.ProfessorID)
 	}
 
 	if filters.Query != "" {
-		searchQuery := "%" + filters.Query + "%"
-		query = query.Where("(LOWER(name) LIKE LOWER(?) OR LOWER(code) LIKE LOWER(?))",
-			searchQuery, searchQuery)
+		query = query.Where("(courses.search_key LIKE ? OR LOWER(courses.code) LIKE LOWER(?))",
+			"%"+likeEscaper.Replace(utils.SearchKey(filters.Query))+"%",
+			"%"+likeEscaper.Replace(filters.Query)+"%")
+	}
+
+	if filters.HasFreeSeats {
+		query = withFreeSeats(query, r.db)
+	}
+
+	if filters.CrossCampus != nil {
+		query = query.Where("courses.cross_campus = ?", *filters.CrossCampus)
+	}
+
+	if filters.EmergencyDrop != nil {
+		query = query.Where("courses.emergency_drop = ?", *filters.EmergencyDrop)
 	}
 
 	if err := query.Find(&courses).Error; err != nil {
@@ -251,3 +440,101 @@ func (r *courseRepository) Search(filters *dto.CourseSearchFilters) ([]models.Co
 
 	return courses, nil
 }
+
+var catalogSortColumns = map[string]string{
+	"code":       "courses.code",
+	"name":       "courses.name",
+	"weight":     "courses.weight",
+	"capacity":   "courses.capacity",
+	"free_seats": "courses.capacity - GREATEST(courses.enrolled, (?))",
+	"exam":       "courses.exam_start",
+}
+
+// courseSeatsTaken counts the seats taken of the course of the enclosing
+// query, see takenSeats
+func courseSeatsTaken(db *gorm.DB) *gorm.DB {
+	return takenSeats(db).Select("COUNT(*)").Where("user_courses.course_id = courses.id")
+}
+
+// withFreeSeats keeps the sections with seats left by both counts: the
+// students Golestan reports enrolled and the selections of active schedules
+func withFreeSeats(query, db *gorm.DB) *gorm.DB {
+	return query.Where("courses.capacity > courses.enrolled AND courses.capacity > (?)", courseSeatsTaken(db))
+}
+
+// FindCatalog returns a page of a semester's courses matching the catalog filters
+func (r *courseRepository) FindCatalog(filters *dto.CourseCatalogFilters, pagination *dto.PaginationQuery) (*dto.PaginatedList[models.Course], error) {
+	var courses []models.Course
+	var total int64
+
+	query := r.db.Model(&models.Course{}).
+		Where("courses.university_id = ? AND courses.semester_id = ?", filters.UniversityID, filters.SemesterID)
+
+	if filters.FacultyID != uuid.Nil {
+		query = query.Where("courses.faculty_id = ?", filters.FacultyID)
+	}
+
+	if filters.ProfessorID != uuid.Nil {
+		query = query.Where("courses.professor_id = ?", filters.ProfessorID)
+	}
+
+	if filters.Weight > 0 {
+		query = query.Where("courses.weight = ?", filters.Weight)
+	}
+
+	if filters.Gender != "" {
+		query = query.Where("courses.gender_restriction IN ?", []string{filters.Gender, "mixed"})
+	}
+
+	if filters.HasFreeSeats {
+		query = withFreeSeats(query, r.db)
+	}
+
+	if filters.Day != nil || filters.From != "" || filters.To != "" {
+		sessions := r.db.Model(&models.CourseTime{}).
+			Select("1").
+			Where("course_times.course_id = courses.id")
+		if filters.Day != nil {
+			sessions = sessions.Where("course_times.day_of_week = ?", *filters.Day)
+		}
+		if filters.From != "" {
+			sessions = sessions.Where("course_times.start_time >= ?", filters.From)
+		}
+		if filters.To != "" {
+			sessions = sessions.Where("course_times.end_time <= ?", filters.To)
+		}
+		query = query.Where("EXISTS (?)", sessions)
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to count courses")
+	}
+
+	column, ok := catalogSortColumns[filters.Sort]
+	if !ok {
+		column = catalogSortColumns["code"]
+	}
+	direction := "ASC"
+	if strings.EqualFold(filters.Order, "desc") {
+		direction = "DESC"
+	}
+	order := clause.Expr{SQL: column + " " + direction + ", courses.code, courses.group_number"}
+	if filters.Sort == "free_seats" {
+		order.Vars = []interface{}{courseSeatsTaken(r.db)}
+	}
+
+	err := query.Preload("CourseTimes").Preload("Professor").
+		Order(clause.OrderBy{Expression: order}).
+		Limit(pagination.Limit).Offset(pagination.Offset).
+		Find(&courses).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch course catalog")
+	}
+
+	return &dto.PaginatedList[models.Course]{
+		Items: courses,
+		Total: total,
+		Page:  pagination.Page,
+		Limit: pagination.Limit,
+	}, nil
+}
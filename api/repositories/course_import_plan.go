@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type CourseImportPlanRepository interface {
+	Create(plan *models.CourseImportPlan) (*models.CourseImportPlan, error)
+	Find(id uuid.UUID) (*models.CourseImportPlan, error)
+	MarkCommitted(id uuid.UUID) error
+	Release(id uuid.UUID) error
+	CleanupExpired() error
+}
+
+type courseImportPlanRepository struct {
+	db *gorm.DB
+}
+
+func NewCourseImportPlanRepository(db *gorm.DB) CourseImportPlanRepository {
+	return &courseImportPlanRepository{db: db}
+}
+
+func (r *courseImportPlanRepository) Create(plan *models.CourseImportPlan) (*models.CourseImportPlan, error) {
+	if err := r.db.Create(plan).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to create course import plan")
+	}
+	return plan, nil
+}
+
+func (r *courseImportPlanRepository) Find(id uuid.UUID) (*models.CourseImportPlan, error) {
+	var plan models.CourseImportPlan
+	if err := r.db.First(&plan, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NewNotFoundError("course import plan", id.String())
+		}
+		return nil, errors.Wrap(err, "database error: failed to find course import plan")
+	}
+	return &plan, nil
+}
+
+// MarkCommitted claims the plan, failing with a conflict if it was already committed
+func (r *courseImportPlanRepository) MarkCommitted(id uuid.UUID) error {
+	result := r.db.Model(&models.CourseImportPlan{}).
+		Where("id = ? AND committed_at IS NULL", id).
+		Update("committed_at", time.Now())
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to mark course import plan as committed")
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewConflictError("course import plan already committed")
+	}
+	return nil
+}
+
+// Release reopens a plan whose commit failed
+func (r *courseImportPlanRepository) Release(id uuid.UUID) error {
+	return r.db.Model(&models.CourseImportPlan{}).
+		Where("id = ?", id).
+		Update("committed_at", nil).Error
+}
+
+func (r *courseImportPlanRepository) CleanupExpired() error {
+	return r.db.Where("expires_at <= ? AND committed_at IS NULL", time.Now()).
+		Delete(&models.CourseImportPlan{}).Error
+}
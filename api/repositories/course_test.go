@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// sqlRecorder records the statements of a dry run database
+type sqlRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *sqlRecorder) LogMode(logger.LogLevel) logger.Interface { return r }
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+// dryRunPool stands in for a connection in dry runs, which begin and commit
+// transactions without running a statement
+type dryRunPool struct {
+	gorm.ConnPool
+}
+
+func (p *dryRunPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) { return p, nil }
+func (p *dryRunPool) Commit() error                                                  { return nil }
+func (p *dryRunPool) Rollback() error                                                { return nil }
+
+// dryRunDB builds PostgreSQL statements without a database to run them on
+func dryRunDB(t *testing.T) (*gorm.DB, *sqlRecorder) {
+	recorder := &sqlRecorder{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: &dryRunPool{}}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               recorder,
+	})
+	require.NoError(t, err)
+	return db, recorder
+}
+
+// catalogQuery returns the statement FindCatalog fetches a page with
+func catalogQuery(t *testing.T, filters *dto.CourseCatalogFilters) string {
+	db, recorder := dryRunDB(t)
+	_, err := NewCourseRepository(db).FindCatalog(filters, &dto.PaginationQuery{Page: 1, Limit: 20})
+	require.NoError(t, err)
+	require.Len(t, recorder.statements, 2, "count and page")
+	return recorder.statements[1]
+}
+
+func TestFindCatalogFilters(t *testing.T) {
+	day := 2
+	query := catalogQuery(t, &dto.CourseCatalogFilters{
+		UniversityID: uuid.New(),
+		SemesterID:   uuid.New(),
+		FacultyID:    uuid.New(),
+		Day:          &day,
+		From:         "08:00",
+		To:           "12:00",
+		Weight:       3,
+		Gender:       "female",
+		HasFreeSeats: true,
+	})
+
+	assert.Contains(t, query, "courses.faculty_id = ")
+	assert.Contains(t, query, "courses.weight = 3")
+	assert.Contains(t, query, "courses.gender_restriction IN ('female','mixed')")
+	assert.Contains(t, query, "courses.capacity > courses.enrolled AND courses.capacity > (SELECT COUNT(*) FROM \"user_courses\" JOIN schedules ON schedules.id = user_courses.schedule_id WHERE schedules.is_active AND user_courses.course_id = courses.id)")
+	assert.Contains(t, query, "EXISTS (SELECT 1 FROM \"course_times\" WHERE course_times.course_id = courses.id AND course_times.day_of_week = 2 AND course_times.start_time >= '08:00' AND course_times.end_time <= '12:00')")
+	assert.NotContains(t, query, "courses.professor_id")
+}
+
+func TestFindCatalogOrder(t *testing.T) {
+	tests := []struct {
+		sort  string
+		order string
+		want  string
+	}{
+		{sort: "", want: "ORDER BY courses.code ASC, courses.code, courses.group_number"},
+		{sort: "name; DROP TABLE courses", order: "desc", want: "ORDER BY courses.code DESC, courses.code, courses.group_number"},
+		{sort: "weight", order: "DESC", want: "ORDER BY courses.weight DESC, courses.code, courses.group_number"},
+		{sort: "free_seats", want: "ORDER BY courses.capacity - GREATEST(courses.enrolled, (SELECT COUNT(*) FROM \"user_courses\" JOIN schedules ON schedules.id = user_courses.schedule_id WHERE schedules.is_active AND user_courses.course_id = courses.id)) ASC, courses.code, courses.group_number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			query := catalogQuery(t, &dto.CourseCatalogFilters{
+				UniversityID: uuid.New(),
+				SemesterID:   uuid.New(),
+				Sort:         tt.sort,
+				Order:        tt.order,
+			})
+			assert.Contains(t, query, tt.want)
+		})
+	}
+}
+
+func TestSearchQualifiesCourseColumns(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	facultyID, professorID := uuid.New(), uuid.New()
+
+	_, err := NewCourseRepository(db).Search(&dto.CourseSearchFilters{FacultyID: facultyID, ProfessorID: professorID})
+	require.NoError(t, err)
+	require.Len(t, recorder.statements, 1)
+
+	query := recorder.statements[0]
+	assert.Contains(t, query, `LEFT JOIN "professors" "Professor"`)
+	assert.Contains(t, query, "courses.faculty_id = '"+facultyID.String()+"'")
+	assert.Contains(t, query, "courses.professor_id = '"+professorID.String()+"'")
+	assert.NotRegexp(t, `[^.]faculty_id = '`, query)
+	assert.NotRegexp(t, `[^.]professor_id = '`, query)
+}
+
+func TestSearchFreeSeatsByBothCounts(t *testing.T) {
+	db, recorder := dryRunDB(t)
+
+	_, err := NewCourseRepository(db).Search(&dto.CourseSearchFilters{HasFreeSeats: true})
+	require.NoError(t, err)
+	require.Len(t, recorder.statements, 1)
+	assert.Contains(t, recorder.statements[0], "courses.capacity > courses.enrolled AND courses.capacity > (SELECT COUNT(*) FROM \"user_courses\" JOIN schedules ON schedules.id = user_courses.schedule_id WHERE schedules.is_active AND user_courses.course_id = courses.id)")
+}
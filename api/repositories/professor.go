@@ -1,10 +1,12 @@
 package repositories
 
 import (
+	"github.com/armanjr/termustat/api/dto"
 	"github.com/armanjr/termustat/api/errors"
 	"github.com/armanjr/termustat/api/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProfessorRepository interface {
@@ -12,6 +14,11 @@ type ProfessorRepository interface {
 	FindAllByUniversity(universityID uuid.UUID) (*[]models.Professor, error)
 	Create(professor *models.Professor) (*models.Professor, error)
 	Find(id uuid.UUID) (*models.Professor, error)
+	FindByAlias(universityID uuid.UUID, normalizedName string) (*models.Professor, error)
+	Merge(targetID uuid.UUID, sources []models.Professor) error
+	Search(universityID uuid.UUID, key string, pagination *dto.PaginationQuery) (*dto.PaginatedList[models.Professor], error)
+	Update(professor *models.Professor, alias *models.ProfessorAlias) (*models.Professor, error)
+	Delete(id uuid.UUID) error
 }
 
 type professorRepository struct {
@@ -24,7 +31,7 @@ func NewProfessorRepository(db *gorm.DB) ProfessorRepository {
 
 func (r *professorRepository) Find(id uuid.UUID) (*models.Professor, error) {
 	var professor models.Professor
-	if err := r.db.Preload("University").Preload("Courses").First(&professor, "id = ?", id).Error; err != nil {
+	if err := r.db.Preload("University").Preload("Courses").Preload("Aliases").First(&professor, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.NewNotFoundError("professor", id.String())
 		}
@@ -71,3 +78,164 @@ func (r *professorRepository) FindAllByUniversity(universityID uuid.UUID) (*[]mo
 	}
 	return &professors, nil
 }
+
+// FindByAlias finds the professor a merged spelling of a name now belongs to
+func (r *professorRepository) FindByAlias(universityID uuid.UUID, normalizedName string) (*models.Professor, error) {
+	var professor models.Professor
+	err := r.db.Joins("JOIN professor_aliases ON professor_aliases.professor_id = professors.id").
+		Where("professor_aliases.university_id = ? AND professor_aliases.normalized_name = ?", universityID, normalizedName).
+		First(&professor).Error
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, errors.NewNotFoundError("professor alias", normalizedName)
+		default:
+			return nil, errors.Wrap(err, "database error: failed to find professor by alias")
+		}
+	}
+	return &professor, nil
+}
+
+// Merge moves the courses and aliases of the source professors to the target,
+// keeps the sources' names as aliases of the target and deletes the sources
+func (r *professorRepository) Merge(targetID uuid.UUID, sources []models.Professor) error {
+	tx := r.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	sourceIDs := make([]uuid.UUID, len(sources))
+	aliases := make([]models.ProfessorAlias, len(sources))
+	for i, source := range sources {
+		sourceIDs[i] = source.ID
+		aliases[i] = models.ProfessorAlias{
+			ProfessorID:    targetID,
+			UniversityID:   source.UniversityID,
+			Name:           source.Name,
+			NormalizedName: source.NormalizedName,
+		}
+	}
+
+	err := tx.Model(&models.Course{}).
+		Where("professor_id IN ?", sourceIDs).
+		Update("professor_id", targetID).Error
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "failed to move courses")
+	}
+
+	err = tx.Model(&models.ProfessorAlias{}).
+		Where("professor_id IN ?", sourceIDs).
+		Update("professor_id", targetID).Error
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "failed to move aliases")
+	}
+
+	err = tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "university_id"}, {Name: "normalized_name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"professor_id"}),
+	}).Create(&aliases).Error
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "failed to record aliases")
+	}
+
+	if err := tx.Delete(&models.Professor{}, "id IN ?", sourceIDs).Error; err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "failed to delete merged professors")
+	}
+
+	return tx.Commit().Error
+}
+
+// Search lists a page of a university's professors by name, or by closeness
+// to a search key when one is given
+func (r *professorRepository) Search(universityID uuid.UUID, key string, pagination *dto.PaginationQuery) (*dto.PaginatedList[models.Professor], error) {
+	var professors []models.Professor
+	var total int64
+
+	query := r.db.Model(&models.Professor{}).Where("university_id = ?", universityID)
+	order := clause.OrderBy{Expression: clause.Expr{SQL: "name"}}
+	if key != "" {
+		query = query.Where("search_key LIKE ? OR search_key % ?", "%"+likeEscaper.Replace(key)+"%", key)
+		order = clause.OrderBy{Expression: clause.Expr{
+			SQL:  "similarity(search_key, ?) DESC, name",
+			Vars: []interface{}{key},
+		}}
+	}
+
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to count professors")
+	}
+
+	err := query.Clauses(order).
+		Limit(pagination.Limit).Offset(pagination.Offset).
+		Find(&professors).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "database error: failed to search professors")
+	}
+
+	return &dto.PaginatedList[models.Professor]{
+		Items: professors,
+		Total: total,
+		Page:  pagination.Page,
+		Limit: pagination.Limit,
+	}, nil
+}
+
+// Update saves a professor's name and details. A non-nil alias, the
+// professor's previous name, is recorded in the same transaction.
+func (r *professorRepository) Update(professor *models.Professor, alias *models.ProfessorAlias) (*models.Professor, error) {
+	tx := r.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if alias != nil {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "university_id"}, {Name: "normalized_name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"professor_id"}),
+		}).Create(alias).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.Wrap(err, "failed to record alias")
+		}
+
+		// a professor renamed back to an old name no longer has it as an alias
+		err = tx.Where("university_id = ? AND normalized_name = ?", professor.UniversityID, professor.NormalizedName).
+			Delete(&models.ProfessorAlias{}).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.Wrap(err, "failed to drop alias of the new name")
+		}
+	}
+
+	if err := tx.Omit(clause.Associations).Save(professor).Error; err != nil {
+		tx.Rollback()
+		return nil, errors.Wrap(err, "failed to update professor")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return r.Find(professor.ID)
+}
+
+func (r *professorRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.Professor{}, "id = ?", id)
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to delete professor")
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("professor", id.String())
+	}
+
+	return nil
+}
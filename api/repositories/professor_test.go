@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/armanjr/termustat/api/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRenameBackDropsAlias(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	professor := &models.Professor{ID: uuid.New(), UniversityID: uuid.New(), Name: "علی رضایی", NormalizedName: "علی رضایی"}
+	alias := &models.ProfessorAlias{ProfessorID: professor.ID, UniversityID: professor.UniversityID, Name: "علی رضایی‌نژاد", NormalizedName: "علی رضایی‌نژاد"}
+
+	_, err := NewProfessorRepository(db).Update(professor, alias)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(recorder.statements), 3)
+
+	assert.Contains(t, recorder.statements[0], `ON CONFLICT ("university_id","normalized_name") DO UPDATE SET "professor_id"="excluded"."professor_id"`)
+	assert.True(t, strings.HasPrefix(recorder.statements[1], `DELETE FROM "professor_aliases"`), recorder.statements[1])
+	assert.Contains(t, recorder.statements[1], "normalized_name = 'علی رضایی'")
+}
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ScheduleRepository interface {
+	Find(id uuid.UUID) (*models.Schedule, error)
+	FindByUserAndSemester(userID, semesterID uuid.UUID) ([]models.Schedule, error)
+	FindActive(userID, semesterID uuid.UUID) (*models.Schedule, error)
+	Create(schedule *models.Schedule) error
+	Update(schedule *models.Schedule) error
+	Delete(id uuid.UUID) error
+	Clone(sourceID uuid.UUID, clone *models.Schedule) error
+}
+
+type scheduleRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepository(db *gorm.DB) ScheduleRepository {
+	return &scheduleRepository{db: db}
+}
+
+func (r *scheduleRepository) Find(id uuid.UUID) (*models.Schedule, error) {
+	var schedule models.Schedule
+	if err := r.db.First(&schedule, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NewNotFoundError("schedule", id.String())
+		}
+		return nil, errors.Wrap(err, "database error")
+	}
+	return &schedule, nil
+}
+
+func (r *scheduleRepository) FindByUserAndSemester(userID, semesterID uuid.UUID) ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	err := r.db.Where("user_id = ? AND semester_id = ?", userID, semesterID).
+		Order("position, created_at").
+		Find(&schedules).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find schedules")
+	}
+	return schedules, nil
+}
+
+func (r *scheduleRepository) FindActive(userID, semesterID uuid.UUID) (*models.Schedule, error) {
+	var schedule models.Schedule
+	err := r.db.Where("user_id = ? AND semester_id = ? AND is_active", userID, semesterID).
+		First(&schedule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NewNotFoundError("active schedule", semesterID.String())
+		}
+		return nil, errors.Wrap(err, "failed to find active schedule")
+	}
+	return &schedule, nil
+}
+
+// Create adds a schedule, deactivating the user's other schedules of the
+// semester if it is the active one
+func (r *scheduleRepository) Create(schedule *models.Schedule) error {
+	tx := r.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := deactivateOthers(tx, schedule); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Omit(clause.Associations).Create(schedule).Error; err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "failed to create schedule")
+	}
+
+	return tx.Commit().Error
+}
+
+// Update saves a schedule, deactivating the user's other schedules of the
+// semester if it is the active one
+func (r *scheduleRepository) Update(schedule *models.Schedule) error {
+	tx := r.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := deactivateOthers(tx, schedule); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Omit(clause.Associations).Save(schedule).Error; err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "failed to update schedule")
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *scheduleRepository) Delete(id uuid.UUID) error {
+	result := r.db.Delete(&models.Schedule{}, "id = ?", id)
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to delete schedule")
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("schedule", id.String())
+	}
+
+	return nil
+}
+
+// Clone creates a schedule holding the same courses as the source schedule
+func (r *scheduleRepository) Clone(sourceID uuid.UUID, clone *models.Schedule) error {
+	tx := r.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err := deactivateOthers(tx, clone); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Omit(clause.Associations).Create(clone).Error; err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "failed to create schedule")
+	}
+
+	err := tx.Exec(`INSERT INTO user_courses (user_id, course_id, semester_id, schedule_id)
+		SELECT user_id, course_id, semester_id, ? FROM user_courses WHERE schedule_id = ?`,
+		clone.ID, sourceID).Error
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "failed to copy schedule courses")
+	}
+
+	return tx.Commit().Error
+}
+
+func deactivateOthers(tx *gorm.DB, schedule *models.Schedule) error {
+	if !schedule.IsActive {
+		return nil
+	}
+
+	err := tx.Model(&models.Schedule{}).
+		Where("user_id = ? AND semester_id = ? AND id <> ? AND is_active", schedule.UserID, schedule.SemesterID, schedule.ID).
+		Update("is_active", false).Error
+	if err != nil {
+		return errors.Wrap(err, "failed to deactivate schedules")
+	}
+	return nil
+}
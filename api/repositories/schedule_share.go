@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ScheduleShareRepository interface {
+	Create(share *models.ScheduleShare) error
+	FindByToken(token string) (*models.ScheduleShare, error)
+	FindBySchedule(scheduleID uuid.UUID) ([]models.ScheduleShare, error)
+	Revoke(scheduleID, id uuid.UUID) error
+}
+
+type scheduleShareRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduleShareRepository(db *gorm.DB) ScheduleShareRepository {
+	return &scheduleShareRepository{db: db}
+}
+
+func (r *scheduleShareRepository) Create(share *models.ScheduleShare) error {
+	if err := r.db.Omit(clause.Associations).Create(share).Error; err != nil {
+		return errors.Wrap(err, "failed to create schedule share")
+	}
+	return nil
+}
+
+// FindByToken returns an unrevoked share with its schedule; expiry is left to the caller
+func (r *scheduleShareRepository) FindByToken(token string) (*models.ScheduleShare, error) {
+	var share models.ScheduleShare
+	err := r.db.Preload("Schedule").
+		Where("token = ? AND revoked = FALSE", token).
+		First(&share).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NewNotFoundError("shared schedule", "")
+		}
+		return nil, errors.Wrap(err, "failed to find schedule share")
+	}
+	return &share, nil
+}
+
+// FindBySchedule returns the unrevoked shares of a schedule, newest first
+func (r *scheduleShareRepository) FindBySchedule(scheduleID uuid.UUID) ([]models.ScheduleShare, error) {
+	var shares []models.ScheduleShare
+	err := r.db.Where("schedule_id = ? AND revoked = FALSE", scheduleID).
+		Order("created_at DESC").
+		Find(&shares).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find schedule shares")
+	}
+	return shares, nil
+}
+
+func (r *scheduleShareRepository) Revoke(scheduleID, id uuid.UUID) error {
+	result := r.db.Model(&models.ScheduleShare{}).
+		Where("id = ? AND schedule_id = ? AND revoked = FALSE", id, scheduleID).
+		Update("revoked", true)
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to revoke schedule share")
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("schedule share", id.String())
+	}
+
+	return nil
+}
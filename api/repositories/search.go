@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"fmt"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// TextQuery is a search query prepared for the search indexes: Terms are
+// matched as prefixes against search_vector, Keys against the search_key
+// trigram index and Code as a prefix of course codes
+type TextQuery struct {
+	Terms []string
+	Keys  []string
+	Code  string
+}
+
+type CourseMatch struct {
+	Course models.Course
+	Score  float64
+}
+
+type ProfessorMatch struct {
+	Professor models.Professor
+	Score     float64
+}
+
+type SearchRepository interface {
+	SearchCourses(universityID, semesterID uuid.UUID, query *TextQuery, limit int) ([]CourseMatch, error)
+	SearchProfessors(universityID uuid.UUID, query *TextQuery, limit int) ([]ProfessorMatch, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+type searchHit struct {
+	ID    uuid.UUID
+	Score float64
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchCourses ranks courses by their name and code, and with half the
+// weight by their professor's name. A semester of uuid.Nil searches all.
+func (r *searchRepository) SearchCourses(universityID, semesterID uuid.UUID, query *TextQuery, limit int) ([]CourseMatch, error) {
+	courseScore, courseScoreArgs := relevance("courses", query)
+	professorScore, professorScoreArgs := relevance("professors", query)
+	courseMatch, courseMatchArgs := matches("courses", query)
+	professorMatch, professorMatchArgs := matches("professors", query)
+
+	score := fmt.Sprintf("%s + 0.5 * (%s)", courseScore, professorScore)
+	scoreArgs := append(courseScoreArgs, professorScoreArgs...)
+	match := fmt.Sprintf("(%s) OR (%s)", courseMatch, professorMatch)
+	matchArgs := append(courseMatchArgs, professorMatchArgs...)
+	if query.Code != "" {
+		code := strings.ToLower(likeEscaper.Replace(query.Code)) + "%"
+		score += " + CASE WHEN lower(courses.code) LIKE ? THEN 1 ELSE 0 END"
+		scoreArgs = append(scoreArgs, code)
+		match += " OR lower(courses.code) LIKE ?"
+		matchArgs = append(matchArgs, code)
+	}
+
+	db := r.db.Table("courses").
+		Select("courses.id, "+score+" AS score", scoreArgs...).
+		Joins("JOIN professors ON professors.id = courses.professor_id").
+		Where("courses.university_id = ?", universityID)
+	if semesterID != uuid.Nil {
+		db = db.Where("courses.semester_id = ?", semesterID)
+	}
+
+	var hits []searchHit
+	err := db.Where(match, matchArgs...).
+		Order("score DESC, courses.code").
+		Limit(limit).
+		Scan(&hits).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to search courses")
+	}
+	if len(hits) == 0 {
+		return nil, nil
+	}
+
+	var courses []models.Course
+	err = r.db.Preload("CourseTimes").Preload("Professor").
+		Where("id IN ?", hitIDs(hits)).
+		Find(&courses).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch found courses")
+	}
+
+	byID := make(map[uuid.UUID]models.Course, len(courses))
+	for _, course := range courses {
+		byID[course.ID] = course
+	}
+	found := make([]CourseMatch, 0, len(hits))
+	for _, hit := range hits {
+		if course, ok := byID[hit.ID]; ok {
+			found = append(found, CourseMatch{Course: course, Score: hit.Score})
+		}
+	}
+	return found, nil
+}
+
+// SearchProfessors ranks a university's professors by name
+func (r *searchRepository) SearchProfessors(universityID uuid.UUID, query *TextQuery, limit int) ([]ProfessorMatch, error) {
+	score, scoreArgs := relevance("professors", query)
+	match, matchArgs := matches("professors", query)
+
+	var hits []searchHit
+	err := r.db.Table("professors").
+		Select("professors.id, "+score+" AS score", scoreArgs...).
+		Where("professors.university_id = ?", universityID).
+		Where(match, matchArgs...).
+		Order("score DESC, professors.name").
+		Limit(limit).
+		Scan(&hits).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to search professors")
+	}
+	if len(hits) == 0 {
+		return nil, nil
+	}
+
+	var professors []models.Professor
+	if err := r.db.Where("id IN ?", hitIDs(hits)).Find(&professors).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to fetch found professors")
+	}
+
+	byID := make(map[uuid.UUID]models.Professor, len(professors))
+	for _, professor := range professors {
+		byID[professor.ID] = professor
+	}
+	found := make([]ProfessorMatch, 0, len(hits))
+	for _, hit := range hits {
+		if professor, ok := byID[hit.ID]; ok {
+			found = append(found, ProfessorMatch{Professor: professor, Score: hit.Score})
+		}
+	}
+	return found, nil
+}
+
+// relevance scores a row of table as its full-text rank plus the best
+// trigram similarity of its search key
+func relevance(table string, query *TextQuery) (string, []interface{}) {
+	score := "0"
+	var args []interface{}
+	if tsquery := prefixTSQuery(query.Terms); tsquery != "" {
+		score = fmt.Sprintf("ts_rank(%s.search_vector, to_tsquery('simple', ?))", table)
+		args = append(args, tsquery)
+	}
+
+	if len(query.Keys) > 0 {
+		similarities := make([]string, len(query.Keys))
+		for i, key := range query.Keys {
+			similarities[i] = fmt.Sprintf("similarity(%s.search_key, ?)", table)
+			args = append(args, key)
+		}
+		score += " + GREATEST(" + strings.Join(similarities, ", ") + ")"
+	}
+
+	return score, args
+}
+
+// matches keeps rows of table whose words start with every term, or whose
+// search key contains or resembles one of the keys
+func matches(table string, query *TextQuery) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if tsquery := prefixTSQuery(query.Terms); tsquery != "" {
+		conditions = append(conditions, fmt.Sprintf("%s.search_vector @@ to_tsquery('simple', ?)", table))
+		args = append(args, tsquery)
+	}
+
+	for _, key := range query.Keys {
+		conditions = append(conditions,
+			fmt.Sprintf("%s.search_key LIKE ?", table),
+			fmt.Sprintf("%s.search_key %% ?", table))
+		args = append(args, "%"+likeEscaper.Replace(key)+"%", key)
+	}
+
+	if len(conditions) == 0 {
+		return "FALSE", nil
+	}
+	return strings.Join(conditions, " OR "), args
+}
+
+// prefixTSQuery builds a tsquery matching words that start with every term.
+// Terms hold only letters and digits, so they need no quoting.
+func prefixTSQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+func hitIDs(hits []searchHit) []uuid.UUID {
+	ids := make([]uuid.UUID, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	return ids
+}
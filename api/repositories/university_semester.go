@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UniversitySemesterRepository interface {
+	Find(universityID, semesterID uuid.UUID) (*models.UniversitySemester, error)
+	FindByUniversity(universityID uuid.UUID) ([]models.UniversitySemester, error)
+	FindCurrent(universityID uuid.UUID) (*models.UniversitySemester, error)
+	Save(calendar *models.UniversitySemester) error
+	Delete(universityID, semesterID uuid.UUID) error
+}
+
+type universitySemesterRepository struct {
+	db *gorm.DB
+}
+
+func NewUniversitySemesterRepository(db *gorm.DB) UniversitySemesterRepository {
+	return &universitySemesterRepository{db: db}
+}
+
+func (r *universitySemesterRepository) Find(universityID, semesterID uuid.UUID) (*models.UniversitySemester, error) {
+	var calendar models.UniversitySemester
+	err := r.db.Preload("Semester").
+		Where("university_id = ? AND semester_id = ?", universityID, semesterID).
+		First(&calendar).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NewNotFoundError("university semester", semesterID.String())
+		}
+		return nil, errors.Wrap(err, "database error")
+	}
+	return &calendar, nil
+}
+
+// FindByUniversity returns a university's semester calendars, newest semester first
+func (r *universitySemesterRepository) FindByUniversity(universityID uuid.UUID) ([]models.UniversitySemester, error) {
+	var calendars []models.UniversitySemester
+	err := r.db.Preload("Semester").
+		Joins("JOIN semesters ON semesters.id = university_semesters.semester_id").
+		Where("university_semesters.university_id = ?", universityID).
+		Order("semesters.year DESC, semesters.term DESC").
+		Find(&calendars).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch university semesters")
+	}
+	return calendars, nil
+}
+
+func (r *universitySemesterRepository) FindCurrent(universityID uuid.UUID) (*models.UniversitySemester, error) {
+	var calendar models.UniversitySemester
+	err := r.db.Preload("Semester").
+		Where("university_id = ? AND is_current", universityID).
+		First(&calendar).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.NewNotFoundError("current semester of university", universityID.String())
+		}
+		return nil, errors.Wrap(err, "database error")
+	}
+	return &calendar, nil
+}
+
+// Save creates or replaces a university's calendar for a semester. Making it
+// current clears the flag on the university's other semesters.
+func (r *universitySemesterRepository) Save(calendar *models.UniversitySemester) error {
+	tx := r.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if calendar.IsCurrent {
+		err := tx.Model(&models.UniversitySemester{}).
+			Where("university_id = ? AND semester_id <> ? AND is_current", calendar.UniversityID, calendar.SemesterID).
+			Update("is_current", false).Error
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(err, "failed to clear current semester")
+		}
+	}
+
+	err := tx.Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "university_id"}, {Name: "semester_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"registration_start", "registration_end", "add_drop_start", "add_drop_end",
+				"exam_start", "exam_end", "is_current", "updated_at",
+			}),
+		}).
+		Create(calendar).Error
+	if err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "failed to save university semester")
+	}
+
+	return tx.Commit().Error
+}
+
+func (r *universitySemesterRepository) Delete(universityID, semesterID uuid.UUID) error {
+	result := r.db.Delete(&models.UniversitySemester{}, "university_id = ? AND semester_id = ?", universityID, semesterID)
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "failed to delete university semester")
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("university semester", semesterID.String())
+	}
+
+	return nil
+}
@@ -6,14 +6,18 @@ import (
 	"github.com/armanjr/termustat/api/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserCourseRepository interface {
 	Create(userCourse *models.UserCourse) error
-	Delete(userID, courseID uuid.UUID) error
-	FindByUserAndSemester(userID, semesterID uuid.UUID) ([]models.UserCourse, error)
+	Delete(scheduleID, courseID uuid.UUID) error
+	FindBySchedule(scheduleID uuid.UUID) ([]models.UserCourse, error)
 	FindByCourseAndSemester(courseID, semesterID uuid.UUID) ([]models.UserCourse, error)
-	ExistsByCourseAndSemester(userID, courseID, semesterID uuid.UUID) (bool, error)
+	CountTakenSeats(courseIDs []uuid.UUID) (map[uuid.UUID]int, error)
+	ExistsInSchedule(scheduleID, courseID uuid.UUID) (bool, error)
+	FindByCatalogCourse(scheduleID, catalogCourseID uuid.UUID) (*models.UserCourse, error)
+	Swap(scheduleID, fromCourseID uuid.UUID, to *models.UserCourse) error
 	GetCoursesForUser(userID uuid.UUID, pagination *dto.PaginationQuery) (*dto.PaginatedList[models.UserCourse], error)
 }
 
@@ -26,14 +30,14 @@ func NewUserCourseRepository(db *gorm.DB) UserCourseRepository {
 }
 
 func (r *userCourseRepository) Create(userCourse *models.UserCourse) error {
-	if err := r.db.Create(userCourse).Error; err != nil {
+	if err := r.db.Omit(clause.Associations).Create(userCourse).Error; err != nil {
 		return errors.Wrap(err, "failed to create user course")
 	}
 	return nil
 }
 
-func (r *userCourseRepository) Delete(userID, courseID uuid.UUID) error {
-	result := r.db.Where("user_id = ? AND course_id = ?", userID, courseID).
+func (r *userCourseRepository) Delete(scheduleID, courseID uuid.UUID) error {
+	result := r.db.Where("schedule_id = ? AND course_id = ?", scheduleID, courseID).
 		Delete(&models.UserCourse{})
 
 	if result.Error != nil {
@@ -47,12 +51,13 @@ func (r *userCourseRepository) Delete(userID, courseID uuid.UUID) error {
 	return nil
 }
 
-func (r *userCourseRepository) FindByUserAndSemester(userID, semesterID uuid.UUID) ([]models.UserCourse, error) {
+func (r *userCourseRepository) FindBySchedule(scheduleID uuid.UUID) ([]models.UserCourse, error) {
 	var userCourses []models.UserCourse
 
 	err := r.db.Preload("Course").
 		Preload("Course.CourseTimes").
-		Where("user_id = ? AND semester_id = ?", userID, semesterID).
+		Where("schedule_id = ?", scheduleID).
+		Order("created_at").
 		Find(&userCourses).Error
 
 	if err != nil {
@@ -62,11 +67,13 @@ func (r *userCourseRepository) FindByUserAndSemester(userID, semesterID uuid.UUI
 	return userCourses, nil
 }
 
+// FindByCourseAndSemester returns the selections of a course in active
+// schedules; draft plans don't take a seat
 func (r *userCourseRepository) FindByCourseAndSemester(courseID, semesterID uuid.UUID) ([]models.UserCourse, error) {
 	var userCourses []models.UserCourse
 
-	err := r.db.Preload("User").
-		Where("course_id = ? AND semester_id = ?", courseID, semesterID).
+	err := takenSeats(r.db).
+		Where("user_courses.course_id = ? AND user_courses.semester_id = ?", courseID, semesterID).
 		Find(&userCourses).Error
 
 	if err != nil {
@@ -76,11 +83,37 @@ func (r *userCourseRepository) FindByCourseAndSemester(courseID, semesterID uuid
 	return userCourses, nil
 }
 
-func (r *userCourseRepository) ExistsByCourseAndSemester(userID, courseID, semesterID uuid.UUID) (bool, error) {
+// CountTakenSeats counts the seats taken of each course, as
+// FindByCourseAndSemester does for one. Courses without a taken seat are
+// missing from the result.
+func (r *userCourseRepository) CountTakenSeats(courseIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	var counts []struct {
+		CourseID uuid.UUID
+		Taken    int
+	}
+
+	err := takenSeats(r.db).
+		Select("user_courses.course_id, COUNT(*) AS taken").
+		Where("user_courses.course_id IN ?", courseIDs).
+		Group("user_courses.course_id").
+		Find(&counts).Error
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to count taken seats")
+	}
+
+	taken := make(map[uuid.UUID]int, len(counts))
+	for _, count := range counts {
+		taken[count.CourseID] = count.Taken
+	}
+	return taken, nil
+}
+
+func (r *userCourseRepository) ExistsInSchedule(scheduleID, courseID uuid.UUID) (bool, error) {
 	var count int64
 
 	err := r.db.Model(&models.UserCourse{}).
-		Where("user_id = ? AND course_id = ? AND semester_id = ?", userID, courseID, semesterID).
+		Where("schedule_id = ? AND course_id = ?", scheduleID, courseID).
 		Count(&count).Error
 
 	if err != nil {
@@ -90,6 +123,54 @@ func (r *userCourseRepository) ExistsByCourseAndSemester(userID, courseID, semes
 	return count > 0, nil
 }
 
+// FindByCatalogCourse returns the section of a catalog course selected in a schedule
+func (r *userCourseRepository) FindByCatalogCourse(scheduleID, catalogCourseID uuid.UUID) (*models.UserCourse, error) {
+	var userCourse models.UserCourse
+
+	err := r.db.Joins("JOIN courses ON courses.id = user_courses.course_id").
+		Where("user_courses.schedule_id = ? AND courses.catalog_course_id = ?", scheduleID, catalogCourseID).
+		First(&userCourse).Error
+
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, errors.NewNotFoundError("user course", catalogCourseID.String())
+		default:
+			return nil, errors.Wrap(err, "failed to find selected section")
+		}
+	}
+
+	return &userCourse, nil
+}
+
+// Swap replaces a selected section with another one in a single transaction
+func (r *userCourseRepository) Swap(scheduleID, fromCourseID uuid.UUID, to *models.UserCourse) error {
+	tx := r.db.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	result := tx.Where("schedule_id = ? AND course_id = ?", scheduleID, fromCourseID).
+		Delete(&models.UserCourse{})
+	if result.Error != nil {
+		tx.Rollback()
+		return errors.Wrap(result.Error, "failed to remove previous section")
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return errors.NewNotFoundError("user course", fromCourseID.String())
+	}
+
+	if err := tx.Omit(clause.Associations).Create(to).Error; err != nil {
+		tx.Rollback()
+		return errors.Wrap(err, "failed to select new section")
+	}
+
+	return tx.Commit().Error
+}
+
 func (r *userCourseRepository) GetCoursesForUser(userID uuid.UUID, pagination *dto.PaginationQuery) (*dto.PaginatedList[models.UserCourse], error) {
 	var userCourses []models.UserCourse
 	var total int64
@@ -119,3 +200,11 @@ func (r *userCourseRepository) GetCoursesForUser(userID uuid.UUID, pagination *d
 		Limit: pagination.Limit,
 	}, nil
 }
+
+// takenSeats selects the selections that take a seat of their course: those
+// of active schedules. The course catalog counts free seats the same way.
+func takenSeats(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.UserCourse{}).
+		Joins("JOIN schedules ON schedules.id = user_courses.schedule_id").
+		Where("schedules.is_active")
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountTakenSeatsInOneQuery(t *testing.T) {
+	db, recorder := dryRunDB(t)
+	first, second := uuid.New(), uuid.New()
+
+	_, err := NewUserCourseRepository(db).CountTakenSeats([]uuid.UUID{first, second})
+	require.NoError(t, err)
+	require.Len(t, recorder.statements, 1)
+
+	query := recorder.statements[0]
+	assert.Contains(t, query, `SELECT user_courses.course_id, COUNT(*) AS taken FROM "user_courses" JOIN schedules ON schedules.id = user_courses.schedule_id WHERE schedules.is_active`)
+	assert.Contains(t, query, "user_courses.course_id IN ('"+first.String()+"','"+second.String()+"')")
+	assert.Contains(t, query, `GROUP BY "user_courses"."course_id"`)
+}
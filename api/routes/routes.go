@@ -12,15 +12,18 @@ import (
 )
 
 type Handlers struct {
-	Auth       *handlers.AuthHandler
-	University *handlers.UniversityHandler
-	Professor  *handlers.ProfessorHandler
-	Semester   *handlers.SemesterHandler
-	Faculty    *handlers.FacultyHandler
-	Course     *handlers.CourseHandler
-	AdminUser  *handlers.AdminUserHandler
-	UserCourse *handlers.UserCourseHandler
-	Health     *handlers.HealthHandler
+	Auth               *handlers.AuthHandler
+	University         *handlers.UniversityHandler
+	Professor          *handlers.ProfessorHandler
+	Semester           *handlers.SemesterHandler
+	Faculty            *handlers.FacultyHandler
+	Course             *handlers.CourseHandler
+	AdminUser          *handlers.AdminUserHandler
+	UserCourse         *handlers.UserCourseHandler
+	Schedule           *handlers.ScheduleHandler
+	Health             *handlers.HealthHandler
+	UniversitySemester *handlers.UniversitySemesterHandler
+	Search             *handlers.SearchHandler
 }
 
 type Middlewares struct {
@@ -58,6 +61,14 @@ func SetupRoutes(app *app.App, h *Handlers, authService services.AuthService, ad
 			auth.POST("/refresh", h.Auth.Refresh)
 			auth.POST("/logout", h.Auth.Logout)
 		}
+
+		// Course catalog routes
+		public.GET("/catalog/courses", h.Course.Catalog)
+		public.GET("/search", h.Search.Search)
+
+		// Shared schedule routes
+		public.GET("/shared/schedules/:token", h.Schedule.GetShared)
+		public.GET("/shared/schedules/:token/calendar.ics", h.Schedule.SharedCalendar)
 	}
 
 	// Protected routes
@@ -68,15 +79,39 @@ func SetupRoutes(app *app.App, h *Handlers, authService services.AuthService, ad
 		user := protected.Group("/user")
 		{
 			user.GET("/me", h.Auth.GetCurrentUser)
+
+			schedules := user.Group("/schedules")
+			{
+				schedules.POST("", h.Schedule.Create)
+				schedules.GET("", h.Schedule.GetAll)
+				schedules.GET("/:id", h.Schedule.Get)
+				schedules.PUT("/:id", h.Schedule.Update)
+				schedules.DELETE("/:id", h.Schedule.Delete)
+				schedules.POST("/:id/clone", h.Schedule.Clone)
+				schedules.GET("/:id/diff", h.Schedule.Diff)
+				schedules.GET("/:id/calendar.ics", h.Schedule.Calendar)
+				schedules.GET("/:id/timetable", h.Schedule.Timetable)
+				schedules.POST("/:id/shares", h.Schedule.Share)
+				schedules.GET("/:id/shares", h.Schedule.GetShares)
+				schedules.DELETE("/:id/shares/:shareId", h.Schedule.RevokeShare)
+			}
 		}
 
+		// Semester routes
+		protected.GET("/semesters/current", h.UniversitySemester.GetCurrent)
+
 		// User Course routes
 		userCourses := protected.Group("/courses")
 		{
 			userCourses.POST("/select", h.UserCourse.AddCourse)
+			userCourses.POST("/select/swap", h.UserCourse.SwapSection)
+			userCourses.GET("/select/:courseId/alternatives", h.UserCourse.GetAlternativeSections)
 			userCourses.DELETE("/select/:courseId", h.UserCourse.RemoveCourse)
 			userCourses.GET("/selected", h.UserCourse.GetUserCourses)
 			userCourses.GET("/validate", h.UserCourse.ValidateTimeConflicts)
+			userCourses.GET("/exams", h.UserCourse.GetExamCalendar)
+			userCourses.POST("/generate", h.UserCourse.GenerateSchedules)
+			userCourses.GET("/:id/sections", h.Course.GetSections)
 		}
 	}
 
@@ -93,15 +128,24 @@ func SetupRoutes(app *app.App, h *Handlers, authService services.AuthService, ad
 			universities.PUT("/:id", h.University.Update)
 			universities.DELETE("/:id", h.University.Delete)
 			universities.GET("/:id/professors", h.Professor.GetAllByUniversity)
+			universities.GET("/:id/professors/duplicates", h.Professor.FindDuplicates)
 			universities.GET("/:id/faculties", h.Faculty.GetAllByUniversity)
 			universities.GET("/:id/faculties/:short_code", h.Faculty.GetByUniversityAndShortCode)
+			universities.GET("/:id/semesters", h.UniversitySemester.GetAll)
+			universities.PUT("/:id/semesters/:semesterId", h.UniversitySemester.Set)
+			universities.DELETE("/:id/semesters/:semesterId", h.UniversitySemester.Delete)
 		}
 
 		// Professor routes
 		professors := admin.Group("/professors")
 		{
+			professors.GET("", h.Professor.Search)
 			professors.POST("", h.Professor.Create)
 			professors.GET("/:id", h.Professor.Get)
+			professors.PUT("/:id", h.Professor.Update)
+			professors.DELETE("/:id", h.Professor.Delete)
+			professors.GET("/:id/history", h.Professor.History)
+			professors.POST("/:id/merge", h.Professor.Merge)
 		}
 
 		// Semester routes
@@ -109,6 +153,7 @@ func SetupRoutes(app *app.App, h *Handlers, authService services.AuthService, ad
 		{
 			semesters.POST("", h.Semester.Create)
 			semesters.GET("", h.Semester.GetAll)
+			semesters.GET("/code/:code", h.Semester.GetByCode)
 			semesters.GET("/:id", h.Semester.Get)
 			semesters.PUT("/:id", h.Semester.Update)
 			semesters.DELETE("/:id", h.Semester.Delete)
@@ -129,7 +174,12 @@ func SetupRoutes(app *app.App, h *Handlers, authService services.AuthService, ad
 		{
 			courses.POST("", h.Course.Create)
 			courses.GET("", h.Course.Search)
+			courses.POST("/import", h.Course.Import)
+			courses.POST("/import/preview", h.Course.PreviewImport)
+			courses.POST("/import/commit", h.Course.CommitImport)
+			courses.GET("/rooms", h.Course.GetRoomOccupancy)
 			courses.GET("/:id", h.Course.Get)
+			courses.GET("/:id/sections", h.Course.GetSections)
 			courses.PUT("/:id", h.Course.Update)
 			courses.DELETE("/:id", h.Course.Delete)
 		}
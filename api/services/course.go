@@ -7,9 +7,12 @@ import (
 	"github.com/armanjr/termustat/api/errors"
 	"github.com/armanjr/termustat/api/models"
 	"github.com/armanjr/termustat/api/repositories"
+	"github.com/armanjr/termustat/api/utils"
+	"github.com/armanjr/termustat/engine/jalali"
+	"github.com/armanjr/termustat/engine/schema"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 )
@@ -23,31 +26,45 @@ type CourseService interface {
 	Delete(id uuid.UUID) error
 	BatchCreate(dtos []dto.CreateCourseDTO) ([]*dto.CourseResponse, error)
 	Search(filters *dto.CourseSearchFilters) ([]dto.CourseResponse, error)
+	Catalog(filters *dto.CourseCatalogFilters, pagination *dto.PaginationQuery) (*dto.PaginatedList[dto.CourseResponse], error)
+	GetSections(id uuid.UUID) (*dto.CourseSectionsResponse, error)
+	GetSectionsByCode(universityID, semesterID uuid.UUID, code string) (*dto.CourseSectionsResponse, error)
+	GetRoomOccupancy(universityID, semesterID uuid.UUID) ([]dto.RoomOccupancyResponse, error)
+	ImportFromEngine(universityID, semesterID uuid.UUID, courses []schema.Course) ([]*dto.CourseResponse, error)
+	SyncFromEngine(universityID, semesterID uuid.UUID, courses []schema.Course, deleteMissing bool) (*dto.CourseImportDiffResponse, error)
+	PreviewImport(opts dto.CourseImportOptions, rows []dto.CourseImportRow, createdBy uuid.UUID) (*dto.CourseImportPreviewResponse, error)
+	CommitImport(planID uuid.UUID) (*dto.CourseImportDiffResponse, error)
 }
 
 type courseService struct {
 	courseRepo        repositories.CourseRepository
+	importPlanRepo    repositories.CourseImportPlanRepository
 	universityService UniversityService
 	facultyService    FacultyService
 	professorService  ProfessorService
 	semesterService   SemesterService
+	location          *time.Location
 	logger            *zap.Logger
 }
 
 func NewCourseService(
 	courseRepo repositories.CourseRepository,
+	importPlanRepo repositories.CourseImportPlanRepository,
 	universityService UniversityService,
 	facultyService FacultyService,
 	professorService ProfessorService,
 	semesterService SemesterService,
+	location *time.Location,
 	logger *zap.Logger,
 ) CourseService {
 	return &courseService{
 		courseRepo:        courseRepo,
+		importPlanRepo:    importPlanRepo,
 		universityService: universityService,
 		facultyService:    facultyService,
 		professorService:  professorService,
 		semesterService:   semesterService,
+		location:          location,
 		logger:            logger,
 	}
 }
@@ -80,13 +97,13 @@ func (s *courseService) Create(dto dto.CreateCourseDTO) (*dto.CourseResponse, er
 		return nil, errors.NewValidationError("invalid exam time: " + err.Error())
 	}
 
-	courseTimes, err := s.parseCourseTimes(dto.Times)
+	courseTimes, err := s.parseCourseTimes(dto.Times, dto.Locations)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid course time: " + err.Error())
 	}
 
 	// Check for existing course with same code
-	existing, err := s.courseRepo.FindByUniversityAndCode(dto.UniversityID, dto.Code)
+	existing, err := s.courseRepo.FindBySemesterAndCode(dto.UniversityID, dto.SemesterID, dto.Code)
 	if err != nil && !errors.Is(err, errors.ErrNotFound) {
 		s.logger.Error("Failed to check existing course",
 			zap.String("code", dto.Code),
@@ -109,6 +126,13 @@ func (s *courseService) Create(dto dto.CreateCourseDTO) (*dto.CourseResponse, er
 		Weight:            dto.Weight,
 		Capacity:          dto.Capacity,
 		GenderRestriction: dto.GenderRestriction,
+		GroupNumber:       strings.TrimSpace(dto.GroupNumber),
+		PracticalWeight:   dto.PracticalWeight,
+		Enrolled:          dto.Enrolled,
+		WaitingList:       dto.WaitingList,
+		Notes:             strings.TrimSpace(dto.Notes),
+		CrossCampus:       dto.CrossCampus,
+		EmergencyDrop:     dto.EmergencyDrop,
 		ExamStart:         examStart,
 		ExamEnd:           examEnd,
 		CourseTimes:       courseTimes,
@@ -123,7 +147,7 @@ func (s *courseService) Create(dto dto.CreateCourseDTO) (*dto.CourseResponse, er
 		return nil, fmt.Errorf("failed to create course")
 	}
 
-	return mapCourseToResponse(created), nil
+	return mapCourseToResponse(created, s.location), nil
 }
 
 func (s *courseService) Get(id uuid.UUID) (*dto.CourseResponse, error) {
@@ -141,7 +165,7 @@ func (s *courseService) Get(id uuid.UUID) (*dto.CourseResponse, error) {
 			return nil, fmt.Errorf("failed to get course")
 		}
 	}
-	return mapCourseToResponse(course), nil
+	return mapCourseToResponse(course, s.location), nil
 }
 
 func (s *courseService) GetAllBySemester(semesterID uuid.UUID) ([]*dto.CourseResponse, error) {
@@ -159,7 +183,7 @@ func (s *courseService) GetAllBySemester(semesterID uuid.UUID) ([]*dto.CourseRes
 		return nil, fmt.Errorf("failed to get courses")
 	}
 
-	return mapCoursesToResponse(courses), nil
+	return mapCoursesToResponse(courses, s.location), nil
 }
 
 func (s *courseService) GetAllByFaculty(facultyID uuid.UUID) ([]*dto.CourseResponse, error) {
@@ -177,7 +201,7 @@ func (s *courseService) GetAllByFaculty(facultyID uuid.UUID) ([]*dto.CourseRespo
 		return nil, fmt.Errorf("failed to get courses")
 	}
 
-	return mapCoursesToResponse(courses), nil
+	return mapCoursesToResponse(courses, s.location), nil
 }
 
 func (s *courseService) Update(id uuid.UUID, dto dto.UpdateCourseDTO) (*dto.CourseResponse, error) {
@@ -223,7 +247,7 @@ func (s *courseService) Update(id uuid.UUID, dto dto.UpdateCourseDTO) (*dto.Cour
 		return nil, errors.NewValidationError("invalid exam time: " + err.Error())
 	}
 
-	courseTimes, err := s.parseCourseTimes(dto.Times)
+	courseTimes, err := s.parseCourseTimes(dto.Times, dto.Locations)
 	if err != nil {
 		return nil, errors.NewValidationError("invalid course time: " + err.Error())
 	}
@@ -236,6 +260,13 @@ func (s *courseService) Update(id uuid.UUID, dto dto.UpdateCourseDTO) (*dto.Cour
 	existing.Weight = dto.Weight
 	existing.Capacity = dto.Capacity
 	existing.GenderRestriction = dto.GenderRestriction
+	existing.GroupNumber = strings.TrimSpace(dto.GroupNumber)
+	existing.PracticalWeight = dto.PracticalWeight
+	existing.Enrolled = dto.Enrolled
+	existing.WaitingList = dto.WaitingList
+	existing.Notes = strings.TrimSpace(dto.Notes)
+	existing.CrossCampus = dto.CrossCampus
+	existing.EmergencyDrop = dto.EmergencyDrop
 	existing.ExamStart = examStart
 	existing.ExamEnd = examEnd
 	existing.CourseTimes = courseTimes
@@ -250,7 +281,7 @@ func (s *courseService) Update(id uuid.UUID, dto dto.UpdateCourseDTO) (*dto.Cour
 		return nil, fmt.Errorf("failed to update course")
 	}
 
-	return mapCourseToResponse(updated), nil
+	return mapCourseToResponse(updated, s.location), nil
 }
 
 func (s *courseService) Delete(id uuid.UUID) error {
@@ -312,7 +343,7 @@ func (s *courseService) BatchCreate(dtos []dto.CreateCourseDTO) ([]*dto.CourseRe
 		courseCodes[dto.Code] = true
 
 		// Check if course code already exists in database
-		existing, err := s.courseRepo.FindByUniversityAndCode(universityID, dto.Code)
+		existing, err := s.courseRepo.FindBySemesterAndCode(universityID, semesterID, dto.Code)
 		if err != nil && !errors.Is(err, errors.ErrNotFound) {
 			s.logger.Error("Failed to check existing course",
 				zap.String("code", dto.Code),
@@ -349,7 +380,136 @@ func (s *courseService) BatchCreate(dtos []dto.CreateCourseDTO) ([]*dto.CourseRe
 		zap.String("service", "Course"),
 		zap.String("operation", "BatchCreate"))
 
-	return mapCoursesToResponse(created), nil
+	return mapCoursesToResponse(created, s.location), nil
+}
+
+// GetSections lists every section offered in the course's semester under the same catalog course
+func (s *courseService) GetSections(id uuid.UUID) (*dto.CourseSectionsResponse, error) {
+	course, err := s.courseRepo.Find(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			return nil, err
+		default:
+			s.logger.Error("Failed to fetch course",
+				zap.String("id", id.String()),
+				zap.String("service", "Course"),
+				zap.String("operation", "GetSections"),
+				zap.Error(err))
+			return nil, fmt.Errorf("failed to fetch course sections")
+		}
+	}
+
+	catalogCourse, err := s.courseRepo.FindCatalogCourse(course.CatalogCourseID)
+	if err != nil {
+		s.logger.Error("Failed to fetch catalog course",
+			zap.String("id", id.String()),
+			zap.String("catalog_course_id", course.CatalogCourseID.String()),
+			zap.String("service", "Course"),
+			zap.String("operation", "GetSections"),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch course sections")
+	}
+
+	return s.sectionsOf(catalogCourse, course.SemesterID, "GetSections")
+}
+
+// GetSectionsByCode lists every section of a catalog course, given by its code
+// without the group suffix, offered in a semester
+func (s *courseService) GetSectionsByCode(universityID, semesterID uuid.UUID, code string) (*dto.CourseSectionsResponse, error) {
+	base, _ := schema.SplitCourseCode(code)
+	catalogCourse, err := s.courseRepo.FindCatalogCourseByCode(universityID, base)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			return nil, err
+		default:
+			s.logger.Error("Failed to fetch catalog course",
+				zap.String("university_id", universityID.String()),
+				zap.String("code", code),
+				zap.String("service", "Course"),
+				zap.String("operation", "GetSectionsByCode"),
+				zap.Error(err))
+			return nil, fmt.Errorf("failed to fetch course sections")
+		}
+	}
+
+	return s.sectionsOf(catalogCourse, semesterID, "GetSectionsByCode")
+}
+
+func (s *courseService) sectionsOf(catalogCourse *models.CatalogCourse, semesterID uuid.UUID, operation string) (*dto.CourseSectionsResponse, error) {
+	sections, err := s.courseRepo.FindSections(catalogCourse.ID, semesterID)
+	if err != nil {
+		s.logger.Error("Failed to fetch course sections",
+			zap.String("catalog_course_id", catalogCourse.ID.String()),
+			zap.String("service", "Course"),
+			zap.String("operation", operation),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch course sections")
+	}
+
+	return &dto.CourseSectionsResponse{
+		CatalogCourseID: catalogCourse.ID,
+		SemesterID:      semesterID,
+		Code:            catalogCourse.Code,
+		Name:            catalogCourse.Name,
+		Weight:          catalogCourse.Weight,
+		Sections:        mapCoursesToResponse(sections, s.location),
+	}, nil
+}
+
+// GetRoomOccupancy groups a semester's course times by room
+func (s *courseService) GetRoomOccupancy(universityID, semesterID uuid.UUID) ([]dto.RoomOccupancyResponse, error) {
+	courses, err := s.courseRepo.FindAllByUniversityAndSemester(universityID, semesterID)
+	if err != nil {
+		s.logger.Error("Failed to fetch courses",
+			zap.String("university_id", universityID.String()),
+			zap.String("semester_id", semesterID.String()),
+			zap.String("service", "Course"),
+			zap.String("operation", "GetRoomOccupancy"),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch room occupancy")
+	}
+
+	rooms := make(map[string]*dto.RoomOccupancyResponse)
+	for _, course := range courses {
+		for _, ct := range course.CourseTimes {
+			if ct.Room == "" {
+				continue
+			}
+			room, ok := rooms[ct.Room]
+			if !ok {
+				room = &dto.RoomOccupancyResponse{Room: ct.Room, Location: ct.Location}
+				rooms[ct.Room] = room
+			}
+			room.Slots = append(room.Slots, dto.RoomSlotResponse{
+				CourseID:   course.ID,
+				Code:       course.Code,
+				Name:       course.Name,
+				DayOfWeek:  ct.DayOfWeek,
+				StartTime:  ct.StartTime,
+				EndTime:    ct.EndTime,
+				WeekParity: ct.WeekParity,
+				HalfTerm:   ct.HalfTerm,
+			})
+		}
+	}
+
+	response := make([]dto.RoomOccupancyResponse, 0, len(rooms))
+	for _, room := range rooms {
+		sort.Slice(room.Slots, func(i, j int) bool {
+			if room.Slots[i].DayOfWeek != room.Slots[j].DayOfWeek {
+				return room.Slots[i].DayOfWeek < room.Slots[j].DayOfWeek
+			}
+			return room.Slots[i].StartTime.Before(room.Slots[j].StartTime)
+		})
+		response = append(response, *room)
+	}
+	sort.Slice(response, func(i, j int) bool {
+		return response[i].Room < response[j].Room
+	})
+
+	return response, nil
 }
 
 func (s *courseService) Search(filters *dto.CourseSearchFilters) ([]dto.CourseResponse, error) {
@@ -404,13 +564,14 @@ func (s *courseService) mapCourseToDTO(course *models.Course) (*dto.CourseRespon
 		return nil, fmt.Errorf("failed to fetch professor details: %w", err)
 	}
 
-	return &dto.CourseResponse{
+	response := &dto.CourseResponse{
 		ID:                course.ID,
 		UniversityID:      course.UniversityID,
 		FacultyID:         course.FacultyID,
 		FacultyNameEn:     faculty.NameEn,
 		FacultyNameFa:     faculty.NameFa,
 		ProfessorID:       course.ProfessorID,
+		CatalogCourseID:   course.CatalogCourseID,
 		ProfessorName:     professor.Name,
 		SemesterID:        course.SemesterID,
 		Code:              course.Code,
@@ -418,12 +579,20 @@ func (s *courseService) mapCourseToDTO(course *models.Course) (*dto.CourseRespon
 		Weight:            course.Weight,
 		Capacity:          course.Capacity,
 		GenderRestriction: course.GenderRestriction,
-		ExamStart:         course.ExamStart,
-		ExamEnd:           course.ExamEnd,
+		GroupNumber:       course.GroupNumber,
+		PracticalWeight:   course.PracticalWeight,
+		Enrolled:          course.Enrolled,
+		WaitingList:       course.WaitingList,
+		Notes:             course.Notes,
+		CrossCampus:       course.CrossCampus,
+		EmergencyDrop:     course.EmergencyDrop,
 		CourseTimes:       nil,
 		CreatedAt:         course.CreatedAt,
 		UpdatedAt:         course.UpdatedAt,
-	}, nil
+	}
+	setExamFields(response, course.ExamStart, course.ExamEnd, s.location)
+
+	return response, nil
 }
 
 // Helper methods
@@ -452,101 +621,75 @@ func (s *courseService) validateSearchFilters(filters *dto.CourseSearchFilters)
 	return nil
 }
 
-func (s *courseService) parseExamDateTime(dateStr, timeStr string) (time.Time, time.Time, error) {
-	examDate, err := time.Parse("2006/01/02", dateStr)
-	if err != nil {
-		return time.Time{}, time.Time{}, errors.NewValidationError("invalid exam date format")
-	}
+// minExamYear and maxExamYear bound the Jalali years exam dates are accepted in
+const (
+	minExamYear = 1300
+	maxExamYear = 1500
+)
 
-	timeParts := strings.Split(timeStr, "-")
-	if len(timeParts) != 2 {
-		return time.Time{}, time.Time{}, errors.NewValidationError("invalid exam time format")
+func (s *courseService) parseExamDateTime(dateStr, timeStr string) (time.Time, time.Time, error) {
+	// Golestan lists no exam for projects, seminars and theses
+	if dateStr == "" && timeStr == "" {
+		return time.Time{}, time.Time{}, nil
 	}
 
-	startTime, err := time.Parse("15:04", timeParts[0])
-	if err != nil {
-		return time.Time{}, time.Time{}, errors.NewValidationError("invalid exam start time")
+	// jalali.Parse takes any year, so a Gregorian 2025/01/15 would land in 2646
+	examDate, err := jalali.Parse(dateStr)
+	if err != nil || examDate.Year < minExamYear || examDate.Year > maxExamYear {
+		return time.Time{}, time.Time{}, errors.NewValidationError(fmt.Sprintf("exam date %q (expected a Jalali YYYY/MM/DD date between %d and %d)", dateStr, minExamYear, maxExamYear))
 	}
 
-	endTime, err := time.Parse("15:04", timeParts[1])
+	start, end, err := schema.ParseTimeRange(timeStr)
 	if err != nil {
-		return time.Time{}, time.Time{}, errors.NewValidationError("invalid exam end time")
+		return time.Time{}, time.Time{}, errors.Wrap(errors.NewValidationError("exam time"), err.Error())
 	}
 
-	examStart := time.Date(
-		examDate.Year(),
-		examDate.Month(),
-		examDate.Day(),
-		startTime.Hour(),
-		startTime.Minute(),
-		0, 0, time.UTC,
-	)
+	// ParseTimeRange checked both times
+	startTime, _ := time.Parse("15:04", start)
+	endTime, _ := time.Parse("15:04", end)
 
-	examEnd := time.Date(
-		examDate.Year(),
-		examDate.Month(),
-		examDate.Day(),
-		endTime.Hour(),
-		endTime.Minute(),
-		0, 0, time.UTC,
-	)
-
-	if examEnd.Before(examStart) {
-		return time.Time{}, time.Time{}, errors.NewValidationError("exam end time must be after start time")
-	}
+	examStart := examDate.Time(startTime.Hour(), startTime.Minute(), s.location)
+	examEnd := examDate.Time(endTime.Hour(), endTime.Minute(), s.location)
 
 	return examStart, examEnd, nil
 }
 
-func (s *courseService) parseTimeSlot(timeStr string) (*models.CourseTime, error) {
+func (s *courseService) parseTimeSlot(timeStr, location string) (*models.CourseTime, error) {
 	if timeStr == "" {
 		return nil, nil
 	}
 
-	parts := strings.Split(timeStr, "/")
-	if len(parts) != 2 {
-		return nil, errors.NewValidationError("invalid time format")
-	}
-
-	dayPart := strings.TrimPrefix(parts[0], "d")
-	day, err := strconv.Atoi(dayPart)
-	if err != nil || day < 0 || day > 6 {
-		return nil, errors.NewValidationError("invalid day value")
-	}
-
-	timeRange := strings.Split(parts[1], "-")
-	if len(timeRange) != 2 {
-		return nil, errors.NewValidationError("invalid time range")
-	}
-
-	startTime, err := time.Parse("15:04", timeRange[0])
-	if err != nil {
-		return nil, errors.NewValidationError("invalid start time")
-	}
-
-	endTime, err := time.Parse("15:04", timeRange[1])
+	session, err := schema.ParseSession(timeStr, utils.NormalizeText(location))
 	if err != nil {
-		return nil, errors.NewValidationError("invalid end time")
+		return nil, errors.Wrap(errors.NewValidationError("time slot"), err.Error())
 	}
 
-	if endTime.Before(startTime) {
-		return nil, errors.NewValidationError("end time must be after start time")
-	}
+	// ParseSession checked both times
+	startTime, _ := time.Parse("15:04", session.Start)
+	endTime, _ := time.Parse("15:04", session.End)
 
 	return &models.CourseTime{
-		DayOfWeek: day,
-		StartTime: startTime,
-		EndTime:   endTime,
+		DayOfWeek:  session.Day,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Room:       session.Room,
+		Location:   session.Location,
+		WeekParity: string(session.WeekParity),
+		HalfTerm:   session.HalfTerm,
 	}, nil
 }
 
-func (s *courseService) parseCourseTimes(times []string) ([]models.CourseTime, error) {
+func (s *courseService) parseCourseTimes(times, locations []string) ([]models.CourseTime, error) {
 	var courseTimes []models.CourseTime
-	for _, ts := range times {
+	for i, ts := range times {
 		if ts == "" {
 			continue
 		}
-		ct, err := s.parseTimeSlot(ts)
+		location := ""
+		if i < len(locations) {
+			location = locations[i]
+		}
+		ct, err := s.parseTimeSlot(ts, location)
 		if err != nil {
 			return nil, errors.Wrap(err, fmt.Sprintf("invalid time slot: %s", ts))
 		}
@@ -570,12 +713,36 @@ func (s *courseService) prepareCourse(dto dto.CreateCourseDTO) (*models.Course,
 		return nil, err
 	}
 
+	course, err := s.buildCourse(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for existing course with same code
+	existing, err := s.courseRepo.FindBySemesterAndCode(dto.UniversityID, dto.SemesterID, dto.Code)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		s.logger.Error("Failed to check existing course",
+			zap.String("code", dto.Code),
+			zap.String("service", "Course"),
+			zap.String("operation", "prepareCourse"),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to prepare course")
+	}
+	if existing != nil {
+		return nil, errors.NewConflictError("course with this code already exists")
+	}
+
+	return course, nil
+}
+
+// buildCourse resolves the professor and parses exam and class times into a course model
+func (s *courseService) buildCourse(dto dto.CreateCourseDTO) (*models.Course, error) {
 	professor, err := s.professorService.GetOrCreateByName(dto.UniversityID, dto.ProfessorName)
 	if err != nil {
 		s.logger.Error("Failed to get/create professor",
 			zap.String("professor_name", dto.ProfessorName),
 			zap.String("service", "Course"),
-			zap.String("operation", "prepareCourse"),
+			zap.String("operation", "buildCourse"),
 			zap.Error(err))
 		return nil, fmt.Errorf("failed to process professor")
 	}
@@ -585,25 +752,11 @@ func (s *courseService) prepareCourse(dto dto.CreateCourseDTO) (*models.Course,
 		return nil, err
 	}
 
-	courseTimes, err := s.parseCourseTimes(dto.Times)
+	courseTimes, err := s.parseCourseTimes(dto.Times, dto.Locations)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check for existing course with same code
-	existing, err := s.courseRepo.FindByUniversityAndCode(dto.UniversityID, dto.Code)
-	if err != nil && !errors.Is(err, errors.ErrNotFound) {
-		s.logger.Error("Failed to check existing course",
-			zap.String("code", dto.Code),
-			zap.String("service", "Course"),
-			zap.String("operation", "prepareCourse"),
-			zap.Error(err))
-		return nil, fmt.Errorf("failed to prepare course")
-	}
-	if existing != nil {
-		return nil, errors.NewConflictError("course with this code already exists")
-	}
-
 	return &models.Course{
 		UniversityID:      dto.UniversityID,
 		FacultyID:         dto.FacultyID,
@@ -614,45 +767,30 @@ func (s *courseService) prepareCourse(dto dto.CreateCourseDTO) (*models.Course,
 		Weight:            dto.Weight,
 		Capacity:          dto.Capacity,
 		GenderRestriction: dto.GenderRestriction,
+		GroupNumber:       strings.TrimSpace(dto.GroupNumber),
+		PracticalWeight:   dto.PracticalWeight,
+		Enrolled:          dto.Enrolled,
+		WaitingList:       dto.WaitingList,
+		Notes:             strings.TrimSpace(dto.Notes),
+		CrossCampus:       dto.CrossCampus,
+		EmergencyDrop:     dto.EmergencyDrop,
 		ExamStart:         examStart,
 		ExamEnd:           examEnd,
 		CourseTimes:       courseTimes,
 	}, nil
 }
 
-func (s *courseService) CreateFromEngine(reqDto dto.CourseEngineDTO) (*dto.CourseResponse, error) {
-	// Convert engine times to standard format
-	var times []string
-	for _, t := range []string{reqDto.Time1, reqDto.Time2, reqDto.Time3, reqDto.Time4, reqDto.Time5} {
-		if t != "" {
-			times = append(times, t)
-		}
-	}
-
-	createDTO := dto.CreateCourseDTO{
-		UniversityID:      reqDto.UniversityID,
-		SemesterID:        reqDto.SemesterID,
-		Code:              reqDto.CourseID,
-		Name:              reqDto.Name,
-		ProfessorName:     reqDto.Professor,
-		Weight:            reqDto.Weight,
-		Capacity:          reqDto.Capacity,
-		GenderRestriction: reqDto.Gender,
-		Times:             times,
-		TimeExam:          reqDto.TimeExam,
-		DateExam:          reqDto.DateExam,
-	}
-
-	return s.Create(createDTO)
-}
-
 func mapCourseTimeToResponse(courseTime models.CourseTime) dto.CourseTimeResponse {
 	return dto.CourseTimeResponse{
-		ID:        courseTime.ID,
-		CourseID:  courseTime.CourseID,
-		DayOfWeek: courseTime.DayOfWeek,
-		StartTime: courseTime.StartTime,
-		EndTime:   courseTime.EndTime,
+		ID:         courseTime.ID,
+		CourseID:   courseTime.CourseID,
+		DayOfWeek:  courseTime.DayOfWeek,
+		StartTime:  courseTime.StartTime,
+		EndTime:    courseTime.EndTime,
+		Room:       courseTime.Room,
+		Location:   courseTime.Location,
+		WeekParity: courseTime.WeekParity,
+		HalfTerm:   courseTime.HalfTerm,
 	}
 }
 
@@ -667,36 +805,60 @@ func mapCourseTimesToResponse(courseTimes []models.CourseTime) []dto.CourseTimeR
 	return response
 }
 
-func mapCourseToResponse(course *models.Course) *dto.CourseResponse {
+// mapCourseToResponse maps a course, naming its professor if it was loaded with it
+func mapCourseToResponse(course *models.Course, loc *time.Location) *dto.CourseResponse {
 	if course == nil {
 		return nil
 	}
-	return &dto.CourseResponse{
+	response := &dto.CourseResponse{
 		ID:                course.ID,
 		UniversityID:      course.UniversityID,
 		FacultyID:         course.FacultyID,
 		ProfessorID:       course.ProfessorID,
+		CatalogCourseID:   course.CatalogCourseID,
+		ProfessorName:     course.Professor.Name,
 		SemesterID:        course.SemesterID,
 		Code:              course.Code,
 		Name:              course.Name,
 		Weight:            course.Weight,
 		Capacity:          course.Capacity,
 		GenderRestriction: course.GenderRestriction,
-		ExamStart:         course.ExamStart,
-		ExamEnd:           course.ExamEnd,
+		GroupNumber:       course.GroupNumber,
+		PracticalWeight:   course.PracticalWeight,
+		Enrolled:          course.Enrolled,
+		WaitingList:       course.WaitingList,
+		Notes:             course.Notes,
+		CrossCampus:       course.CrossCampus,
+		EmergencyDrop:     course.EmergencyDrop,
 		CourseTimes:       mapCourseTimesToResponse(course.CourseTimes),
 		CreatedAt:         course.CreatedAt,
 		UpdatedAt:         course.UpdatedAt,
 	}
+	setExamFields(response, course.ExamStart, course.ExamEnd, loc)
+	return response
+}
+
+// setExamFields shows exam times in loc along with the exam day in both calendars
+func setExamFields(response *dto.CourseResponse, examStart, examEnd time.Time, loc *time.Location) {
+	response.ExamStart = examStart
+	response.ExamEnd = examEnd
+	if examStart.IsZero() {
+		return
+	}
+
+	response.ExamStart = examStart.In(loc)
+	response.ExamEnd = examEnd.In(loc)
+	response.ExamDate = response.ExamStart.Format("2006-01-02")
+	response.ExamDateJalali = jalali.FromTime(response.ExamStart).String()
 }
 
-func mapCoursesToResponse(courses []*models.Course) []*dto.CourseResponse {
+func mapCoursesToResponse(courses []*models.Course, loc *time.Location) []*dto.CourseResponse {
 	if courses == nil {
 		return nil
 	}
 	responses := make([]*dto.CourseResponse, len(courses))
 	for i, course := range courses {
-		responses[i] = mapCourseToResponse(course)
+		responses[i] = mapCourseToResponse(course, loc)
 	}
 	return responses
 }
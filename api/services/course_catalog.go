@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+// Catalog lists a page of the courses a university offers in a semester
+func (s *courseService) Catalog(filters *dto.CourseCatalogFilters, pagination *dto.PaginationQuery) (*dto.PaginatedList[dto.CourseResponse], error) {
+	if filters.UniversityID == uuid.Nil {
+		return nil, errors.NewValidationError("university_id")
+	}
+	if filters.SemesterID == uuid.Nil {
+		return nil, errors.NewValidationError("semester_id")
+	}
+	if err := checkCatalogTimeRange(filters.From, filters.To); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background() // todo: remove and pass request context
+	if _, err := s.universityService.Get(ctx, filters.UniversityID); err != nil {
+		return nil, err
+	}
+	if _, err := s.semesterService.Get(filters.SemesterID); err != nil {
+		return nil, err
+	}
+
+	faculties, err := facultiesByID(s.facultyService, filters.UniversityID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.courseRepo.FindCatalog(filters, pagination)
+	if err != nil {
+		s.logger.Error("Failed to fetch course catalog",
+			zap.String("university_id", filters.UniversityID.String()),
+			zap.String("semester_id", filters.SemesterID.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to fetch course catalog: %w", err)
+	}
+
+	items := make([]dto.CourseResponse, 0, len(result.Items))
+	for i := range result.Items {
+		items = append(items, *mapListedCourse(&result.Items[i], faculties, s.location))
+	}
+
+	return &dto.PaginatedList[dto.CourseResponse]{
+		Items: items,
+		Total: result.Total,
+		Page:  result.Page,
+		Limit: result.Limit,
+	}, nil
+}
+
+// facultiesByID looks up a university's faculties to name the faculty of each listed course
+func facultiesByID(facultyService FacultyService, universityID uuid.UUID) (map[uuid.UUID]*dto.FacultyResponse, error) {
+	faculties, err := facultyService.GetAllByUniversity(universityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch faculties: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*dto.FacultyResponse, len(faculties))
+	for _, faculty := range faculties {
+		byID[faculty.ID] = faculty
+	}
+	return byID, nil
+}
+
+// mapListedCourse maps a course loaded with its professor, naming its faculty
+func mapListedCourse(course *models.Course, faculties map[uuid.UUID]*dto.FacultyResponse, loc *time.Location) *dto.CourseResponse {
+	response := mapCourseToResponse(course, loc)
+	if faculty, ok := faculties[course.FacultyID]; ok {
+		response.FacultyNameEn = faculty.NameEn
+		response.FacultyNameFa = faculty.NameFa
+	}
+	return response
+}
+
+// checkCatalogTimeRange validates the optional HH:MM bounds of a catalog time filter
+func checkCatalogTimeRange(from, to string) error {
+	var start, end time.Time
+	var err error
+	if from != "" {
+		if start, err = time.Parse("15:04", from); err != nil {
+			return errors.NewValidationError("from")
+		}
+	}
+	if to != "" {
+		if end, err = time.Parse("15:04", to); err != nil {
+			return errors.NewValidationError("to")
+		}
+	}
+	if from != "" && to != "" && !end.After(start) {
+		return errors.NewValidationError("time range ending before it starts")
+	}
+	return nil
+}
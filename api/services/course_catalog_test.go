@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCatalogTimeRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "no bounds"},
+		{name: "only from", from: "08:00"},
+		{name: "only to", to: "18:00"},
+		{name: "range", from: "08:00", to: "12:00"},
+		{name: "malformed from", from: "8am", wantErr: true},
+		{name: "malformed to", to: "25:00", wantErr: true},
+		{name: "empty range", from: "10:00", to: "10:00", wantErr: true},
+		{name: "ending before it starts", from: "12:00", to: "08:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCatalogTimeRange(tt.from, tt.to)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errors.ErrInvalid)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,850 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/armanjr/termustat/api/utils"
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// courseImportPlanTTL is how long a previewed import can be committed
+const courseImportPlanTTL = time.Hour
+
+func (s *courseService) ImportFromEngine(universityID, semesterID uuid.UUID, courses []schema.Course) ([]*dto.CourseResponse, error) {
+	batch, err := mapEngineCourses(universityID, semesterID, courses)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.batchCreateFromEngine(batch)
+}
+
+// SyncFromEngine makes a semester's courses match the engine's, creating,
+// updating and optionally deleting courses. Every course is checked before
+// anything is written.
+func (s *courseService) SyncFromEngine(universityID, semesterID uuid.UUID, courses []schema.Course, deleteMissing bool) (*dto.CourseImportDiffResponse, error) {
+	if _, err := s.universityService.Get(context.Background(), universityID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.semesterService.Get(semesterID); err != nil {
+		return nil, err
+	}
+
+	batch, err := mapEngineCourses(universityID, semesterID, courses)
+	if err != nil {
+		return nil, err
+	}
+
+	facultyIDs, err := s.resolveEngineFaculties(universityID, batch.Courses)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.courseRepo.FindAllByUniversityAndSemester(universityID, semesterID)
+	if err != nil {
+		s.logger.Error("Failed to fetch existing courses",
+			zap.String("university_id", universityID.String()),
+			zap.String("semester_id", semesterID.String()),
+			zap.String("service", "Course"),
+			zap.String("operation", "SyncFromEngine"),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to sync courses")
+	}
+
+	existingByCode := make(map[string]*models.Course, len(existing))
+	for _, course := range existing {
+		existingByCode[course.Code] = course
+	}
+
+	report := &dto.CourseImportDiffResponse{
+		Created: []dto.CourseDiffEntry{},
+		Updated: []dto.CourseDiffEntry{},
+		Deleted: []dto.CourseDiffEntry{},
+	}
+	var created, updated []*models.Course
+	var professors []*models.Professor
+	professorIDs := make(map[string]uuid.UUID)
+	facultyLookup := func(faculty string) (uuid.UUID, bool) {
+		id, ok := facultyIDs[faculty]
+		return id, ok
+	}
+	opts := dto.CourseImportOptions{UniversityID: universityID, SemesterID: semesterID, Mode: "sync"}
+	seen := make(map[string]bool, len(batch.Courses))
+
+	for i, engineCourse := range batch.Courses {
+		code := strings.TrimSpace(engineCourse.Code)
+		if seen[code] {
+			return nil, errors.NewValidationError(fmt.Sprintf("duplicate course code %s at index %d", code, i))
+		}
+		seen[code] = true
+
+		check, err := s.checkImportRow(opts, engineCourse, facultyLookup, professorIDs)
+		if err != nil {
+			return nil, err
+		}
+		if len(check.errors) > 0 {
+			return nil, errors.NewValidationError(fmt.Sprintf("course %s at index %d (%s)", code, i, strings.Join(check.errors, "; ")))
+		}
+
+		// Professors the import introduces are created with the courses, in
+		// the same transaction, so a failed sync leaves none behind
+		if check.newProfessor {
+			professor := &models.Professor{
+				ID:             uuid.New(),
+				UniversityID:   universityID,
+				Name:           check.professorName,
+				NormalizedName: utils.NormalizeProfessor(check.professorName),
+			}
+			professors = append(professors, professor)
+			professorIDs[professor.NormalizedName] = professor.ID
+			check.course.ProfessorID = professor.ID
+		}
+		desired := check.course
+
+		current, ok := existingByCode[code]
+		if !ok {
+			created = append(created, desired)
+			continue
+		}
+
+		changes := diffCourses(current, desired, check.professorName)
+		if len(changes) == 0 {
+			report.Unchanged++
+			continue
+		}
+
+		applyCourseChanges(current, desired)
+		updated = append(updated, current)
+		report.Updated = append(report.Updated, dto.CourseDiffEntry{
+			ID:      current.ID,
+			Code:    current.Code,
+			Name:    current.Name,
+			Changes: changes,
+		})
+	}
+
+	var deletedIDs []uuid.UUID
+	if deleteMissing {
+		for _, course := range existing {
+			if seen[course.Code] {
+				continue
+			}
+			deletedIDs = append(deletedIDs, course.ID)
+			report.Deleted = append(report.Deleted, dto.CourseDiffEntry{
+				ID:   course.ID,
+				Code: course.Code,
+				Name: course.Name,
+			})
+		}
+	}
+
+	if err := s.courseRepo.Sync(professors, created, updated, deletedIDs); err != nil {
+		s.logger.Error("Failed to sync courses",
+			zap.String("university_id", universityID.String()),
+			zap.String("semester_id", semesterID.String()),
+			zap.String("service", "Course"),
+			zap.String("operation", "SyncFromEngine"),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to sync courses")
+	}
+
+	for _, course := range created {
+		report.Created = append(report.Created, dto.CourseDiffEntry{
+			ID:   course.ID,
+			Code: course.Code,
+			Name: course.Name,
+		})
+	}
+
+	s.logger.Info("Synced courses from engine",
+		zap.String("university_id", universityID.String()),
+		zap.String("semester_id", semesterID.String()),
+		zap.Int("created", len(report.Created)),
+		zap.Int("updated", len(report.Updated)),
+		zap.Int("deleted", len(report.Deleted)),
+		zap.Int("unchanged", report.Unchanged),
+		zap.String("service", "Course"),
+		zap.String("operation", "SyncFromEngine"))
+
+	return report, nil
+}
+
+// PreviewImport reports what importing the rows of a file would do without
+// writing any courses or professors. Rows that could not be read are listed
+// as invalid with their errors. A valid preview is stored as a plan for
+// CommitImport.
+func (s *courseService) PreviewImport(opts dto.CourseImportOptions, rows []dto.CourseImportRow, createdBy uuid.UUID) (*dto.CourseImportPreviewResponse, error) {
+	if opts.Mode != "create" && opts.Mode != "sync" {
+		return nil, errors.NewValidationError("import mode")
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.NewValidationError("no courses provided")
+	}
+
+	if _, err := s.universityService.Get(context.Background(), opts.UniversityID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.semesterService.Get(opts.SemesterID); err != nil {
+		return nil, err
+	}
+
+	facultyLookup, err := s.engineFacultyLookup(opts.UniversityID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.courseRepo.FindAllByUniversityAndSemester(opts.UniversityID, opts.SemesterID)
+	if err != nil {
+		s.logger.Error("Failed to fetch existing courses",
+			zap.String("university_id", opts.UniversityID.String()),
+			zap.String("semester_id", opts.SemesterID.String()),
+			zap.String("service", "Course"),
+			zap.String("operation", "PreviewImport"),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to preview course import")
+	}
+
+	existingByCode := make(map[string]*models.Course, len(existing))
+	for _, course := range existing {
+		existingByCode[course.Code] = course
+	}
+
+	preview := &dto.CourseImportPreviewResponse{
+		Valid:              true,
+		Rows:               make([]dto.CourseImportPreviewRow, 0, len(rows)),
+		NewProfessors:      []string{},
+		UnmatchedFaculties: []string{},
+		Diff: dto.CourseImportDiffResponse{
+			Created: []dto.CourseDiffEntry{},
+			Updated: []dto.CourseDiffEntry{},
+			Deleted: []dto.CourseDiffEntry{},
+		},
+	}
+	professorIDs := make(map[string]uuid.UUID)
+	unmatched := make(map[string]bool)
+	newProfessors := make(map[string]bool)
+	seen := make(map[string]bool, len(rows))
+	courses := make([]schema.Course, 0, len(rows))
+
+	for _, fileRow := range rows {
+		if fileRow.Course == nil {
+			if code := strings.TrimSpace(fileRow.Code); code != "" {
+				seen[code] = true
+			}
+			preview.Valid = false
+			preview.Rows = append(preview.Rows, dto.CourseImportPreviewRow{
+				Index:    fileRow.Index,
+				Code:     fileRow.Code,
+				Name:     fileRow.Name,
+				Action:   "invalid",
+				Errors:   fileRow.Errors,
+				Warnings: fileRow.Warnings,
+			})
+			continue
+		}
+		course := *fileRow.Course
+		courses = append(courses, course)
+
+		check, err := s.checkImportRow(opts, course, facultyLookup, professorIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if check.unmatchedFaculty != "" && !unmatched[check.unmatchedFaculty] {
+			unmatched[check.unmatchedFaculty] = true
+			preview.UnmatchedFaculties = append(preview.UnmatchedFaculties, check.unmatchedFaculty)
+		}
+		if check.newProfessor && !newProfessors[check.professorName] {
+			newProfessors[check.professorName] = true
+			preview.NewProfessors = append(preview.NewProfessors, check.professorName)
+		}
+
+		row := dto.CourseImportPreviewRow{
+			Index:    fileRow.Index,
+			Code:     course.Code,
+			Name:     course.Name,
+			Course:   fileRow.Course,
+			Errors:   check.errors,
+			Warnings: fileRow.Warnings,
+		}
+
+		code := strings.TrimSpace(course.Code)
+		if code != "" {
+			if seen[code] {
+				row.Errors = append(row.Errors, fmt.Sprintf("duplicate course code %s", code))
+			}
+			seen[code] = true
+		}
+
+		current, exists := existingByCode[code]
+		if len(row.Errors) == 0 && exists && opts.Mode == "create" {
+			row.Errors = append(row.Errors, "course with this code already exists")
+		}
+
+		switch {
+		case len(row.Errors) > 0:
+			row.Action = "invalid"
+			preview.Valid = false
+		case !exists:
+			row.Action = "create"
+			preview.Diff.Created = append(preview.Diff.Created, dto.CourseDiffEntry{
+				Code: check.course.Code,
+				Name: check.course.Name,
+			})
+		default:
+			row.Changes = diffCourses(current, check.course, check.professorName)
+			if len(row.Changes) == 0 {
+				row.Action = "unchanged"
+				preview.Diff.Unchanged++
+				break
+			}
+			row.Action = "update"
+			preview.Diff.Updated = append(preview.Diff.Updated, dto.CourseDiffEntry{
+				ID:      current.ID,
+				Code:    current.Code,
+				Name:    current.Name,
+				Changes: row.Changes,
+			})
+		}
+
+		preview.Rows = append(preview.Rows, row)
+	}
+
+	if opts.Mode == "sync" && opts.DeleteMissing {
+		for _, course := range existing {
+			if seen[course.Code] {
+				continue
+			}
+			preview.Diff.Deleted = append(preview.Diff.Deleted, dto.CourseDiffEntry{
+				ID:   course.ID,
+				Code: course.Code,
+				Name: course.Name,
+			})
+		}
+	}
+
+	if !preview.Valid {
+		return preview, nil
+	}
+
+	if err := s.importPlanRepo.CleanupExpired(); err != nil {
+		s.logger.Warn("Failed to clean up expired course import plans",
+			zap.String("service", "Course"),
+			zap.String("operation", "PreviewImport"),
+			zap.Error(err))
+	}
+
+	names, err := s.resolveImportNames(opts.UniversityID, courses, facultyLookup, professorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(courses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode course import plan: %w", err)
+	}
+
+	plan, err := s.importPlanRepo.Create(&models.CourseImportPlan{
+		UniversityID:  opts.UniversityID,
+		SemesterID:    opts.SemesterID,
+		CreatedBy:     createdBy,
+		Mode:          opts.Mode,
+		DeleteMissing: opts.DeleteMissing,
+		Records:       string(encoded),
+		Fingerprint:   importPlanFingerprint(existing, names),
+		ExpiresAt:     time.Now().Add(courseImportPlanTTL),
+	})
+	if err != nil {
+		s.logger.Error("Failed to store course import plan",
+			zap.String("university_id", opts.UniversityID.String()),
+			zap.String("semester_id", opts.SemesterID.String()),
+			zap.String("service", "Course"),
+			zap.String("operation", "PreviewImport"),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to preview course import")
+	}
+
+	preview.PlanID = &plan.ID
+	preview.ExpiresAt = &plan.ExpiresAt
+
+	return preview, nil
+}
+
+// CommitImport applies a previewed plan, refusing if the semester's courses
+// or the faculties and professors they resolve to changed since the preview
+// was made
+func (s *courseService) CommitImport(planID uuid.UUID) (*dto.CourseImportDiffResponse, error) {
+	plan, err := s.importPlanRepo.Find(planID)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			return nil, err
+		default:
+			s.logger.Error("Failed to fetch course import plan",
+				zap.String("plan_id", planID.String()),
+				zap.String("service", "Course"),
+				zap.String("operation", "CommitImport"),
+				zap.Error(err))
+			return nil, fmt.Errorf("failed to commit course import")
+		}
+	}
+
+	if plan.CommittedAt != nil {
+		return nil, errors.NewConflictError("course import plan already committed")
+	}
+
+	if time.Now().After(plan.ExpiresAt) {
+		return nil, errors.NewExpiredTokenError("course import plan")
+	}
+
+	var courses []schema.Course
+	if err := json.Unmarshal([]byte(plan.Records), &courses); err != nil {
+		s.logger.Error("Failed to decode course import plan",
+			zap.String("plan_id", planID.String()),
+			zap.String("service", "Course"),
+			zap.String("operation", "CommitImport"),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to commit course import")
+	}
+
+	existing, err := s.courseRepo.FindAllByUniversityAndSemester(plan.UniversityID, plan.SemesterID)
+	if err != nil {
+		s.logger.Error("Failed to fetch existing courses",
+			zap.String("plan_id", planID.String()),
+			zap.String("service", "Course"),
+			zap.String("operation", "CommitImport"),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to commit course import")
+	}
+
+	facultyLookup, err := s.engineFacultyLookup(plan.UniversityID)
+	if err != nil {
+		return nil, err
+	}
+	names, err := s.resolveImportNames(plan.UniversityID, courses, facultyLookup, make(map[string]uuid.UUID))
+	if err != nil {
+		return nil, err
+	}
+
+	if importPlanFingerprint(existing, names) != plan.Fingerprint {
+		return nil, errors.NewConflictError("semester courses, faculties or professors changed since the preview; preview the import again")
+	}
+
+	if err := s.importPlanRepo.MarkCommitted(planID); err != nil {
+		return nil, err
+	}
+
+	report, err := s.applyImportPlan(plan, courses)
+	if err != nil {
+		if releaseErr := s.importPlanRepo.Release(planID); releaseErr != nil {
+			s.logger.Error("Failed to release course import plan",
+				zap.String("plan_id", planID.String()),
+				zap.String("service", "Course"),
+				zap.String("operation", "CommitImport"),
+				zap.Error(releaseErr))
+		}
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (s *courseService) applyImportPlan(plan *models.CourseImportPlan, courses []schema.Course) (*dto.CourseImportDiffResponse, error) {
+	if plan.Mode == "sync" {
+		return s.SyncFromEngine(plan.UniversityID, plan.SemesterID, courses, plan.DeleteMissing)
+	}
+
+	created, err := s.ImportFromEngine(plan.UniversityID, plan.SemesterID, courses)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.CourseImportDiffResponse{
+		Created: make([]dto.CourseDiffEntry, len(created)),
+		Updated: []dto.CourseDiffEntry{},
+		Deleted: []dto.CourseDiffEntry{},
+	}
+	for i, course := range created {
+		report.Created[i] = dto.CourseDiffEntry{
+			ID:   course.ID,
+			Code: course.Code,
+			Name: course.Name,
+		}
+	}
+	return report, nil
+}
+
+type importRowCheck struct {
+	course           *models.Course
+	professorName    string
+	newProfessor     bool
+	unmatchedFaculty string
+	errors           []string
+}
+
+// checkImportRow validates a single course the way an import would, collecting
+// every problem instead of stopping at the first one. The returned error is
+// reserved for lookup failures.
+func (s *courseService) checkImportRow(
+	opts dto.CourseImportOptions,
+	course schema.Course,
+	facultyLookup func(faculty string) (uuid.UUID, bool),
+	professorIDs map[string]uuid.UUID,
+) (importRowCheck, error) {
+	var check importRowCheck
+
+	if err := course.Validate(); err != nil {
+		check.errors = append(check.errors, err.Error())
+		return check, nil
+	}
+
+	facultyID, ok := facultyLookup(course.Faculty)
+	if !ok {
+		check.unmatchedFaculty = course.Faculty
+		check.errors = append(check.errors, fmt.Sprintf("unknown faculty %s", course.Faculty))
+	}
+
+	createDTO := mapEngineCourseToCreateDTO(opts.UniversityID, opts.SemesterID, course, facultyID)
+
+	examStart, examEnd, err := s.parseExamDateTime(createDTO.DateExam, createDTO.TimeExam)
+	if err != nil {
+		check.errors = append(check.errors, "invalid exam time: "+err.Error())
+	}
+
+	courseTimes, err := s.parseCourseTimes(createDTO.Times, createDTO.Locations)
+	if err != nil {
+		check.errors = append(check.errors, "invalid course time: "+err.Error())
+	}
+
+	check.professorName = createDTO.ProfessorName
+	professorID, err := s.lookupImportProfessor(opts.UniversityID, createDTO.ProfessorName, professorIDs)
+	switch {
+	case err == nil:
+	case errors.Is(err, errors.ErrInvalid):
+		check.errors = append(check.errors, "invalid professor name")
+	default:
+		return check, err
+	}
+	check.newProfessor = utils.NormalizeProfessor(createDTO.ProfessorName) != "" && professorID == uuid.Nil
+
+	check.course = &models.Course{
+		UniversityID:      createDTO.UniversityID,
+		FacultyID:         createDTO.FacultyID,
+		ProfessorID:       professorID,
+		SemesterID:        createDTO.SemesterID,
+		Code:              strings.TrimSpace(createDTO.Code),
+		Name:              strings.TrimSpace(createDTO.Name),
+		Weight:            createDTO.Weight,
+		Capacity:          createDTO.Capacity,
+		GenderRestriction: createDTO.GenderRestriction,
+		GroupNumber:       strings.TrimSpace(createDTO.GroupNumber),
+		PracticalWeight:   createDTO.PracticalWeight,
+		Enrolled:          createDTO.Enrolled,
+		WaitingList:       createDTO.WaitingList,
+		Notes:             strings.TrimSpace(createDTO.Notes),
+		CrossCampus:       createDTO.CrossCampus,
+		EmergencyDrop:     createDTO.EmergencyDrop,
+		ExamStart:         examStart,
+		ExamEnd:           examEnd,
+		CourseTimes:       courseTimes,
+	}
+
+	return check, nil
+}
+
+// lookupImportProfessor resolves a professor name the way an import would,
+// caching by normalized name. uuid.Nil means the import creates the professor.
+func (s *courseService) lookupImportProfessor(universityID uuid.UUID, name string, professorIDs map[string]uuid.UUID) (uuid.UUID, error) {
+	normalizedName := utils.NormalizeProfessor(name)
+	if id, ok := professorIDs[normalizedName]; ok {
+		return id, nil
+	}
+
+	professor, err := s.professorService.FindByName(universityID, name)
+	switch {
+	case err == nil:
+		professorIDs[normalizedName] = professor.ID
+		return professor.ID, nil
+	case errors.Is(err, errors.ErrNotFound):
+		professorIDs[normalizedName] = uuid.Nil
+		return uuid.Nil, nil
+	default:
+		return uuid.Nil, err
+	}
+}
+
+// resolveImportNames lists what the faculties and professors of courses
+// resolve to, so a plan can tell whether a faculty, professor or alias
+// changed after its preview
+func (s *courseService) resolveImportNames(
+	universityID uuid.UUID,
+	courses []schema.Course,
+	facultyLookup func(faculty string) (uuid.UUID, bool),
+	professorIDs map[string]uuid.UUID,
+) ([]string, error) {
+	seen := make(map[string]bool)
+	var names []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, course := range courses {
+		facultyID, _ := facultyLookup(course.Faculty)
+		add("faculty " + course.Faculty + "=" + facultyID.String())
+
+		professorID, err := s.lookupImportProfessor(universityID, course.Professor, professorIDs)
+		if err != nil {
+			return nil, err
+		}
+		add("professor " + utils.NormalizeProfessor(course.Professor) + "=" + professorID.String())
+	}
+	return names, nil
+}
+
+// importPlanFingerprint identifies the stored state of a semester's courses
+// and of the names an import resolves, see resolveImportNames, so a plan can
+// tell whether either changed after its preview
+func importPlanFingerprint(courses []*models.Course, names []string) string {
+	entries := make([]string, 0, len(courses)+len(names))
+	for _, course := range courses {
+		entries = append(entries, course.ID.String()+"@"+course.UpdatedAt.UTC().Format(time.RFC3339Nano))
+	}
+	entries = append(entries, names...)
+	sort.Strings(entries)
+
+	sum := sha256.Sum256([]byte(strings.Join(entries, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *courseService) batchCreateFromEngine(batch dto.BatchEngineCoursesDTO) ([]*dto.CourseResponse, error) {
+	facultyIDs, err := s.resolveEngineFaculties(batch.UniversityID, batch.Courses)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]dto.CreateCourseDTO, len(batch.Courses))
+	for i, course := range batch.Courses {
+		dtos[i] = mapEngineCourseToCreateDTO(batch.UniversityID, batch.SemesterID, course, facultyIDs[course.Faculty])
+	}
+
+	created, err := s.BatchCreate(dtos)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Imported courses from engine",
+		zap.String("university_id", batch.UniversityID.String()),
+		zap.String("semester_id", batch.SemesterID.String()),
+		zap.Int("count", len(created)),
+		zap.String("service", "Course"),
+		zap.String("operation", "ImportFromEngine"))
+
+	return created, nil
+}
+
+// resolveEngineFaculties maps the engine's faculty strings (Golestan faculty
+// code or Persian name) to the university's faculties
+func (s *courseService) resolveEngineFaculties(universityID uuid.UUID, courses []schema.Course) (map[string]uuid.UUID, error) {
+	lookup, err := s.engineFacultyLookup(universityID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[string]uuid.UUID)
+	var missing []string
+	for _, course := range courses {
+		if _, ok := resolved[course.Faculty]; ok {
+			continue
+		}
+		id, ok := lookup(course.Faculty)
+		if !ok {
+			missing = append(missing, course.Faculty)
+		}
+		resolved[course.Faculty] = id
+	}
+
+	if len(missing) > 0 {
+		return nil, errors.NewValidationError(fmt.Sprintf("unknown faculties (%s)", strings.Join(missing, ", ")))
+	}
+
+	return resolved, nil
+}
+
+func (s *courseService) engineFacultyLookup(universityID uuid.UUID) (func(faculty string) (uuid.UUID, bool), error) {
+	faculties, err := s.facultyService.GetAllByUniversity(universityID)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]uuid.UUID, len(faculties)*2)
+	for _, faculty := range faculties {
+		byKey[strings.ToUpper(strings.TrimSpace(faculty.ShortCode))] = faculty.ID
+		byKey[utils.NormalizeText(faculty.NameFa)] = faculty.ID
+	}
+
+	return func(faculty string) (uuid.UUID, bool) {
+		if id, ok := byKey[strings.ToUpper(strings.TrimSpace(faculty))]; ok {
+			return id, true
+		}
+		id, ok := byKey[utils.NormalizeText(faculty)]
+		return id, ok
+	}, nil
+}
+
+// mapEngineCourses validates the engine's courses against the shared schema
+func mapEngineCourses(universityID, semesterID uuid.UUID, courses []schema.Course) (dto.BatchEngineCoursesDTO, error) {
+	if len(courses) == 0 {
+		return dto.BatchEngineCoursesDTO{}, errors.NewValidationError("no courses provided")
+	}
+
+	for i := range courses {
+		if err := courses[i].Validate(); err != nil {
+			return dto.BatchEngineCoursesDTO{}, errors.NewValidationError(fmt.Sprintf("course %s at index %d (%v)", courses[i].Code, i, err))
+		}
+	}
+
+	return dto.BatchEngineCoursesDTO{
+		UniversityID: universityID,
+		SemesterID:   semesterID,
+		Courses:      courses,
+	}, nil
+}
+
+// mapEngineCourseToCreateDTO writes a validated engine course as a create
+// request, sessions in the slot notation and the exam as its Jalali date
+func mapEngineCourseToCreateDTO(universityID, semesterID uuid.UUID, course schema.Course, facultyID uuid.UUID) dto.CreateCourseDTO {
+	times := make([]string, len(course.Sessions))
+	locations := make([]string, len(course.Sessions))
+	for i, session := range course.Sessions {
+		times[i] = session.String()
+		locations[i] = session.Location
+	}
+
+	var dateExam, timeExam string
+	if course.Exam != nil {
+		dateExam = course.Exam.DateJalali
+		timeExam = course.Exam.Start + "-" + course.Exam.End
+	}
+
+	return dto.CreateCourseDTO{
+		UniversityID:      universityID,
+		FacultyID:         facultyID,
+		SemesterID:        semesterID,
+		Code:              course.Code,
+		Name:              course.Name,
+		ProfessorName:     course.Professor,
+		Weight:            course.Weight,
+		Capacity:          course.Capacity,
+		GenderRestriction: string(course.Gender),
+		GroupNumber:       course.Group,
+		PracticalWeight:   course.PracticalWeight,
+		Enrolled:          course.Enrolled,
+		WaitingList:       course.WaitingList,
+		Notes:             course.Notes,
+		CrossCampus:       course.CrossCampus,
+		EmergencyDrop:     course.EmergencyDrop,
+		Times:             times,
+		Locations:         locations,
+		TimeExam:          timeExam,
+		DateExam:          dateExam,
+	}
+}
+
+// diffCourses lists the imported fields that differ between the stored and the incoming course
+func diffCourses(current, desired *models.Course, professorName string) []dto.CourseFieldChange {
+	var changes []dto.CourseFieldChange
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, dto.CourseFieldChange{Field: field, Old: old, New: new})
+		}
+	}
+
+	add("name", current.Name, desired.Name)
+	add("faculty_id", current.FacultyID.String(), desired.FacultyID.String())
+	if current.ProfessorID != desired.ProfessorID {
+		changes = append(changes, dto.CourseFieldChange{Field: "professor", Old: current.Professor.Name, New: professorName})
+	}
+	add("weight", strconv.Itoa(current.Weight), strconv.Itoa(desired.Weight))
+	add("capacity", strconv.Itoa(current.Capacity), strconv.Itoa(desired.Capacity))
+	add("gender_restriction", current.GenderRestriction, desired.GenderRestriction)
+	add("group_number", current.GroupNumber, desired.GroupNumber)
+	add("practical_weight", strconv.Itoa(current.PracticalWeight), strconv.Itoa(desired.PracticalWeight))
+	add("enrolled", strconv.Itoa(current.Enrolled), strconv.Itoa(desired.Enrolled))
+	add("waiting_list", strconv.Itoa(current.WaitingList), strconv.Itoa(desired.WaitingList))
+	add("notes", current.Notes, desired.Notes)
+	add("cross_campus", strconv.FormatBool(current.CrossCampus), strconv.FormatBool(desired.CrossCampus))
+	add("emergency_drop", strconv.FormatBool(current.EmergencyDrop), strconv.FormatBool(desired.EmergencyDrop))
+	if !current.ExamStart.Equal(desired.ExamStart) {
+		changes = append(changes, dto.CourseFieldChange{Field: "exam_start", Old: formatExamTime(current.ExamStart), New: formatExamTime(desired.ExamStart)})
+	}
+	if !current.ExamEnd.Equal(desired.ExamEnd) {
+		changes = append(changes, dto.CourseFieldChange{Field: "exam_end", Old: formatExamTime(current.ExamEnd), New: formatExamTime(desired.ExamEnd)})
+	}
+	add("course_times", formatCourseTimes(current.CourseTimes), formatCourseTimes(desired.CourseTimes))
+
+	return changes
+}
+
+func applyCourseChanges(current, desired *models.Course) {
+	current.FacultyID = desired.FacultyID
+	current.ProfessorID = desired.ProfessorID
+	current.Name = desired.Name
+	current.Weight = desired.Weight
+	current.Capacity = desired.Capacity
+	current.GenderRestriction = desired.GenderRestriction
+	current.GroupNumber = desired.GroupNumber
+	current.PracticalWeight = desired.PracticalWeight
+	current.Enrolled = desired.Enrolled
+	current.WaitingList = desired.WaitingList
+	current.Notes = desired.Notes
+	current.CrossCampus = desired.CrossCampus
+	current.EmergencyDrop = desired.EmergencyDrop
+	current.ExamStart = desired.ExamStart
+	current.ExamEnd = desired.ExamEnd
+	current.CourseTimes = desired.CourseTimes
+}
+
+func formatExamTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatCourseTimes renders course times in the engine's d<day>/HH:MM-HH:MM[/h1][/odd] notation,
+// followed by the location if there is one, sorted
+func formatCourseTimes(courseTimes []models.CourseTime) string {
+	slots := make([]string, len(courseTimes))
+	for i, ct := range courseTimes {
+		slots[i] = fmt.Sprintf("d%d/%s-%s", ct.DayOfWeek, ct.StartTime.Format("15:04"), ct.EndTime.Format("15:04"))
+		if ct.HalfTerm != 0 {
+			slots[i] += fmt.Sprintf("/h%d", ct.HalfTerm)
+		}
+		if ct.WeekParity != "" {
+			slots[i] += "/" + ct.WeekParity
+		}
+		if ct.Location != "" {
+			slots[i] += " (" + ct.Location + ")"
+		}
+	}
+	sort.Strings(slots)
+	return strings.Join(slots, " ")
+}
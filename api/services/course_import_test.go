@@ -0,0 +1,465 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/armanjr/termustat/api/repositories"
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func (m *MockCourseRepository) FindAllByUniversityAndSemester(universityID, semesterID uuid.UUID) ([]*models.Course, error) {
+	args := m.Called(universityID, semesterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Course), args.Error(1)
+}
+
+func (m *MockCourseRepository) Sync(professors []*models.Professor, created, updated []*models.Course, deletedIDs []uuid.UUID) error {
+	return m.Called(professors, created, updated, deletedIDs).Error(0)
+}
+
+type MockCourseImportPlanRepository struct {
+	repositories.CourseImportPlanRepository
+	mock.Mock
+}
+
+func (m *MockCourseImportPlanRepository) Create(plan *models.CourseImportPlan) (*models.CourseImportPlan, error) {
+	args := m.Called(plan)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseImportPlan), args.Error(1)
+}
+
+func (m *MockCourseImportPlanRepository) Find(id uuid.UUID) (*models.CourseImportPlan, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CourseImportPlan), args.Error(1)
+}
+
+func (m *MockCourseImportPlanRepository) CleanupExpired() error {
+	return m.Called().Error(0)
+}
+
+type MockUniversityService struct {
+	UniversityService
+	mock.Mock
+}
+
+func (m *MockUniversityService) Get(ctx context.Context, id uuid.UUID) (*dto.UniversityResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UniversityResponse), args.Error(1)
+}
+
+type MockFacultyService struct {
+	FacultyService
+	mock.Mock
+}
+
+func (m *MockFacultyService) GetAllByUniversity(universityID uuid.UUID) ([]*dto.FacultyResponse, error) {
+	args := m.Called(universityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.FacultyResponse), args.Error(1)
+}
+
+type MockProfessorService struct {
+	ProfessorService
+	mock.Mock
+}
+
+func (m *MockProfessorService) FindByName(universityID uuid.UUID, name string) (*dto.ProfessorMinimalResponse, error) {
+	args := m.Called(universityID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ProfessorMinimalResponse), args.Error(1)
+}
+
+var (
+	importFacultyID = uuid.MustParse("0b5c7a36-1d4e-4f2a-9c61-5f0f3d2e8a10")
+	importProfessor = dto.ProfessorMinimalResponse{ID: uuid.MustParse("6f1e2d3c-4b5a-4978-8a9b-0c1d2e3f4a5b"), Name: "احمدی علی"}
+)
+
+// importFixture is a course service over a semester holding the existing
+// courses, of a university with one faculty, 12, and one professor,
+// importProfessor
+func importFixture(t *testing.T, existing ...*models.Course) (*courseService, *MockCourseImportPlanRepository, dto.CourseImportOptions) {
+	opts := dto.CourseImportOptions{UniversityID: uuid.New(), SemesterID: uuid.New(), Mode: "create"}
+
+	universities := new(MockUniversityService)
+	universities.On("Get", opts.UniversityID).Return(&dto.UniversityResponse{ID: opts.UniversityID}, nil)
+	semesters := new(MockSemesterService)
+	semesters.On("Get", opts.SemesterID).Return(&dto.SemesterResponse{ID: opts.SemesterID}, nil)
+	faculties := new(MockFacultyService)
+	faculties.On("GetAllByUniversity", opts.UniversityID).Return([]*dto.FacultyResponse{{ID: importFacultyID, ShortCode: "12"}}, nil)
+	professors := new(MockProfessorService)
+	professors.On("FindByName", opts.UniversityID, importProfessor.Name).Return(&importProfessor, nil)
+	professors.On("FindByName", opts.UniversityID, mock.Anything).Return(nil, errors.ErrNotFound)
+	courses := new(MockCourseRepository)
+	if existing == nil {
+		existing = []*models.Course{}
+	}
+	courses.On("FindAllByUniversityAndSemester", opts.UniversityID, opts.SemesterID).Return(existing, nil)
+	plans := new(MockCourseImportPlanRepository)
+
+	return &courseService{
+		courseRepo:        courses,
+		importPlanRepo:    plans,
+		universityService: universities,
+		facultyService:    faculties,
+		professorService:  professors,
+		semesterService:   semesters,
+		location:          tehran(t),
+		logger:            zap.NewNop(),
+	}, plans, opts
+}
+
+func engineCourse(code, professor string) schema.Course {
+	return schema.Course{
+		Code:      code,
+		Name:      "ریاضی عمومی 1",
+		Faculty:   "12",
+		Professor: professor,
+		Weight:    3,
+		Capacity:  40,
+		Gender:    schema.GenderMixed,
+		Sessions:  []schema.Session{{Day: 0, Start: "08:00", End: "10:00"}},
+	}
+}
+
+// storedCourse is engineCourse as it is stored after an import by importProfessor
+func storedCourse(code string) *models.Course {
+	return &models.Course{
+		ID:                uuid.New(),
+		FacultyID:         importFacultyID,
+		ProfessorID:       importProfessor.ID,
+		Professor:         models.Professor{Name: importProfessor.Name},
+		Code:              code,
+		Name:              "ریاضی عمومی 1",
+		Weight:            3,
+		Capacity:          40,
+		GenderRestriction: "mixed",
+		CourseTimes: []models.CourseTime{{
+			DayOfWeek: 0,
+			StartTime: time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+		}},
+	}
+}
+
+func importRow(index int, code string) dto.CourseImportRow {
+	course := engineCourse(code, "الیاسی نیره")
+	return dto.CourseImportRow{Index: index, Code: code, Name: course.Name, Course: &course, Warnings: []string{"no exam listed"}}
+}
+
+// courseRows are the rows of engine JSON holding courses
+func courseRows(courses ...schema.Course) []dto.CourseImportRow {
+	rows := make([]dto.CourseImportRow, len(courses))
+	for i := range courses {
+		rows[i] = dto.CourseImportRow{Index: i, Code: courses[i].Code, Name: courses[i].Name, Course: &courses[i]}
+	}
+	return rows
+}
+
+func TestMapEngineCourseToCreateDTO(t *testing.T) {
+	course := engineCourse("1211003_01", importProfessor.Name)
+	course.Group = "01"
+	course.Enrolled = 12
+	course.CrossCampus = true
+	course.Sessions = append(course.Sessions, schema.Session{
+		Day: 2, Start: "10:00", End: "12:00", Location: "109- طبقه همکف", Room: "109", WeekParity: schema.WeekParityOdd,
+	})
+	course.Exam = &schema.Exam{Date: "2025-01-09", DateJalali: "1403/10/20", Start: "08:00", End: "10:00"}
+
+	created := mapEngineCourseToCreateDTO(uuid.Nil, uuid.Nil, course, importFacultyID)
+	assert.Equal(t, importFacultyID, created.FacultyID)
+	assert.Equal(t, "mixed", created.GenderRestriction)
+	assert.Equal(t, "01", created.GroupNumber)
+	assert.Equal(t, 12, created.Enrolled)
+	assert.True(t, created.CrossCampus)
+	assert.Equal(t, []string{"d0/08:00-10:00", "d2/10:00-12:00/odd"}, created.Times)
+	assert.Equal(t, []string{"", "109- طبقه همکف"}, created.Locations)
+	assert.Equal(t, "1403/10/20", created.DateExam)
+	assert.Equal(t, "08:00-10:00", created.TimeExam)
+
+	invalid := course
+	invalid.Weight = 0
+	_, err := mapEngineCourses(uuid.Nil, uuid.Nil, []schema.Course{course, invalid})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	assert.ErrorContains(t, err, "at index 1")
+}
+
+func TestResolveEngineFaculties(t *testing.T) {
+	universityID := uuid.New()
+	science := &dto.FacultyResponse{ID: uuid.New(), ShortCode: "sci", NameFa: "دانشکده علوم"}
+	faculties := new(MockFacultyService)
+	faculties.On("GetAllByUniversity", universityID).Return([]*dto.FacultyResponse{science}, nil)
+	service := &courseService{facultyService: faculties, logger: zap.NewNop()}
+
+	resolved, err := service.resolveEngineFaculties(universityID, []schema.Course{
+		{Faculty: "SCI"},
+		{Faculty: "دانشكده علوم"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, science.ID, resolved["SCI"])
+	assert.Equal(t, science.ID, resolved["دانشكده علوم"])
+
+	_, err = service.resolveEngineFaculties(universityID, []schema.Course{{Faculty: "ENG"}, {Faculty: "ادبیات"}})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	assert.ErrorContains(t, err, "unknown faculties (ENG, ادبیات)")
+}
+
+func TestPreviewImportListsInvalidRows(t *testing.T) {
+	s, plans, opts := importFixture(t)
+	unknownFaculty := engineCourse("1211004_01", "الیاسی نیره")
+	unknownFaculty.Faculty = "99"
+
+	preview, err := s.PreviewImport(opts, courseRows(
+		engineCourse("1211003_01", importProfessor.Name),
+		unknownFaculty,
+		engineCourse("1211003_01", importProfessor.Name),
+	), uuid.New())
+	require.NoError(t, err)
+
+	assert.False(t, preview.Valid)
+	assert.Nil(t, preview.PlanID)
+	require.Len(t, preview.Rows, 3)
+	assert.Equal(t, "create", preview.Rows[0].Action)
+	assert.Equal(t, "invalid", preview.Rows[1].Action)
+	assert.Equal(t, []string{"unknown faculty 99"}, preview.Rows[1].Errors)
+	assert.Equal(t, "invalid", preview.Rows[2].Action)
+	assert.Equal(t, []string{"duplicate course code 1211003_01"}, preview.Rows[2].Errors)
+	assert.Equal(t, []string{"99"}, preview.UnmatchedFaculties)
+	assert.Equal(t, []string{"الیاسی نیره"}, preview.NewProfessors)
+	plans.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestPreviewImportStoresPlan(t *testing.T) {
+	changed := storedCourse("1211004_01")
+	changed.Capacity = 30
+	s, plans, opts := importFixture(t, storedCourse("1211003_01"), changed)
+	opts.Mode = "sync"
+	plans.On("CleanupExpired").Return(nil)
+	plans.On("Create", mock.Anything).Return(&models.CourseImportPlan{ID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	preview, err := s.PreviewImport(opts, courseRows(
+		engineCourse("1211003_01", importProfessor.Name),
+		engineCourse("1211004_01", importProfessor.Name),
+		engineCourse("1211005_01", importProfessor.Name),
+	), uuid.New())
+	require.NoError(t, err)
+
+	assert.True(t, preview.Valid)
+	assert.NotNil(t, preview.PlanID)
+	assert.Equal(t, 1, preview.Diff.Unchanged)
+	require.Len(t, preview.Diff.Updated, 1)
+	assert.Equal(t, []dto.CourseFieldChange{{Field: "capacity", Old: "30", New: "40"}}, preview.Diff.Updated[0].Changes)
+	require.Len(t, preview.Diff.Created, 1)
+	assert.Equal(t, "1211005_01", preview.Diff.Created[0].Code)
+	plans.AssertCalled(t, "Create", mock.Anything)
+}
+
+func TestPreviewImportListsUnreadableRows(t *testing.T) {
+	s, plans, opts := importFixture(t)
+	rows := []dto.CourseImportRow{
+		importRow(2, "1211003_01"),
+		{Index: 3, Code: "1211004_01", Name: "ریاضی عمومی 2", Errors: []string{`unknown gender "نامعلوم"`}},
+	}
+
+	preview, err := s.PreviewImport(opts, rows, uuid.New())
+	require.NoError(t, err)
+
+	assert.False(t, preview.Valid)
+	assert.Nil(t, preview.PlanID)
+	require.Len(t, preview.Rows, 2)
+
+	assert.Equal(t, 2, preview.Rows[0].Index)
+	assert.Equal(t, "create", preview.Rows[0].Action)
+	assert.Empty(t, preview.Rows[0].Errors)
+	assert.Equal(t, []string{"no exam listed"}, preview.Rows[0].Warnings)
+
+	assert.Equal(t, 3, preview.Rows[1].Index)
+	assert.Equal(t, "invalid", preview.Rows[1].Action)
+	assert.Equal(t, "1211004_01", preview.Rows[1].Code)
+	assert.Nil(t, preview.Rows[1].Course)
+	assert.Equal(t, []string{`unknown gender "نامعلوم"`}, preview.Rows[1].Errors)
+
+	assert.Equal(t, []string{"الیاسی نیره"}, preview.NewProfessors)
+	plans.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestPreviewImportStoresPlanWithWarnings(t *testing.T) {
+	s, plans, opts := importFixture(t)
+	plans.On("CleanupExpired").Return(nil)
+	plans.On("Create", mock.Anything).Return(&models.CourseImportPlan{ID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour)}, nil)
+
+	preview, err := s.PreviewImport(opts, []dto.CourseImportRow{importRow(2, "1211003_01")}, uuid.New())
+	require.NoError(t, err)
+
+	assert.True(t, preview.Valid)
+	assert.NotNil(t, preview.PlanID)
+	require.Len(t, preview.Rows, 1)
+	assert.Equal(t, "create", preview.Rows[0].Action)
+	assert.Equal(t, []string{"no exam listed"}, preview.Rows[0].Warnings)
+	plans.AssertCalled(t, "Create", mock.Anything)
+}
+
+func TestCommitImportRefusesStalePlans(t *testing.T) {
+	s, plans, opts := importFixture(t, storedCourse("1211003_01"))
+	plan := func(modify func(*models.CourseImportPlan)) uuid.UUID {
+		p := &models.CourseImportPlan{
+			ID:           uuid.New(),
+			UniversityID: opts.UniversityID,
+			SemesterID:   opts.SemesterID,
+			Mode:         "create",
+			Records:      "[]",
+			Fingerprint:  importPlanFingerprint(nil, nil),
+			ExpiresAt:    time.Now().Add(time.Hour),
+		}
+		modify(p)
+		plans.On("Find", p.ID).Return(p, nil)
+		return p.ID
+	}
+
+	committedAt := time.Now()
+	tests := []struct {
+		name string
+		id   uuid.UUID
+		err  error
+	}{
+		{name: "committed", id: plan(func(p *models.CourseImportPlan) { p.CommittedAt = &committedAt }), err: errors.ErrConflict},
+		{name: "expired", id: plan(func(p *models.CourseImportPlan) { p.ExpiresAt = time.Now().Add(-time.Minute) }), err: errors.ErrExpiredToken},
+		{name: "semester changed", id: plan(func(*models.CourseImportPlan) {}), err: errors.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CommitImport(tt.id)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+	plans.AssertNotCalled(t, "MarkCommitted", mock.Anything)
+}
+
+func TestDiffCoursesListsChangedFields(t *testing.T) {
+	current := storedCourse("1211003_01")
+	assert.Empty(t, diffCourses(current, storedCourse("1211003_01"), importProfessor.Name))
+
+	desired := storedCourse("1211003_01")
+	desired.Capacity = 45
+	desired.ProfessorID = uuid.New()
+	desired.CourseTimes[0].Location = "کلاس 101"
+
+	changes := diffCourses(current, desired, "الیاسی نیره")
+	assert.Equal(t, []dto.CourseFieldChange{
+		{Field: "professor", Old: "احمدی علی", New: "الیاسی نیره"},
+		{Field: "capacity", Old: "40", New: "45"},
+		{Field: "course_times", Old: "d0/08:00-10:00", New: "d0/08:00-10:00 (کلاس 101)"},
+	}, changes)
+}
+
+func TestApplyCourseChangesKeepsIdentity(t *testing.T) {
+	current := storedCourse("1211003_01")
+	current.CatalogCourseID = uuid.New()
+	id, catalogCourseID := current.ID, current.CatalogCourseID
+
+	desired := storedCourse("1211003_01")
+	desired.Name = "ریاضی عمومی 2"
+	desired.Capacity = 45
+	desired.ProfessorID = uuid.New()
+	applyCourseChanges(current, desired)
+
+	assert.Equal(t, id, current.ID)
+	assert.Equal(t, catalogCourseID, current.CatalogCourseID)
+	assert.Equal(t, "ریاضی عمومی 2", current.Name)
+	assert.Equal(t, 45, current.Capacity)
+	assert.Equal(t, desired.ProfessorID, current.ProfessorID)
+	assert.Empty(t, diffCourses(current, desired, "الیاسی نیره"))
+}
+
+func TestSyncFromEngineAppliesDiff(t *testing.T) {
+	unchanged := storedCourse("1211003_01")
+	changed := storedCourse("1211004_01")
+	changed.Capacity = 30
+	missing := storedCourse("1211009_01")
+	s, _, opts := importFixture(t, unchanged, changed, missing)
+	courses := s.courseRepo.(*MockCourseRepository)
+	courses.On("Sync", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	report, err := s.SyncFromEngine(opts.UniversityID, opts.SemesterID, []schema.Course{
+		engineCourse("1211003_01", importProfessor.Name),
+		engineCourse("1211004_01", importProfessor.Name),
+		engineCourse("1211005_01", "الیاسی نیره"),
+		engineCourse("1211006_01", "الیاسی نیره"),
+	}, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Unchanged)
+	require.Len(t, report.Updated, 1)
+	assert.Equal(t, changed.ID, report.Updated[0].ID)
+	assert.Equal(t, []dto.CourseFieldChange{{Field: "capacity", Old: "30", New: "40"}}, report.Updated[0].Changes)
+	require.Len(t, report.Deleted, 1)
+	assert.Equal(t, missing.ID, report.Deleted[0].ID)
+	require.Len(t, report.Created, 2)
+
+	courses.AssertNumberOfCalls(t, "Sync", 1)
+	args := courses.Calls[len(courses.Calls)-1].Arguments
+	professors := args.Get(0).([]*models.Professor)
+	require.Len(t, professors, 1)
+	assert.Equal(t, "الیاسی نیره", professors[0].Name)
+	assert.Equal(t, opts.UniversityID, professors[0].UniversityID)
+	assert.NotEqual(t, uuid.Nil, professors[0].ID)
+
+	created := args.Get(1).([]*models.Course)
+	require.Len(t, created, 2)
+	for _, course := range created {
+		assert.Equal(t, professors[0].ID, course.ProfessorID)
+	}
+	assert.Equal(t, []*models.Course{changed}, args.Get(2).([]*models.Course))
+	assert.Equal(t, 40, changed.Capacity)
+	assert.Equal(t, []uuid.UUID{missing.ID}, args.Get(3).([]uuid.UUID))
+}
+
+func TestSyncFromEngineChecksEveryCourseFirst(t *testing.T) {
+	s, _, opts := importFixture(t)
+	courses := s.courseRepo.(*MockCourseRepository)
+
+	invalidExam := engineCourse("1211004_01", importProfessor.Name)
+	invalidExam.Exam = &schema.Exam{DateJalali: "1299/10/20", Start: "08:00", End: "10:00"}
+
+	for name, rows := range map[string][]schema.Course{
+		"duplicate code": {
+			engineCourse("1211003_01", "الیاسی نیره"),
+			engineCourse("1211003_01", "الیاسی نیره"),
+		},
+		"invalid exam": {
+			engineCourse("1211003_01", "الیاسی نیره"),
+			invalidExam,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.SyncFromEngine(opts.UniversityID, opts.SemesterID, rows, false)
+			assert.ErrorIs(t, err, errors.ErrInvalid)
+		})
+	}
+
+	courses.AssertNotCalled(t, "Sync", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseExamDateTime(t *testing.T) {
+	tehran, err := time.LoadLocation("Asia/Tehran")
+	require.NoError(t, err)
+	s := &courseService{location: tehran}
+
+	start, end, err := s.parseExamDateTime("1404/04/07", "08:00-10:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 6, 28, 8, 0, 0, 0, tehran), start)
+	assert.Equal(t, time.Date(2025, 6, 28, 10, 0, 0, 0, tehran), end)
+
+	start, end, err = s.parseExamDateTime("", "")
+	require.NoError(t, err)
+	assert.True(t, start.IsZero())
+	assert.True(t, end.IsZero())
+
+	for _, date := range []string{"2025/01/15", "2025-01-15", "1299/12/29", "1501/01/01", "1404/13/01"} {
+		_, _, err := s.parseExamDateTime(date, "08:00-10:00")
+		assert.ErrorIs(t, err, errors.ErrInvalid, date)
+		assert.ErrorContains(t, err, "Jalali", date)
+	}
+}
+
+func TestParseCourseTimesLocations(t *testing.T) {
+	s := &courseService{}
+	courseTimes, err := s.parseCourseTimes([]string{"d0/08:00-10:00", "d2/10:00-12:00"}, []string{"109-  طبقه همکف"})
+	require.NoError(t, err)
+	require.Len(t, courseTimes, 2)
+	assert.Equal(t, "109", courseTimes[0].Room)
+	assert.Equal(t, "109- طبقه همکف", courseTimes[0].Location)
+	assert.Empty(t, courseTimes[1].Room)
+}
+
+func TestParseTimeSlotQualifiers(t *testing.T) {
+	s := &courseService{}
+	courseTime, err := s.parseTimeSlot("d1/08:00-10:00/odd/h2", "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, courseTime.DayOfWeek)
+	assert.Equal(t, "odd", courseTime.WeekParity)
+	assert.Equal(t, 2, courseTime.HalfTerm)
+
+	courseTime, err = s.parseTimeSlot("d1/08:00-10:00", "")
+	require.NoError(t, err)
+	assert.Empty(t, courseTime.WeekParity)
+	assert.Zero(t, courseTime.HalfTerm)
+
+	_, err = s.parseTimeSlot("d1/08:00-10:00/weekly", "")
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestGetRoomOccupancy(t *testing.T) {
+	universityID, semesterID := uuid.New(), uuid.New()
+	slot := func(day int, start, room string) models.CourseTime {
+		startTime, _ := time.Parse("15:04", start)
+		return models.CourseTime{DayOfWeek: day, StartTime: startTime, EndTime: startTime.Add(2 * time.Hour), Room: room, Location: room + "- طبقه همکف"}
+	}
+	math := &models.Course{ID: uuid.New(), Code: "1211003_01", CourseTimes: []models.CourseTime{slot(2, "10:00", "109"), slot(0, "08:00", "109")}}
+	physics := &models.Course{ID: uuid.New(), Code: "1212001_01", CourseTimes: []models.CourseTime{slot(0, "10:00", "109"), slot(1, "08:00", "")}}
+	chemistry := &models.Course{ID: uuid.New(), Code: "1213001_01", CourseTimes: []models.CourseTime{slot(0, "08:00", "013")}}
+	courses := new(MockCourseRepository)
+	courses.On("FindAllByUniversityAndSemester", universityID, semesterID).Return([]*models.Course{math, physics, chemistry}, nil)
+	s := &courseService{courseRepo: courses, logger: zap.NewNop()}
+
+	rooms, err := s.GetRoomOccupancy(universityID, semesterID)
+	require.NoError(t, err)
+	require.Len(t, rooms, 2)
+
+	assert.Equal(t, "013", rooms[0].Room)
+	require.Len(t, rooms[0].Slots, 1)
+	assert.Equal(t, chemistry.ID, rooms[0].Slots[0].CourseID)
+
+	assert.Equal(t, "109", rooms[1].Room)
+	assert.Equal(t, "109- طبقه همکف", rooms[1].Location)
+	require.Len(t, rooms[1].Slots, 3)
+	assert.Equal(t, []uuid.UUID{math.ID, physics.ID, math.ID},
+		[]uuid.UUID{rooms[1].Slots[0].CourseID, rooms[1].Slots[1].CourseID, rooms[1].Slots[2].CourseID})
+	assert.Equal(t, 2, rooms[1].Slots[2].DayOfWeek)
+}
@@ -10,27 +10,50 @@ import (
 	"github.com/armanjr/termustat/api/utils"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"sort"
+	"strings"
+	"time"
 )
 
 type ProfessorService interface {
 	GetOrCreateByName(universityID uuid.UUID, name string) (*dto.ProfessorMinimalResponse, error)
+	FindByName(universityID uuid.UUID, name string) (*dto.ProfessorMinimalResponse, error)
 	GetAllByUniversity(universityID uuid.UUID) ([]dto.ProfessorMinimalResponse, error)
 	Get(id uuid.UUID) (*dto.ProfessorDetailResponse, error)
+	Create(req *dto.CreateProfessorRequest) (*dto.ProfessorDetailResponse, error)
+	Update(id uuid.UUID, req *dto.UpdateProfessorRequest) (*dto.ProfessorDetailResponse, error)
+	Delete(id uuid.UUID) error
+	Search(universityID uuid.UUID, text string, pagination *dto.PaginationQuery) (*dto.PaginatedList[dto.ProfessorMinimalResponse], error)
+	History(id uuid.UUID) ([]dto.ProfessorTeachingResponse, error)
+	FindDuplicates(universityID uuid.UUID, minScore float64) ([]dto.ProfessorDuplicateResponse, error)
+	Merge(targetID uuid.UUID, req *dto.MergeProfessorsRequest) (*dto.ProfessorDetailResponse, error)
 }
 
 type professorService struct {
 	professorRepository repositories.ProfessorRepository
+	courseRepository    repositories.CourseRepository
 	universityService   UniversityService
+	facultyService      FacultyService
+	semesterService     SemesterService
+	location            *time.Location
 	logger              *zap.Logger
 }
 
 func NewProfessorService(
 	professorRepository repositories.ProfessorRepository,
+	courseRepository repositories.CourseRepository,
 	universityService UniversityService,
+	facultyService FacultyService,
+	semesterService SemesterService,
+	location *time.Location,
 	logger *zap.Logger) ProfessorService {
 	return &professorService{
 		professorRepository: professorRepository,
+		courseRepository:    courseRepository,
 		universityService:   universityService,
+		facultyService:      facultyService,
+		semesterService:     semesterService,
+		location:            location,
 		logger:              logger,
 	}
 }
@@ -43,7 +66,7 @@ func mapProfessorToListDTO(professor *models.Professor) dto.ProfessorMinimalResp
 	}
 }
 
-func mapCoursesToProfessorResponse(courses []models.Course) []dto.CourseResponse {
+func mapCoursesToProfessorResponse(courses []models.Course, loc *time.Location) []dto.CourseResponse {
 	if len(courses) == 0 {
 		return []dto.CourseResponse{}
 	}
@@ -52,14 +75,21 @@ func mapCoursesToProfessorResponse(courses []models.Course) []dto.CourseResponse
 	for i, course := range courses {
 		response[i] = dto.CourseResponse{
 			ID:                course.ID,
+			CatalogCourseID:   course.CatalogCourseID,
 			Code:              course.Code,
 			Name:              course.Name,
 			Weight:            course.Weight,
 			Capacity:          course.Capacity,
 			GenderRestriction: course.GenderRestriction,
-			ExamStart:         course.ExamStart,
-			ExamEnd:           course.ExamEnd,
+			GroupNumber:       course.GroupNumber,
+			PracticalWeight:   course.PracticalWeight,
+			Enrolled:          course.Enrolled,
+			WaitingList:       course.WaitingList,
+			Notes:             course.Notes,
+			CrossCampus:       course.CrossCampus,
+			EmergencyDrop:     course.EmergencyDrop,
 		}
+		setExamFields(&response[i], course.ExamStart, course.ExamEnd, loc)
 	}
 	return response
 }
@@ -111,11 +141,19 @@ func (s *professorService) Get(id uuid.UUID) (*dto.ProfessorDetailResponse, erro
 		ID:             professor.ID,
 		Name:           professor.Name,
 		NormalizedName: professor.NormalizedName,
+		Email:          professor.Email,
+		FacultyID:      professor.FacultyID,
+		AcademicRank:   professor.AcademicRank,
+		Website:        professor.Website,
 		University:     *university,
-		Courses:        mapCoursesToProfessorResponse(professor.Courses),
+		Courses:        mapCoursesToProfessorResponse(professor.Courses, s.location),
+		Aliases:        make([]string, len(professor.Aliases)),
 		CreatedAt:      professor.CreatedAt,
 		UpdatedAt:      professor.UpdatedAt,
 	}
+	for i, alias := range professor.Aliases {
+		response.Aliases[i] = alias.Name
+	}
 	return response, nil
 }
 
@@ -145,7 +183,7 @@ func (s *professorService) GetOrCreateByName(universityID uuid.UUID, name string
 		return nil, fmt.Errorf("invalid professor name after normalization")
 	}
 
-	professor, err := s.professorRepository.FindByUniversityAndNormalizedName(universityID, normalizedName)
+	professor, err := s.findByNormalizedName(universityID, normalizedName)
 	if err != nil {
 		switch {
 		case errors.Is(err, errors.ErrNotFound):
@@ -178,3 +216,302 @@ func (s *professorService) GetOrCreateByName(universityID uuid.UUID, name string
 	response := mapProfessorToListDTO(professor)
 	return &response, nil
 }
+
+// FindByName looks a professor up by normalized name without creating one
+func (s *professorService) FindByName(universityID uuid.UUID, name string) (*dto.ProfessorMinimalResponse, error) {
+	normalizedName := utils.NormalizeProfessor(name)
+	if normalizedName == "" {
+		return nil, errors.NewValidationError("professor name")
+	}
+
+	professor, err := s.findByNormalizedName(universityID, normalizedName)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			return nil, err
+		default:
+			s.logger.Error("Failed to fetch professor by university and normalized name",
+				zap.String("university_id", universityID.String()),
+				zap.String("normalized_name", normalizedName),
+				zap.String("service", "Professor"),
+				zap.String("operation", "FindByName"),
+				zap.Error(err))
+			return nil, fmt.Errorf("failed to find professor")
+		}
+	}
+
+	response := mapProfessorToListDTO(professor)
+	return &response, nil
+}
+
+// findByNormalizedName resolves a name to a professor, falling back to the
+// names of professors merged into another
+func (s *professorService) findByNormalizedName(universityID uuid.UUID, normalizedName string) (*models.Professor, error) {
+	professor, err := s.professorRepository.FindByUniversityAndNormalizedName(universityID, normalizedName)
+	if errors.Is(err, errors.ErrNotFound) {
+		return s.professorRepository.FindByAlias(universityID, normalizedName)
+	}
+	return professor, err
+}
+
+// FindDuplicates suggests pairs of a university's professors that likely
+// name the same person, best match first
+func (s *professorService) FindDuplicates(universityID uuid.UUID, minScore float64) ([]dto.ProfessorDuplicateResponse, error) {
+	professors, err := s.professorRepository.FindAllByUniversity(universityID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch professors: %w", err)
+	}
+
+	keys := make([]string, len(*professors))
+	trigrams := make([]utils.Trigrams, len(*professors))
+	for i, professor := range *professors {
+		keys[i] = utils.ProfessorMatchKey(professor.Name)
+		trigrams[i] = utils.NewTrigrams(keys[i])
+	}
+
+	duplicates := []dto.ProfessorDuplicateResponse{}
+	for i := range *professors {
+		for j := i + 1; j < len(*professors); j++ {
+			if keys[i] == "" || keys[j] == "" {
+				continue
+			}
+
+			score := 1.0
+			if keys[i] != keys[j] {
+				score = trigrams[i].Similarity(trigrams[j])
+			}
+			if score < minScore {
+				continue
+			}
+
+			duplicates = append(duplicates, dto.ProfessorDuplicateResponse{
+				Professor: mapProfessorToListDTO(&(*professors)[i]),
+				Candidate: mapProfessorToListDTO(&(*professors)[j]),
+				Score:     score,
+			})
+		}
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Score > duplicates[j].Score
+	})
+	return duplicates, nil
+}
+
+// Merge folds duplicate professors into the target: their courses move to it
+// and their names become its aliases
+func (s *professorService) Merge(targetID uuid.UUID, req *dto.MergeProfessorsRequest) (*dto.ProfessorDetailResponse, error) {
+	target, err := s.professorRepository.Find(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	var sources []models.Professor
+	for _, id := range req.SourceIDs {
+		if id == targetID {
+			return nil, errors.NewValidationError("merging a professor into itself")
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		source, err := s.professorRepository.Find(id)
+		if err != nil {
+			return nil, err
+		}
+		if source.UniversityID != target.UniversityID {
+			return nil, errors.NewValidationError("professor of another university")
+		}
+		sources = append(sources, *source)
+	}
+
+	if err := s.professorRepository.Merge(targetID, sources); err != nil {
+		s.logger.Error("Failed to merge professors",
+			zap.String("target_id", targetID.String()),
+			zap.Any("source_ids", req.SourceIDs),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to merge professors: %w", err)
+	}
+
+	s.logger.Info("Merged professors",
+		zap.String("target_id", targetID.String()),
+		zap.Any("source_ids", req.SourceIDs))
+
+	return s.Get(targetID)
+}
+
+// Create adds a professor with the given details. A name that already
+// resolves to a professor, directly or through an alias, conflicts; that
+// professor is changed with Update.
+func (s *professorService) Create(req *dto.CreateProfessorRequest) (*dto.ProfessorDetailResponse, error) {
+	// Checked before the professor is created, so a bad faculty creates nothing
+	if err := s.checkFaculty(req.UniversityID, req.FacultyID); err != nil {
+		return nil, err
+	}
+
+	normalizedName := utils.NormalizeProfessor(req.Name)
+	if normalizedName == "" {
+		return nil, errors.NewValidationError("name")
+	}
+	_, err := s.findByNormalizedName(req.UniversityID, normalizedName)
+	switch {
+	case err == nil:
+		return nil, errors.NewConflictError("professor with this name")
+	case !errors.Is(err, errors.ErrNotFound):
+		return nil, fmt.Errorf("failed to find professor: %w", err)
+	}
+
+	created, err := s.GetOrCreateByName(req.UniversityID, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	professor, err := s.professorRepository.Find(created.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch professor: %w", err)
+	}
+
+	professor.Email = req.Email
+	professor.FacultyID = req.FacultyID
+	professor.AcademicRank = req.AcademicRank
+	professor.Website = req.Website
+
+	return s.save(professor, nil)
+}
+
+// Update replaces a professor's name and details. Renaming keeps the old
+// name as an alias and fails if the new one belongs to another professor.
+func (s *professorService) Update(id uuid.UUID, req *dto.UpdateProfessorRequest) (*dto.ProfessorDetailResponse, error) {
+	professor, err := s.professorRepository.Find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedName := utils.NormalizeProfessor(req.Name)
+	if normalizedName == "" {
+		return nil, errors.NewValidationError("name")
+	}
+
+	var alias *models.ProfessorAlias
+	if normalizedName != professor.NormalizedName {
+		owner, err := s.findByNormalizedName(professor.UniversityID, normalizedName)
+		switch {
+		case err == nil && owner.ID != professor.ID:
+			return nil, errors.NewConflictError("professor with this name")
+		case err != nil && !errors.Is(err, errors.ErrNotFound):
+			return nil, fmt.Errorf("failed to find professor: %w", err)
+		}
+
+		alias = &models.ProfessorAlias{
+			ProfessorID:    professor.ID,
+			UniversityID:   professor.UniversityID,
+			Name:           professor.Name,
+			NormalizedName: professor.NormalizedName,
+		}
+	}
+
+	professor.Name = req.Name
+	professor.NormalizedName = normalizedName
+	professor.Email = req.Email
+	professor.FacultyID = req.FacultyID
+	professor.AcademicRank = req.AcademicRank
+	professor.Website = req.Website
+
+	return s.save(professor, alias)
+}
+
+func (s *professorService) save(professor *models.Professor, alias *models.ProfessorAlias) (*dto.ProfessorDetailResponse, error) {
+	if err := s.checkFaculty(professor.UniversityID, professor.FacultyID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.professorRepository.Update(professor, alias); err != nil {
+		s.logger.Error("Failed to update professor",
+			zap.String("id", professor.ID.String()),
+			zap.String("service", "Professor"),
+			zap.String("operation", "Update"),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to update professor: %w", err)
+	}
+
+	return s.Get(professor.ID)
+}
+
+// checkFaculty checks that an optional faculty is one of the university's
+func (s *professorService) checkFaculty(universityID uuid.UUID, facultyID *uuid.UUID) error {
+	if facultyID == nil {
+		return nil
+	}
+
+	faculty, err := s.facultyService.Get(*facultyID)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return errors.NewValidationError("faculty_id")
+		}
+		return err
+	}
+	if faculty.UniversityID != universityID {
+		return errors.NewValidationError("faculty_id")
+	}
+	return nil
+}
+
+// Delete removes a professor who teaches no courses
+func (s *professorService) Delete(id uuid.UUID) error {
+	courses, err := s.courseRepository.FindAllByProfessor(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch professor courses: %w", err)
+	}
+	if len(courses) > 0 {
+		return errors.NewConflictError("professor with courses")
+	}
+
+	if err := s.professorRepository.Delete(id); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return err
+		}
+		s.logger.Error("Failed to delete professor",
+			zap.String("id", id.String()),
+			zap.String("service", "Professor"),
+			zap.String("operation", "Delete"),
+			zap.Error(err))
+		return fmt.Errorf("failed to delete professor: %w", err)
+	}
+
+	return nil
+}
+
+// Search lists a page of a university's professors, closest to text first,
+// tolerating the same spelling variants as the public search
+func (s *professorService) Search(universityID uuid.UUID, text string, pagination *dto.PaginationQuery) (*dto.PaginatedList[dto.ProfessorMinimalResponse], error) {
+	if universityID == uuid.Nil {
+		return nil, errors.NewValidationError("university_id")
+	}
+
+	key := utils.SearchKey(text)
+	if key == "" && strings.TrimSpace(text) != "" {
+		return nil, errors.NewValidationError("q")
+	}
+
+	professors, err := s.professorRepository.Search(universityID, key, pagination)
+	if err != nil {
+		s.logger.Error("Failed to search professors",
+			zap.String("university_id", universityID.String()),
+			zap.String("query", text),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to search professors: %w", err)
+	}
+
+	response := &dto.PaginatedList[dto.ProfessorMinimalResponse]{
+		Items: make([]dto.ProfessorMinimalResponse, len(professors.Items)),
+		Total: professors.Total,
+		Page:  professors.Page,
+		Limit: professors.Limit,
+	}
+	for i := range professors.Items {
+		response.Items[i] = mapProfessorToListDTO(&professors.Items[i])
+	}
+	return response, nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"fmt"
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/google/uuid"
+	"sort"
+)
+
+// History lists the courses a professor taught, grouped by semester and
+// newest semester first
+func (s *professorService) History(id uuid.UUID) ([]dto.ProfessorTeachingResponse, error) {
+	professor, err := s.professorRepository.Find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := s.courseRepository.FindAllByProfessor(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch professor courses: %w", err)
+	}
+
+	faculties, err := facultiesByID(s.facultyService, professor.UniversityID)
+	if err != nil {
+		return nil, err
+	}
+
+	bySemester := make(map[uuid.UUID]int)
+	history := []dto.ProfessorTeachingResponse{}
+	for _, course := range courses {
+		i, ok := bySemester[course.SemesterID]
+		if !ok {
+			semester, err := s.semesterService.Get(course.SemesterID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch semester: %w", err)
+			}
+			i = len(history)
+			bySemester[course.SemesterID] = i
+			history = append(history, dto.ProfessorTeachingResponse{Semester: *semester})
+		}
+
+		course.Professor = *professor
+		history[i].Courses = append(history[i].Courses, mapListedCourse(course, faculties, s.location))
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		a, b := history[i].Semester, history[j].Semester
+		if a.Year != b.Year {
+			return a.Year > b.Year
+		}
+		return schema.TermNumber(a.Term) > schema.TermNumber(b.Term)
+	})
+	for _, teaching := range history {
+		sort.Slice(teaching.Courses, func(i, j int) bool {
+			if teaching.Courses[i].Code != teaching.Courses[j].Code {
+				return teaching.Courses[i].Code < teaching.Courses[j].Code
+			}
+			return teaching.Courses[i].GroupNumber < teaching.Courses[j].GroupNumber
+		})
+	}
+
+	return history, nil
+}
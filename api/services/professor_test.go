@@ -0,0 +1,261 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/armanjr/termustat/api/repositories"
+	"github.com/armanjr/termustat/api/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type MockProfessorRepository struct {
+	repositories.ProfessorRepository
+	mock.Mock
+}
+
+func (m *MockProfessorRepository) FindAllByUniversity(universityID uuid.UUID) (*[]models.Professor, error) {
+	args := m.Called(universityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*[]models.Professor), args.Error(1)
+}
+
+func (m *MockProfessorRepository) Find(id uuid.UUID) (*models.Professor, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Professor), args.Error(1)
+}
+
+func (m *MockProfessorRepository) FindByUniversityAndNormalizedName(universityID uuid.UUID, normalizedName string) (*models.Professor, error) {
+	args := m.Called(universityID, normalizedName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Professor), args.Error(1)
+}
+
+func (m *MockProfessorRepository) FindByAlias(universityID uuid.UUID, normalizedName string) (*models.Professor, error) {
+	args := m.Called(universityID, normalizedName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Professor), args.Error(1)
+}
+
+func (m *MockProfessorRepository) Update(professor *models.Professor, alias *models.ProfessorAlias) (*models.Professor, error) {
+	args := m.Called(professor, alias)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Professor), args.Error(1)
+}
+
+func (m *MockProfessorRepository) Delete(id uuid.UUID) error {
+	return m.Called(id).Error(0)
+}
+
+func (m *MockProfessorRepository) Merge(targetID uuid.UUID, sources []models.Professor) error {
+	return m.Called(targetID, sources).Error(0)
+}
+
+func (m *MockCourseRepository) FindAllByProfessor(professorID uuid.UUID) ([]*models.Course, error) {
+	args := m.Called(professorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Course), args.Error(1)
+}
+
+func (m *MockFacultyService) Get(id uuid.UUID) (*dto.FacultyResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.FacultyResponse), args.Error(1)
+}
+
+// professorFixture is a professor service over mocked professors of one university
+type professorFixture struct {
+	service      *professorService
+	professors   *MockProfessorRepository
+	courses      *MockCourseRepository
+	faculties    *MockFacultyService
+	universityID uuid.UUID
+}
+
+func newProfessorFixture() *professorFixture {
+	f := &professorFixture{
+		professors:   new(MockProfessorRepository),
+		courses:      new(MockCourseRepository),
+		faculties:    new(MockFacultyService),
+		universityID: uuid.New(),
+	}
+
+	universities := new(MockUniversityService)
+	universities.On("Get", f.universityID).Return(&dto.UniversityResponse{ID: f.universityID}, nil)
+
+	f.service = &professorService{
+		professorRepository: f.professors,
+		courseRepository:    f.courses,
+		universityService:   universities,
+		facultyService:      f.faculties,
+		logger:              zap.NewNop(),
+	}
+	return f
+}
+
+// professor adds a professor of the fixture's university
+func (f *professorFixture) professor(name string) *models.Professor {
+	professor := &models.Professor{ID: uuid.New(), UniversityID: f.universityID, Name: name, NormalizedName: utils.NormalizeProfessor(name)}
+	f.professors.On("Find", professor.ID).Return(professor, nil)
+	return professor
+}
+
+func TestFindDuplicatesBestMatchFirst(t *testing.T) {
+	f := newProfessorFixture()
+	titled := f.professor("دکتر علی رضایی")
+	plain := f.professor("رضایی علی")
+	karimi := f.professor("محمد کریمی")
+	karimian := f.professor("محمد کریمیان")
+	other := f.professor("زهرا نوری")
+	f.professors.On("FindAllByUniversity", f.universityID).Return(&[]models.Professor{*karimi, *titled, *other, *karimian, *plain}, nil)
+
+	duplicates, err := f.service.FindDuplicates(f.universityID, 0.5)
+	require.NoError(t, err)
+	require.Len(t, duplicates, 2)
+
+	assert.Equal(t, titled.ID, duplicates[0].Professor.ID)
+	assert.Equal(t, plain.ID, duplicates[0].Candidate.ID)
+	assert.Equal(t, 1.0, duplicates[0].Score)
+
+	assert.Equal(t, karimi.ID, duplicates[1].Professor.ID)
+	assert.Equal(t, karimian.ID, duplicates[1].Candidate.ID)
+	assert.InDelta(t, utils.Similarity(utils.ProfessorMatchKey(karimi.Name), utils.ProfessorMatchKey(karimian.Name)), duplicates[1].Score, 1e-9)
+	assert.Less(t, duplicates[1].Score, 1.0)
+
+	none, err := f.service.FindDuplicates(f.universityID, 1.01)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestMergeValidatesSources(t *testing.T) {
+	f := newProfessorFixture()
+	target := f.professor("علی رضایی")
+	source := f.professor("رضائی علی")
+	foreign := f.professor("علی رضایی")
+	foreign.UniversityID = uuid.New()
+
+	_, err := f.service.Merge(target.ID, &dto.MergeProfessorsRequest{SourceIDs: []uuid.UUID{source.ID, target.ID}})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+
+	_, err = f.service.Merge(target.ID, &dto.MergeProfessorsRequest{SourceIDs: []uuid.UUID{foreign.ID}})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+
+	missing := uuid.New()
+	f.professors.On("Find", missing).Return(nil, errors.NewNotFoundError("professor", missing.String()))
+	_, err = f.service.Merge(target.ID, &dto.MergeProfessorsRequest{SourceIDs: []uuid.UUID{missing}})
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	f.professors.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+
+	f.professors.On("Merge", target.ID, mock.Anything).Return(nil)
+	merged, err := f.service.Merge(target.ID, &dto.MergeProfessorsRequest{SourceIDs: []uuid.UUID{source.ID, source.ID}})
+	require.NoError(t, err)
+	assert.Equal(t, target.ID, merged.ID)
+	f.professors.AssertCalled(t, "Merge", target.ID, []models.Professor{*source})
+}
+
+func TestCreateChecksFacultyFirst(t *testing.T) {
+	f := newProfessorFixture()
+	unknown := uuid.New()
+	f.faculties.On("Get", unknown).Return(nil, errors.NewNotFoundError("faculty", unknown.String()))
+	foreign := uuid.New()
+	f.faculties.On("Get", foreign).Return(&dto.FacultyResponse{ID: foreign, UniversityID: uuid.New()}, nil)
+
+	for _, facultyID := range []uuid.UUID{unknown, foreign} {
+		_, err := f.service.Create(&dto.CreateProfessorRequest{UniversityID: f.universityID, Name: "علی رضایی", FacultyID: &facultyID})
+		assert.ErrorIs(t, err, errors.ErrInvalid)
+	}
+
+	f.professors.AssertNotCalled(t, "FindByUniversityAndNormalizedName", mock.Anything, mock.Anything)
+	f.professors.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateConflictsWithNameOrAlias(t *testing.T) {
+	f := newProfessorFixture()
+	professor := f.professor("علی رضایی")
+	merged := utils.NormalizeProfessor("علی رضائی")
+	f.professors.On("FindByUniversityAndNormalizedName", f.universityID, professor.NormalizedName).Return(professor, nil)
+	f.professors.On("FindByUniversityAndNormalizedName", f.universityID, merged).Return(nil, errors.ErrNotFound)
+	f.professors.On("FindByAlias", f.universityID, merged).Return(professor, nil)
+
+	for _, name := range []string{"علی  رضایی", "علی رضائی"} {
+		_, err := f.service.Create(&dto.CreateProfessorRequest{UniversityID: f.universityID, Name: name, Email: "rezaei@example.com"})
+		assert.ErrorIs(t, err, errors.ErrConflict, name)
+	}
+	f.professors.AssertCalled(t, "FindByAlias", f.universityID, merged)
+	f.professors.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUpdateRenameKeepsOldNameAsAlias(t *testing.T) {
+	f := newProfessorFixture()
+	professor := f.professor("علی رضایی")
+	other := f.professor("محمد کریمی")
+
+	newName := utils.NormalizeProfessor("علی رضایی‌نژاد")
+	f.professors.On("FindByUniversityAndNormalizedName", f.universityID, other.NormalizedName).Return(other, nil)
+	f.professors.On("FindByUniversityAndNormalizedName", f.universityID, newName).Return(nil, errors.ErrNotFound)
+	f.professors.On("FindByAlias", f.universityID, newName).Return(nil, errors.ErrNotFound)
+
+	_, err := f.service.Update(professor.ID, &dto.UpdateProfessorRequest{Name: "محمد کریمی"})
+	assert.ErrorIs(t, err, errors.ErrConflict)
+	f.professors.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+	f.professors.On("Update", professor, mock.Anything).Return(professor, nil)
+	updated, err := f.service.Update(professor.ID, &dto.UpdateProfessorRequest{Name: "علی رضایی‌نژاد", AcademicRank: "professor"})
+	require.NoError(t, err)
+	assert.Equal(t, "علی رضایی‌نژاد", updated.Name)
+	assert.Equal(t, "professor", updated.AcademicRank)
+	f.professors.AssertCalled(t, "Update", professor, &models.ProfessorAlias{
+		ProfessorID:    professor.ID,
+		UniversityID:   f.universityID,
+		Name:           "علی رضایی",
+		NormalizedName: utils.NormalizeProfessor("علی رضایی"),
+	})
+}
+
+func TestUpdateKeepingNameAddsNoAlias(t *testing.T) {
+	f := newProfessorFixture()
+	professor := f.professor("علی رضایی")
+	f.professors.On("Update", professor, (*models.ProfessorAlias)(nil)).Return(professor, nil)
+
+	_, err := f.service.Update(professor.ID, &dto.UpdateProfessorRequest{Name: "علی  رضایی", Email: "rezaei@example.com"})
+	require.NoError(t, err)
+	f.professors.AssertCalled(t, "Update", professor, (*models.ProfessorAlias)(nil))
+	f.professors.AssertNotCalled(t, "FindByUniversityAndNormalizedName", mock.Anything, mock.Anything)
+}
+
+func TestDeleteRefusesProfessorWithCourses(t *testing.T) {
+	f := newProfessorFixture()
+	teaching := f.professor("علی رضایی")
+	retired := f.professor("محمد کریمی")
+	f.courses.On("FindAllByProfessor", teaching.ID).Return([]*models.Course{{ID: uuid.New(), ProfessorID: teaching.ID}}, nil)
+	f.courses.On("FindAllByProfessor", retired.ID).Return([]*models.Course{}, nil)
+	f.professors.On("Delete", retired.ID).Return(nil)
+
+	assert.ErrorIs(t, f.service.Delete(teaching.ID), errors.ErrConflict)
+	f.professors.AssertNotCalled(t, "Delete", teaching.ID)
+
+	require.NoError(t, f.service.Delete(retired.ID))
+	f.professors.AssertCalled(t, "Delete", retired.ID)
+}
@@ -0,0 +1,525 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/infrastructure/timetable"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/armanjr/termustat/api/repositories"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+const (
+	// defaultScheduleName names the schedule created on a user's first selection in a semester
+	defaultScheduleName = "Plan A"
+	shareTokenByteLen   = 32
+)
+
+type ScheduleService interface {
+	Create(userID uuid.UUID, req *dto.CreateScheduleRequest) (*dto.ScheduleResponse, error)
+	Get(userID, id uuid.UUID) (*dto.ScheduleResponse, error)
+	GetAll(userID, semesterID uuid.UUID) ([]dto.ScheduleResponse, error)
+	GetActive(userID, semesterID uuid.UUID) (*dto.ScheduleResponse, error)
+	GetActiveForCourse(userID, courseID uuid.UUID) (*dto.ScheduleResponse, error)
+	Update(userID, id uuid.UUID, req *dto.UpdateScheduleRequest) (*dto.ScheduleResponse, error)
+	Delete(userID, id uuid.UUID) error
+	Clone(userID, id uuid.UUID, req *dto.CloneScheduleRequest) (*dto.ScheduleResponse, error)
+	Diff(userID, id, otherID uuid.UUID) (*dto.ScheduleDiffResponse, error)
+	Share(userID, id uuid.UUID, req *dto.CreateScheduleShareRequest) (*dto.ScheduleShareResponse, error)
+	GetShares(userID, id uuid.UUID) ([]dto.ScheduleShareResponse, error)
+	RevokeShare(userID, id, shareID uuid.UUID) error
+	GetShared(token string) (*dto.SharedScheduleResponse, error)
+	Calendar(userID, id uuid.UUID) ([]byte, error)
+	SharedCalendar(token string) ([]byte, error)
+	Timetable(userID, id uuid.UUID, format string) ([]byte, error)
+}
+
+type scheduleService struct {
+	repo              repositories.ScheduleRepository
+	shareRepo         repositories.ScheduleShareRepository
+	userCourseService UserCourseService
+	courseService     CourseService
+	semesterService   SemesterService
+	calendarService   UniversitySemesterService
+	renderer          timetable.Renderer
+	location          *time.Location
+	logger            *zap.Logger
+}
+
+func NewScheduleService(
+	repo repositories.ScheduleRepository,
+	shareRepo repositories.ScheduleShareRepository,
+	userCourseService UserCourseService,
+	courseService CourseService,
+	semesterService SemesterService,
+	calendarService UniversitySemesterService,
+	renderer timetable.Renderer,
+	location *time.Location,
+	logger *zap.Logger,
+) ScheduleService {
+	return &scheduleService{
+		repo:              repo,
+		shareRepo:         shareRepo,
+		userCourseService: userCourseService,
+		courseService:     courseService,
+		semesterService:   semesterService,
+		calendarService:   calendarService,
+		renderer:          renderer,
+		location:          location,
+		logger:            logger,
+	}
+}
+
+func (s *scheduleService) Create(userID uuid.UUID, req *dto.CreateScheduleRequest) (*dto.ScheduleResponse, error) {
+	if _, err := s.semesterService.Get(req.SemesterID); err != nil {
+		return nil, errors.Wrap(err, "failed to find semester")
+	}
+
+	schedules, err := s.repo.FindByUserAndSemester(userID, req.SemesterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScheduleName(schedules, req.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	schedule := &models.Schedule{
+		UserID:     userID,
+		SemesterID: req.SemesterID,
+		Name:       req.Name,
+		Position:   nextSchedulePosition(schedules),
+		IsActive:   req.IsActive || len(schedules) == 0,
+	}
+	if err := s.repo.Create(schedule); err != nil {
+		s.logger.Error("Failed to create schedule",
+			zap.String("user_id", userID.String()),
+			zap.String("semester_id", req.SemesterID.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to create schedule: %w", err)
+	}
+
+	return mapScheduleToDTO(schedule), nil
+}
+
+// Get returns one of the user's schedules with its courses
+func (s *scheduleService) Get(userID, id uuid.UUID) (*dto.ScheduleResponse, error) {
+	schedule, err := findUserSchedule(s.repo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := s.userCourseService.GetUserCourses(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapScheduleToDTO(schedule)
+	response.Courses = courses
+	return response, nil
+}
+
+func (s *scheduleService) GetAll(userID, semesterID uuid.UUID) ([]dto.ScheduleResponse, error) {
+	schedules, err := s.repo.FindByUserAndSemester(userID, semesterID)
+	if err != nil {
+		s.logger.Error("Failed to fetch schedules",
+			zap.String("user_id", userID.String()),
+			zap.String("semester_id", semesterID.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get schedules: %w", err)
+	}
+
+	responses := make([]dto.ScheduleResponse, 0, len(schedules))
+	for i := range schedules {
+		responses = append(responses, *mapScheduleToDTO(&schedules[i]))
+	}
+	return responses, nil
+}
+
+// GetActive returns the user's active schedule of a semester, activating the
+// first schedule or creating a default one if there is none
+func (s *scheduleService) GetActive(userID, semesterID uuid.UUID) (*dto.ScheduleResponse, error) {
+	active, err := s.repo.FindActive(userID, semesterID)
+	if err == nil {
+		return mapScheduleToDTO(active), nil
+	}
+	if !errors.Is(err, errors.ErrNotFound) {
+		return nil, err
+	}
+
+	schedules, err := s.repo.FindByUserAndSemester(userID, semesterID)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) > 0 {
+		schedule := &schedules[0]
+		schedule.IsActive = true
+		if err := s.repo.Update(schedule); err != nil {
+			return nil, errors.Wrap(err, "failed to activate schedule")
+		}
+		return mapScheduleToDTO(schedule), nil
+	}
+
+	return s.Create(userID, &dto.CreateScheduleRequest{
+		SemesterID: semesterID,
+		Name:       defaultScheduleName,
+		IsActive:   true,
+	})
+}
+
+// GetActiveForCourse returns the user's active schedule of a course's semester
+func (s *scheduleService) GetActiveForCourse(userID, courseID uuid.UUID) (*dto.ScheduleResponse, error) {
+	course, err := s.courseService.Get(courseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find course")
+	}
+
+	return s.GetActive(userID, course.SemesterID)
+}
+
+func (s *scheduleService) Update(userID, id uuid.UUID, req *dto.UpdateScheduleRequest) (*dto.ScheduleResponse, error) {
+	schedule, err := findUserSchedule(s.repo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" && req.Name != schedule.Name {
+		schedules, err := s.repo.FindByUserAndSemester(userID, schedule.SemesterID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkScheduleName(schedules, req.Name, id); err != nil {
+			return nil, err
+		}
+		schedule.Name = req.Name
+	}
+	if req.Position != nil {
+		schedule.Position = *req.Position
+	}
+	if req.IsActive != nil {
+		if !*req.IsActive && schedule.IsActive {
+			return nil, errors.NewValidationError("deactivating the active schedule")
+		}
+		// Activating a draft changes the user's selection
+		if *req.IsActive && !schedule.IsActive {
+			if err := s.userCourseService.ValidateActivation(userID, id); err != nil {
+				return nil, err
+			}
+		}
+		schedule.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Update(schedule); err != nil {
+		s.logger.Error("Failed to update schedule",
+			zap.String("id", id.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to update schedule: %w", err)
+	}
+
+	return mapScheduleToDTO(schedule), nil
+}
+
+// Delete removes a schedule with its courses. Deleting the active schedule
+// activates the next one.
+func (s *scheduleService) Delete(userID, id uuid.UUID) error {
+	schedule, err := findUserSchedule(s.repo, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if schedule.IsActive {
+		if err := s.userCourseService.ValidateSelectionWindow(userID, id); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return errors.Wrap(err, "failed to delete schedule")
+	}
+
+	if schedule.IsActive {
+		remaining, err := s.repo.FindByUserAndSemester(userID, schedule.SemesterID)
+		if err != nil {
+			return err
+		}
+		if len(remaining) > 0 {
+			next := &remaining[0]
+			next.IsActive = true
+			if err := s.repo.Update(next); err != nil {
+				return errors.Wrap(err, "failed to activate schedule")
+			}
+		}
+	}
+
+	s.logger.Info("Schedule deleted",
+		zap.String("user_id", userID.String()),
+		zap.String("schedule_id", id.String()))
+
+	return nil
+}
+
+// Clone copies a schedule and its courses under a new name. The copy is never active.
+func (s *scheduleService) Clone(userID, id uuid.UUID, req *dto.CloneScheduleRequest) (*dto.ScheduleResponse, error) {
+	source, err := findUserSchedule(s.repo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.repo.FindByUserAndSemester(userID, source.SemesterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScheduleName(schedules, req.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	clone := &models.Schedule{
+		UserID:     userID,
+		SemesterID: source.SemesterID,
+		Name:       req.Name,
+		Position:   nextSchedulePosition(schedules),
+	}
+	if err := s.repo.Clone(source.ID, clone); err != nil {
+		s.logger.Error("Failed to clone schedule",
+			zap.String("id", id.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to clone schedule: %w", err)
+	}
+
+	return s.Get(userID, clone.ID)
+}
+
+// Diff compares a schedule with another schedule of the same semester, matching
+// courses by catalog course so a different section shows up as a section change
+func (s *scheduleService) Diff(userID, id, otherID uuid.UUID) (*dto.ScheduleDiffResponse, error) {
+	schedule, err := findUserSchedule(s.repo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	other, err := findUserSchedule(s.repo, userID, otherID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.SemesterID != other.SemesterID {
+		return nil, errors.NewValidationError("schedules of different semesters")
+	}
+
+	courses, err := s.userCourseService.GetUserCourses(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	otherCourses, err := s.userCourseService.GetUserCourses(userID, otherID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &dto.ScheduleDiffResponse{
+		ScheduleID:      id,
+		OtherScheduleID: otherID,
+		Added:           []dto.CourseResponse{},
+		Removed:         []dto.CourseResponse{},
+		SectionChanges:  []dto.SectionChange{},
+		Common:          []dto.CourseResponse{},
+	}
+
+	otherByCatalog := make(map[uuid.UUID]dto.CourseResponse, len(otherCourses))
+	for _, course := range otherCourses {
+		otherByCatalog[course.CatalogCourseID] = course
+		diff.OtherWeight += course.Weight
+	}
+
+	for _, course := range courses {
+		diff.Weight += course.Weight
+		otherCourse, ok := otherByCatalog[course.CatalogCourseID]
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, course)
+		case otherCourse.ID == course.ID:
+			diff.Common = append(diff.Common, course)
+		default:
+			diff.SectionChanges = append(diff.SectionChanges, dto.SectionChange{
+				CatalogCourseID: course.CatalogCourseID,
+				From:            course,
+				To:              otherCourse,
+			})
+		}
+		delete(otherByCatalog, course.CatalogCourseID)
+	}
+
+	for _, course := range otherCourses {
+		if _, ok := otherByCatalog[course.CatalogCourseID]; ok {
+			diff.Added = append(diff.Added, course)
+		}
+	}
+
+	return diff, nil
+}
+
+// Share publishes a schedule under a new public token
+func (s *scheduleService) Share(userID, id uuid.UUID, req *dto.CreateScheduleShareRequest) (*dto.ScheduleShareResponse, error) {
+	if _, err := findUserSchedule(s.repo, userID, id); err != nil {
+		return nil, err
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.NewValidationError("expires_at")
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate share token: %w", err)
+	}
+
+	share := &models.ScheduleShare{
+		ScheduleID: id,
+		Token:      token,
+		ExpiresAt:  req.ExpiresAt,
+	}
+	if err := s.shareRepo.Create(share); err != nil {
+		s.logger.Error("Failed to share schedule",
+			zap.String("schedule_id", id.String()),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to share schedule: %w", err)
+	}
+
+	s.logger.Info("Schedule shared",
+		zap.String("user_id", userID.String()),
+		zap.String("schedule_id", id.String()),
+		zap.String("share_id", share.ID.String()))
+
+	return mapScheduleShareToDTO(share), nil
+}
+
+// GetShares lists the links a schedule is shared under that haven't been revoked
+func (s *scheduleService) GetShares(userID, id uuid.UUID) ([]dto.ScheduleShareResponse, error) {
+	if _, err := findUserSchedule(s.repo, userID, id); err != nil {
+		return nil, err
+	}
+
+	shares, err := s.shareRepo.FindBySchedule(id)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ScheduleShareResponse, 0, len(shares))
+	for i := range shares {
+		responses = append(responses, *mapScheduleShareToDTO(&shares[i]))
+	}
+	return responses, nil
+}
+
+func (s *scheduleService) RevokeShare(userID, id, shareID uuid.UUID) error {
+	if _, err := findUserSchedule(s.repo, userID, id); err != nil {
+		return err
+	}
+
+	if err := s.shareRepo.Revoke(id, shareID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Schedule share revoked",
+		zap.String("user_id", userID.String()),
+		zap.String("schedule_id", id.String()),
+		zap.String("share_id", shareID.String()))
+
+	return nil
+}
+
+// GetShared returns the schedule behind a public token. Revoked and unknown
+// tokens are not found; expired ones report as expired.
+func (s *scheduleService) GetShared(token string) (*dto.SharedScheduleResponse, error) {
+	share, err := s.findShare(token)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := s.userCourseService.GetUserCourses(share.Schedule.UserID, share.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SharedScheduleResponse{
+		Name:       share.Schedule.Name,
+		SemesterID: share.Schedule.SemesterID,
+		Courses:    courses,
+		ExpiresAt:  share.ExpiresAt,
+	}, nil
+}
+
+func (s *scheduleService) findShare(token string) (*models.ScheduleShare, error) {
+	share, err := s.shareRepo.FindByToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if share.ExpiresAt != nil && share.ExpiresAt.Before(time.Now()) {
+		return nil, errors.NewExpiredTokenError("share link")
+	}
+
+	return share, nil
+}
+
+// findUserSchedule loads a schedule owned by the user. Other users' schedules
+// are reported as not found.
+func findUserSchedule(repo repositories.ScheduleRepository, userID, id uuid.UUID) (*models.Schedule, error) {
+	schedule, err := repo.Find(id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.UserID != userID {
+		return nil, errors.NewNotFoundError("schedule", id.String())
+	}
+	return schedule, nil
+}
+
+func checkScheduleName(schedules []models.Schedule, name string, ignoredID uuid.UUID) error {
+	for _, schedule := range schedules {
+		if schedule.ID != ignoredID && schedule.Name == name {
+			return errors.NewConflictError(fmt.Sprintf("schedule name %q", name))
+		}
+	}
+	return nil
+}
+
+func nextSchedulePosition(schedules []models.Schedule) int {
+	position := 0
+	for _, schedule := range schedules {
+		if schedule.Position >= position {
+			position = schedule.Position + 1
+		}
+	}
+	return position
+}
+
+func mapScheduleToDTO(schedule *models.Schedule) *dto.ScheduleResponse {
+	return &dto.ScheduleResponse{
+		ID:         schedule.ID,
+		SemesterID: schedule.SemesterID,
+		Name:       schedule.Name,
+		Position:   schedule.Position,
+		IsActive:   schedule.IsActive,
+		CreatedAt:  schedule.CreatedAt,
+		UpdatedAt:  schedule.UpdatedAt,
+	}
+}
+
+func mapScheduleShareToDTO(share *models.ScheduleShare) *dto.ScheduleShareResponse {
+	return &dto.ScheduleShareResponse{
+		ID:        share.ID,
+		Token:     share.Token,
+		ExpiresAt: share.ExpiresAt,
+		CreatedAt: share.CreatedAt,
+	}
+}
+
+func generateShareToken() (string, error) {
+	b := make([]byte, shareTokenByteLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
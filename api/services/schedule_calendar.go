@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/armanjr/termustat/api/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
+const icalUIDDomain = "termustat"
+
+// Calendar renders one of the user's schedules as an iCalendar file
+func (s *scheduleService) Calendar(userID, id uuid.UUID) ([]byte, error) {
+	schedule, err := findUserSchedule(s.repo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderCalendar(schedule)
+}
+
+// SharedCalendar renders a shared schedule for calendar apps subscribed to its
+// link, so they pick up later changes to the schedule
+func (s *scheduleService) SharedCalendar(token string) ([]byte, error) {
+	share, err := s.findShare(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderCalendar(&share.Schedule)
+}
+
+// renderCalendar turns class times into weekly events bounded by the class
+// dates of the university offering the courses, and exams into one-off events
+func (s *scheduleService) renderCalendar(schedule *models.Schedule) ([]byte, error) {
+	courses, err := s.userCourseService.GetUserCourses(schedule.UserID, schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(courses) == 0 {
+		return utils.NewICalendar(schedule.Name, s.location, time.Now()).Bytes(), nil
+	}
+
+	start, end, err := s.classDates(courses[0].UniversityID, schedule.SemesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := utils.NewICalendar(schedule.Name, s.location, start)
+	for _, course := range courses {
+		description := fmt.Sprintf("Professor: %s\nCode: %s", course.ProfessorName, course.Code)
+
+		for _, courseTime := range course.CourseTimes {
+			first, until, ok := sessionBounds(courseTime, start, end)
+			if !ok {
+				continue
+			}
+
+			rule := "FREQ=WEEKLY"
+			if courseTime.WeekParity != "" {
+				rule += ";INTERVAL=2"
+			}
+			rule += ";UNTIL=" + utils.ICalUntil(until)
+
+			calendar.AddEvent(utils.ICalEvent{
+				UID:         fmt.Sprintf("%s@%s", courseTime.ID, icalUIDDomain),
+				Summary:     course.Name,
+				Description: description,
+				Location:    courseTime.Location,
+				Start:       atClock(first, courseTime.StartTime),
+				End:         atClock(first, courseTime.EndTime),
+				RRule:       rule,
+			})
+		}
+
+		if !course.ExamStart.IsZero() {
+			calendar.AddEvent(utils.ICalEvent{
+				UID:         fmt.Sprintf("exam-%s@%s", course.ID, icalUIDDomain),
+				Summary:     "Exam: " + course.Name,
+				Description: description,
+				Start:       course.ExamStart,
+				End:         course.ExamEnd,
+			})
+		}
+	}
+
+	return calendar.Bytes(), nil
+}
+
+// classDates returns the start of the first day and the end of the last day of
+// classes in a university's semester
+func (s *scheduleService) classDates(universityID, semesterID uuid.UUID) (time.Time, time.Time, error) {
+	semester, err := s.calendarService.Get(universityID, semesterID)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return time.Time{}, time.Time{}, errors.Wrap(err, "failed to find university semester")
+	}
+	if semester == nil || semester.StartDate == "" || semester.EndDate == "" {
+		return time.Time{}, time.Time{}, errors.NewValidationError("semester without start and end dates")
+	}
+
+	start, err := time.ParseInLocation(time.DateOnly, semester.StartDate, s.location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid semester start date: %w", err)
+	}
+	end, err := time.ParseInLocation(time.DateOnly, semester.EndDate, s.location)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid semester end date: %w", err)
+	}
+	// The end date is the last day of classes
+	return start, end.AddDate(0, 0, 1).Add(-time.Second), nil
+}
+
+// sessionBounds returns the day of a session's first meeting and the end of
+// its last possible one. Half-term sessions take the first or second half of
+// the semester; odd and even week sessions meet every other week, counting
+// weeks from the Saturday the semester starts in. ok is false if the session
+// never meets.
+func sessionBounds(courseTime dto.CourseTimeResponse, start, end time.Time) (time.Time, time.Time, bool) {
+	from, until := start, end
+	if courseTime.HalfTerm != 0 {
+		middle := start.AddDate(0, 0, int(end.Sub(start).Hours()/24)/2)
+		if courseTime.HalfTerm == 1 {
+			until = middle.Add(-time.Second)
+		} else {
+			from = middle
+		}
+	}
+
+	// Golestan numbers days from Saturday
+	weekday := time.Weekday((courseTime.DayOfWeek + 6) % 7)
+	first := from.AddDate(0, 0, (int(weekday-from.Weekday())+7)%7)
+
+	if courseTime.WeekParity != "" {
+		firstWeek := start.AddDate(0, 0, -((int(start.Weekday()) + 1) % 7))
+		week := int(first.Sub(firstWeek).Hours()/24)/7 + 1
+		if (week%2 == 1) != (courseTime.WeekParity == "odd") {
+			first = first.AddDate(0, 0, 7)
+		}
+	}
+
+	return first, until, !first.After(until)
+}
+
+// atClock puts the clock time of t on day
+func atClock(day, t time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location())
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionBounds(t *testing.T) {
+	loc := tehran(t)
+	day := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, loc)
+	}
+	// A Tuesday to the Friday 108 days later; weeks count from Saturday 20 September
+	start := day(time.September, 23)
+	end := day(time.September, 23).AddDate(0, 0, 109).Add(-time.Second)
+
+	tests := []struct {
+		name    string
+		session dto.CourseTimeResponse
+		first   time.Time
+		until   time.Time
+		ok      bool
+	}{
+		{name: "weekly on the first day", session: session(3, "08:00", "10:00", "", 0), first: start, until: end, ok: true},
+		{name: "weekly later in the week", session: session(2, "08:00", "10:00", "", 0), first: day(time.September, 29), until: end, ok: true},
+		{name: "odd week in the first week", session: session(3, "08:00", "10:00", "odd", 0), first: start, until: end, ok: true},
+		{name: "even week after an odd first week", session: session(3, "08:00", "10:00", "even", 0), first: day(time.September, 30), until: end, ok: true},
+		{name: "even week on the Saturday of week two", session: session(0, "08:00", "10:00", "even", 0), first: day(time.September, 27), until: end, ok: true},
+		{name: "odd week skips the Saturday of week two", session: session(0, "08:00", "10:00", "odd", 0), first: day(time.October, 4), until: end, ok: true},
+		{
+			name:    "first half ends before the midpoint",
+			session: session(3, "08:00", "10:00", "", 1),
+			first:   start,
+			until:   day(time.November, 16).Add(-time.Second),
+			ok:      true,
+		},
+		{name: "second half starts at the midpoint", session: session(3, "08:00", "10:00", "", 2), first: day(time.November, 18), until: end, ok: true},
+		{
+			name:    "even week in the second half",
+			session: session(1, "08:00", "10:00", "even", 2),
+			first:   day(time.November, 23),
+			until:   end,
+			ok:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, until, ok := sessionBounds(tt.session, start, end)
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, tt.first.Equal(first), "first %s, want %s", first, tt.first)
+			assert.True(t, tt.until.Equal(until), "until %s, want %s", until, tt.until)
+		})
+	}
+
+	// A one-day semester has no Wednesday
+	_, _, ok := sessionBounds(session(4, "08:00", "10:00", "", 0), start, start.AddDate(0, 0, 1).Add(-time.Second))
+	assert.False(t, ok)
+}
+
+func TestSharedCalendar(t *testing.T) {
+	course := &models.Course{
+		ID:           uuid.New(),
+		UniversityID: uuid.New(),
+		Code:         "1211003_01",
+		Name:         "ریاضی عمومی 1",
+		Professor:    models.Professor{Name: "الیاسی نیره"},
+		CourseTimes: []models.CourseTime{
+			{
+				ID:        uuid.New(),
+				DayOfWeek: 3,
+				StartTime: time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+			},
+			{
+				ID:         uuid.New(),
+				DayOfWeek:  0,
+				StartTime:  time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+				EndTime:    time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC),
+				WeekParity: "odd",
+			},
+		},
+	}
+
+	s := sharedSchedule(t, "token", course)
+	calendars := new(MockUniversitySemesterService)
+	calendars.On("Get", course.UniversityID, mock.Anything).Return(&dto.UniversitySemesterResponse{StartDate: "2025-09-23", EndDate: "2026-01-09"}, nil)
+	s.calendarService = calendars
+
+	calendar, err := s.SharedCalendar("token")
+	require.NoError(t, err)
+	text := string(calendar)
+
+	assert.Contains(t, text, "DESCRIPTION:Professor: الیاسی نیره\\nCode: 1211003_01\r\n")
+	assert.Contains(t, text, "DTSTART;TZID=Asia/Tehran:20250923T080000\r\nDTEND;TZID=Asia/Tehran:20250923T100000\r\nRRULE:FREQ=WEEKLY;UNTIL=20260109T202959Z\r\n")
+	assert.Contains(t, text, "DTSTART;TZID=Asia/Tehran:20251004T100000\r\nDTEND;TZID=Asia/Tehran:20251004T120000\r\nRRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=20260109T202959Z\r\n")
+	assert.Equal(t, 2, strings.Count(text, "BEGIN:VEVENT"))
+}
+
+func TestSharedCalendarNeedsClassDates(t *testing.T) {
+	course := &models.Course{ID: uuid.New(), UniversityID: uuid.New(), Code: "1211003_01", Name: "ریاضی عمومی 1"}
+	calendars := new(MockUniversitySemesterService)
+	calendars.On("Get", course.UniversityID, mock.Anything).Return(nil, errors.NewNotFoundError("university semester", "calendar"))
+
+	s := sharedSchedule(t, "token", course)
+	s.calendarService = calendars
+	_, err := s.SharedCalendar("token")
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+
+	empty := sharedSchedule(t, "token")
+	empty.calendarService = calendars
+	calendar, err := empty.SharedCalendar("token")
+	require.NoError(t, err)
+	assert.NotContains(t, string(calendar), "BEGIN:VEVENT")
+}
@@ -0,0 +1,427 @@
+package services
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/armanjr/termustat/api/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type MockScheduleRepository struct {
+	repositories.ScheduleRepository
+	mock.Mock
+}
+
+func (m *MockScheduleRepository) Find(id uuid.UUID) (*models.Schedule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Schedule), args.Error(1)
+}
+
+type MockScheduleShareRepository struct {
+	repositories.ScheduleShareRepository
+	mock.Mock
+}
+
+func (m *MockScheduleShareRepository) Create(share *models.ScheduleShare) error {
+	return m.Called(share).Error(0)
+}
+
+func (m *MockScheduleShareRepository) FindByToken(token string) (*models.ScheduleShare, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ScheduleShare), args.Error(1)
+}
+
+type MockCourseRepository struct {
+	repositories.CourseRepository
+	mock.Mock
+}
+
+func (m *MockCourseRepository) Find(id uuid.UUID) (*models.Course, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Course), args.Error(1)
+}
+
+type MockUserCourseService struct {
+	UserCourseService
+	mock.Mock
+}
+
+func (m *MockUserCourseService) GetUserCourses(userID, scheduleID uuid.UUID) ([]dto.CourseResponse, error) {
+	args := m.Called(userID, scheduleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.CourseResponse), args.Error(1)
+}
+
+func (m *MockUserCourseService) ValidateSelectionWindow(userID, scheduleID uuid.UUID) error {
+	return m.Called(userID, scheduleID).Error(0)
+}
+
+func (m *MockUserCourseService) ValidateActivation(userID, scheduleID uuid.UUID) error {
+	return m.Called(userID, scheduleID).Error(0)
+}
+
+// scheduleStore keeps schedules in memory the way scheduleRepository keeps
+// them in the database: saving an active schedule deactivates the user's
+// other schedules of the semester
+type scheduleStore struct {
+	repositories.ScheduleRepository
+	schedules []*models.Schedule
+}
+
+func (r *scheduleStore) Find(id uuid.UUID) (*models.Schedule, error) {
+	for _, schedule := range r.schedules {
+		if schedule.ID == id {
+			found := *schedule
+			return &found, nil
+		}
+	}
+	return nil, errors.NewNotFoundError("schedule", id.String())
+}
+
+func (r *scheduleStore) FindByUserAndSemester(userID, semesterID uuid.UUID) ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	for _, schedule := range r.schedules {
+		if schedule.UserID == userID && schedule.SemesterID == semesterID {
+			schedules = append(schedules, *schedule)
+		}
+	}
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return schedules[i].Position < schedules[j].Position
+	})
+	return schedules, nil
+}
+
+func (r *scheduleStore) FindActive(userID, semesterID uuid.UUID) (*models.Schedule, error) {
+	for _, schedule := range r.schedules {
+		if schedule.UserID == userID && schedule.SemesterID == semesterID && schedule.IsActive {
+			found := *schedule
+			return &found, nil
+		}
+	}
+	return nil, errors.NewNotFoundError("active schedule", semesterID.String())
+}
+
+func (r *scheduleStore) Create(schedule *models.Schedule) error {
+	if schedule.ID == uuid.Nil {
+		schedule.ID = uuid.New()
+	}
+	r.deactivateOthers(schedule)
+	created := *schedule
+	r.schedules = append(r.schedules, &created)
+	return nil
+}
+
+func (r *scheduleStore) Update(schedule *models.Schedule) error {
+	r.deactivateOthers(schedule)
+	for i, stored := range r.schedules {
+		if stored.ID == schedule.ID {
+			updated := *schedule
+			r.schedules[i] = &updated
+			return nil
+		}
+	}
+	return errors.NewNotFoundError("schedule", schedule.ID.String())
+}
+
+func (r *scheduleStore) Delete(id uuid.UUID) error {
+	for i, schedule := range r.schedules {
+		if schedule.ID == id {
+			r.schedules = append(r.schedules[:i], r.schedules[i+1:]...)
+			return nil
+		}
+	}
+	return errors.NewNotFoundError("schedule", id.String())
+}
+
+func (r *scheduleStore) Clone(sourceID uuid.UUID, clone *models.Schedule) error {
+	if _, err := r.Find(sourceID); err != nil {
+		return err
+	}
+	return r.Create(clone)
+}
+
+func (r *scheduleStore) deactivateOthers(schedule *models.Schedule) {
+	if !schedule.IsActive {
+		return
+	}
+	for _, other := range r.schedules {
+		if other.UserID == schedule.UserID && other.SemesterID == schedule.SemesterID && other.ID != schedule.ID {
+			other.IsActive = false
+		}
+	}
+}
+
+func (r *scheduleStore) active() []string {
+	var names []string
+	for _, schedule := range r.schedules {
+		if schedule.IsActive {
+			names = append(names, schedule.Name)
+		}
+	}
+	return names
+}
+
+// scheduleFixture is a schedule service over the in-memory schedules of one
+// user and semester, the first of them active
+type scheduleFixture struct {
+	service     *scheduleService
+	store       *scheduleStore
+	shares      *MockScheduleShareRepository
+	userCourses *MockUserCourseService
+	userID      uuid.UUID
+	semesterID  uuid.UUID
+}
+
+func newScheduleFixture(names ...string) *scheduleFixture {
+	f := &scheduleFixture{
+		store:       &scheduleStore{},
+		shares:      new(MockScheduleShareRepository),
+		userCourses: new(MockUserCourseService),
+		userID:      uuid.New(),
+		semesterID:  uuid.New(),
+	}
+	for i, name := range names {
+		f.store.schedules = append(f.store.schedules, &models.Schedule{
+			ID:         uuid.New(),
+			UserID:     f.userID,
+			SemesterID: f.semesterID,
+			Name:       name,
+			Position:   i,
+			IsActive:   i == 0,
+		})
+	}
+
+	semesters := new(MockSemesterService)
+	semesters.On("Get", f.semesterID).Return(&dto.SemesterResponse{ID: f.semesterID}, nil)
+	f.userCourses.On("ValidateSelectionWindow", f.userID, mock.Anything).Return(nil)
+
+	f.service = &scheduleService{
+		repo:              f.store,
+		shareRepo:         f.shares,
+		userCourseService: f.userCourses,
+		semesterService:   semesters,
+		logger:            zap.NewNop(),
+	}
+	return f
+}
+
+func (f *scheduleFixture) id(name string) uuid.UUID {
+	for _, schedule := range f.store.schedules {
+		if schedule.Name == name {
+			return schedule.ID
+		}
+	}
+	return uuid.Nil
+}
+
+func TestUpdateActivatingDraftDeactivatesActive(t *testing.T) {
+	f := newScheduleFixture("Plan A", "Plan B")
+	f.userCourses.On("ValidateActivation", f.userID, f.id("Plan B")).Return(nil)
+
+	active := true
+	updated, err := f.service.Update(f.userID, f.id("Plan B"), &dto.UpdateScheduleRequest{IsActive: &active})
+	require.NoError(t, err)
+	assert.True(t, updated.IsActive)
+	assert.Equal(t, []string{"Plan B"}, f.store.active())
+	f.userCourses.AssertCalled(t, "ValidateActivation", f.userID, f.id("Plan B"))
+
+	inactive := false
+	_, err = f.service.Update(f.userID, f.id("Plan B"), &dto.UpdateScheduleRequest{IsActive: &inactive})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	assert.Equal(t, []string{"Plan B"}, f.store.active())
+}
+
+func TestUpdateRejectedActivationKeepsActive(t *testing.T) {
+	f := newScheduleFixture("Plan A", "Plan B")
+	f.userCourses.On("ValidateActivation", f.userID, f.id("Plan B")).
+		Return(errors.NewConflictError("activating the schedule with ریاضی عمومی 1"))
+
+	active := true
+	_, err := f.service.Update(f.userID, f.id("Plan B"), &dto.UpdateScheduleRequest{IsActive: &active})
+	assert.ErrorIs(t, err, errors.ErrConflict)
+	assert.Equal(t, []string{"Plan A"}, f.store.active())
+}
+
+func TestDeleteActiveScheduleActivatesNext(t *testing.T) {
+	f := newScheduleFixture("Plan A", "Plan B", "Plan C")
+
+	require.NoError(t, f.service.Delete(f.userID, f.id("Plan C")))
+	assert.Equal(t, []string{"Plan A"}, f.store.active())
+
+	require.NoError(t, f.service.Delete(f.userID, f.id("Plan A")))
+	assert.Equal(t, []string{"Plan B"}, f.store.active())
+
+	require.NoError(t, f.service.Delete(f.userID, f.id("Plan B")))
+	assert.Empty(t, f.store.schedules)
+}
+
+func TestCloneIsNeverActive(t *testing.T) {
+	f := newScheduleFixture("Plan A")
+	f.userCourses.On("GetUserCourses", f.userID, mock.Anything).Return([]dto.CourseResponse{}, nil)
+
+	clone, err := f.service.Clone(f.userID, f.id("Plan A"), &dto.CloneScheduleRequest{Name: "Plan B"})
+	require.NoError(t, err)
+	assert.False(t, clone.IsActive)
+	assert.Equal(t, 1, clone.Position)
+	assert.Equal(t, []string{"Plan A"}, f.store.active())
+
+	_, err = f.service.Clone(f.userID, f.id("Plan A"), &dto.CloneScheduleRequest{Name: "Plan B"})
+	assert.ErrorIs(t, err, errors.ErrConflict)
+}
+
+func TestDiffMatchesCoursesByCatalogCourse(t *testing.T) {
+	f := newScheduleFixture("Plan A", "Plan B")
+
+	course := func(weight int) dto.CourseResponse {
+		return dto.CourseResponse{ID: uuid.New(), CatalogCourseID: uuid.New(), Weight: weight}
+	}
+	physics := course(3)
+	math := course(3)
+	otherMath := dto.CourseResponse{ID: uuid.New(), CatalogCourseID: math.CatalogCourseID, Weight: 3}
+	literature := course(2)
+	chemistry := course(1)
+
+	f.userCourses.On("GetUserCourses", f.userID, f.id("Plan A")).Return([]dto.CourseResponse{physics, math, literature}, nil)
+	f.userCourses.On("GetUserCourses", f.userID, f.id("Plan B")).Return([]dto.CourseResponse{otherMath, physics, chemistry}, nil)
+
+	diff, err := f.service.Diff(f.userID, f.id("Plan A"), f.id("Plan B"))
+	require.NoError(t, err)
+	assert.Equal(t, []dto.CourseResponse{physics}, diff.Common)
+	assert.Equal(t, []dto.SectionChange{{CatalogCourseID: math.CatalogCourseID, From: math, To: otherMath}}, diff.SectionChanges)
+	assert.Equal(t, []dto.CourseResponse{literature}, diff.Removed)
+	assert.Equal(t, []dto.CourseResponse{chemistry}, diff.Added)
+	assert.Equal(t, 8, diff.Weight)
+	assert.Equal(t, 7, diff.OtherWeight)
+}
+
+func TestGetActiveCreatesDefaultSchedule(t *testing.T) {
+	f := newScheduleFixture()
+
+	active, err := f.service.GetActive(f.userID, f.semesterID)
+	require.NoError(t, err)
+	assert.Equal(t, defaultScheduleName, active.Name)
+	assert.True(t, active.IsActive)
+	require.Len(t, f.store.schedules, 1)
+
+	again, err := f.service.GetActive(f.userID, f.semesterID)
+	require.NoError(t, err)
+	assert.Equal(t, active.ID, again.ID)
+}
+
+func TestShareChecksOwnerAndExpiry(t *testing.T) {
+	f := newScheduleFixture("Plan A")
+	f.shares.On("Create", mock.Anything).Return(nil)
+
+	past := time.Now().Add(-time.Hour)
+	_, err := f.service.Share(f.userID, f.id("Plan A"), &dto.CreateScheduleShareRequest{ExpiresAt: &past})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = f.service.Share(uuid.New(), f.id("Plan A"), &dto.CreateScheduleShareRequest{})
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+	f.shares.AssertNotCalled(t, "Create", mock.Anything)
+
+	share, err := f.service.Share(f.userID, f.id("Plan A"), &dto.CreateScheduleShareRequest{})
+	require.NoError(t, err)
+	assert.Len(t, share.Token, 43)
+	assert.Nil(t, share.ExpiresAt)
+}
+
+func TestGetSharedRefusesExpiredLinks(t *testing.T) {
+	f := newScheduleFixture("Plan A")
+	schedule := *f.store.schedules[0]
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	course := dto.CourseResponse{ID: uuid.New(), Name: "ریاضی عمومی 1"}
+
+	f.shares.On("FindByToken", "expired").
+		Return(&models.ScheduleShare{ScheduleID: schedule.ID, Schedule: schedule, Token: "expired", ExpiresAt: &past}, nil)
+	f.shares.On("FindByToken", "valid").
+		Return(&models.ScheduleShare{ScheduleID: schedule.ID, Schedule: schedule, Token: "valid", ExpiresAt: &future}, nil)
+	f.shares.On("FindByToken", "revoked").Return(nil, errors.NewNotFoundError("shared schedule", ""))
+	f.userCourses.On("GetUserCourses", f.userID, schedule.ID).Return([]dto.CourseResponse{course}, nil)
+
+	_, err := f.service.GetShared("expired")
+	assert.ErrorIs(t, err, errors.ErrExpiredToken)
+	_, err = f.service.GetShared("revoked")
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+
+	shared, err := f.service.GetShared("valid")
+	require.NoError(t, err)
+	assert.Equal(t, "Plan A", shared.Name)
+	assert.Equal(t, schedule.SemesterID, shared.SemesterID)
+	assert.Equal(t, []dto.CourseResponse{course}, shared.Courses)
+}
+
+// sharedSchedule wires the schedule, user course and course services over
+// mocked repositories holding one shared schedule of courses
+func sharedSchedule(t *testing.T, token string, courses ...*models.Course) *scheduleService {
+	loc := tehran(t)
+	schedule := &models.Schedule{ID: uuid.New(), UserID: uuid.New(), SemesterID: uuid.New(), Name: "Plan A"}
+
+	schedules := new(MockScheduleRepository)
+	schedules.On("Find", schedule.ID).Return(schedule, nil)
+	shares := new(MockScheduleShareRepository)
+	shares.On("FindByToken", token).Return(&models.ScheduleShare{ScheduleID: schedule.ID, Schedule: *schedule, Token: token}, nil)
+
+	courseRepo := new(MockCourseRepository)
+	var selected []models.UserCourse
+	for _, course := range courses {
+		courseRepo.On("Find", course.ID).Return(course, nil)
+		selected = append(selected, models.UserCourse{CourseID: course.ID, ScheduleID: schedule.ID})
+	}
+	userCourses := new(MockUserCourseRepository)
+	userCourses.On("FindBySchedule", schedule.ID).Return(selected, nil)
+
+	courseService := &courseService{courseRepo: courseRepo, location: loc, logger: zap.NewNop()}
+	return &scheduleService{
+		repo:      schedules,
+		shareRepo: shares,
+		userCourseService: &userCourseService{
+			userCourseRepo: userCourses,
+			scheduleRepo:   schedules,
+			courseService:  courseService,
+			logger:         zap.NewNop(),
+		},
+		courseService: courseService,
+		location:      loc,
+		logger:        zap.NewNop(),
+	}
+}
+
+func TestGetSharedNamesProfessors(t *testing.T) {
+	course := &models.Course{
+		ID:          uuid.New(),
+		Code:        "1211003_01",
+		Name:        "ریاضی عمومی 1",
+		ProfessorID: uuid.New(),
+		Professor:   models.Professor{Name: "الیاسی نیره"},
+		CourseTimes: []models.CourseTime{{
+			DayOfWeek: 1,
+			StartTime: time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	shared, err := sharedSchedule(t, "token", course).GetShared("token")
+	require.NoError(t, err)
+	require.Len(t, shared.Courses, 1)
+	assert.Equal(t, "الیاسی نیره", shared.Courses[0].ProfessorName)
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/infrastructure/timetable"
+	"github.com/armanjr/termustat/api/utils"
+	"github.com/google/uuid"
+)
+
+// Timetable renders one of the user's schedules as a printable weekly
+// timetable in PDF or PNG
+func (s *scheduleService) Timetable(userID, id uuid.UUID, format string) ([]byte, error) {
+	f := timetable.Format(format)
+	if f != timetable.FormatPDF && f != timetable.FormatPNG {
+		return nil, errors.NewValidationError("format")
+	}
+
+	schedule, err := findUserSchedule(s.repo, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	semester, err := s.semesterService.Get(schedule.SemesterID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find semester")
+	}
+
+	courses, err := s.userCourseService.GetUserCourses(userID, schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.renderer.Render(&timetable.Timetable{
+		Title:    schedule.Name,
+		Subtitle: utils.PersianDigits(semester.Label),
+		Courses:  courses,
+	}, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render timetable")
+	}
+
+	return data, nil
+}
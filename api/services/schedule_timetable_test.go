@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/infrastructure/timetable"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockRenderer struct {
+	mock.Mock
+}
+
+func (m *MockRenderer) Render(t *timetable.Timetable, format timetable.Format) ([]byte, error) {
+	args := m.Called(t, format)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestTimetableTitlesSchedule(t *testing.T) {
+	course := &models.Course{
+		ID:        uuid.New(),
+		Code:      "1211003_01",
+		Name:      "ریاضی عمومی 1",
+		Professor: models.Professor{Name: "الیاسی نیره"},
+		CourseTimes: []models.CourseTime{{
+			DayOfWeek: 1,
+			StartTime: time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(0, 1, 1, 12, 0, 0, 0, time.UTC),
+		}},
+		ExamStart: time.Date(2025, 6, 28, 4, 30, 0, 0, time.UTC),
+		ExamEnd:   time.Date(2025, 6, 28, 6, 30, 0, 0, time.UTC),
+	}
+
+	s := sharedSchedule(t, "token", course)
+	share, err := s.shareRepo.FindByToken("token")
+	require.NoError(t, err)
+
+	semesters := new(MockSemesterService)
+	semesters.On("Get", share.Schedule.SemesterID).Return(&dto.SemesterResponse{Label: "نیمسال اول 1404-1405"}, nil)
+	s.semesterService = semesters
+	renderer := new(MockRenderer)
+	renderer.On("Render", mock.Anything, timetable.FormatPNG).Return([]byte("png"), nil)
+	s.renderer = renderer
+
+	_, err = s.Timetable(share.Schedule.UserID, share.ScheduleID, "svg")
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+
+	data, err := s.Timetable(share.Schedule.UserID, share.ScheduleID, "png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("png"), data)
+
+	renderer.AssertNumberOfCalls(t, "Render", 1)
+	rendered := renderer.Calls[0].Arguments.Get(0).(*timetable.Timetable)
+	assert.Equal(t, "Plan A", rendered.Title)
+	assert.Equal(t, "نیمسال اول ۱۴۰۴-۱۴۰۵", rendered.Subtitle)
+	require.Len(t, rendered.Courses, 1)
+	assert.Equal(t, "1211003_01", rendered.Courses[0].Code)
+	assert.Equal(t, "الیاسی نیره", rendered.Courses[0].ProfessorName)
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"fmt"
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/repositories"
+	"github.com/armanjr/termustat/api/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const defaultSearchLimit = 20
+
+// SearchService finds courses and professors by name or code, tolerating
+// Arabic letter variants, ZWNJ, spacing, misspelled homophones and Finglish
+type SearchService interface {
+	Search(req *dto.SearchRequest) (*dto.SearchResponse, error)
+}
+
+type searchService struct {
+	repo           repositories.SearchRepository
+	facultyService FacultyService
+	location       *time.Location
+	logger         *zap.Logger
+}
+
+func NewSearchService(
+	repo repositories.SearchRepository,
+	facultyService FacultyService,
+	location *time.Location,
+	logger *zap.Logger,
+) SearchService {
+	return &searchService{
+		repo:           repo,
+		facultyService: facultyService,
+		location:       location,
+		logger:         logger,
+	}
+}
+
+func (s *searchService) Search(req *dto.SearchRequest) (*dto.SearchResponse, error) {
+	if req.UniversityID == uuid.Nil {
+		return nil, errors.NewValidationError("university_id")
+	}
+
+	query := buildTextQuery(req.Query)
+	if len(query.Terms) == 0 && len(query.Keys) == 0 {
+		return nil, errors.NewValidationError("q")
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	courses, err := s.repo.SearchCourses(req.UniversityID, req.SemesterID, query, limit)
+	if err != nil {
+		s.logger.Error("Failed to search courses",
+			zap.String("query", req.Query),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to search courses: %w", err)
+	}
+
+	professors, err := s.repo.SearchProfessors(req.UniversityID, query, limit)
+	if err != nil {
+		s.logger.Error("Failed to search professors",
+			zap.String("query", req.Query),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to search professors: %w", err)
+	}
+
+	faculties, err := facultiesByID(s.facultyService, req.UniversityID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.SearchResponse{
+		Query:      req.Query,
+		Courses:    make([]dto.CourseSearchResult, len(courses)),
+		Professors: make([]dto.ProfessorSearchResult, len(professors)),
+	}
+	for i := range courses {
+		response.Courses[i] = dto.CourseSearchResult{
+			Course: *mapListedCourse(&courses[i].Course, faculties, s.location),
+			Score:  courses[i].Score,
+		}
+	}
+	for i := range professors {
+		response.Professors[i] = dto.ProfessorSearchResult{
+			Professor: mapProfessorToListDTO(&professors[i].Professor),
+			Score:     professors[i].Score,
+		}
+	}
+
+	return response, nil
+}
+
+// buildTextQuery prepares raw input for the search indexes. Latin input is
+// searched both as typed, for codes and English names, and as Finglish.
+func buildTextQuery(text string) *repositories.TextQuery {
+	query := &repositories.TextQuery{
+		Terms: utils.SearchTerms(text),
+		Code:  strings.TrimSpace(text),
+	}
+
+	keys := []string{utils.SearchKey(text)}
+	if utils.HasLatin(text) {
+		keys = append(keys, utils.FinglishKey(text))
+	}
+	for _, key := range keys {
+		// shorter keys match nearly everything
+		if utf8.RuneCountInString(key) >= 2 {
+			query.Keys = append(query.Keys, key)
+		}
+	}
+
+	return query
+}
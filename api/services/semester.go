@@ -6,6 +6,7 @@ import (
 	"github.com/armanjr/termustat/api/errors"
 	"github.com/armanjr/termustat/api/models"
 	"github.com/armanjr/termustat/api/repositories"
+	"github.com/armanjr/termustat/engine/schema"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -14,6 +15,7 @@ type SemesterService interface {
 	Create(req *dto.CreateSemesterRequest) (*dto.SemesterResponse, error)
 	Get(id uuid.UUID) (*dto.SemesterResponse, error)
 	GetAll() ([]dto.SemesterResponse, error)
+	GetByCode(code string) (*dto.SemesterResponse, error)
 	Update(id uuid.UUID, req *dto.UpdateSemesterRequest) (*dto.SemesterResponse, error)
 	Delete(id uuid.UUID) error
 }
@@ -32,7 +34,7 @@ func NewSemesterService(repo repositories.SemesterRepository, logger *zap.Logger
 
 func (s *semesterService) Create(req *dto.CreateSemesterRequest) (*dto.SemesterResponse, error) {
 	if !isValidTerm(req.Term) {
-		return nil, errors.NewValidationError("term must be either 'spring' or 'fall'")
+		return nil, errors.NewValidationError("term must be 'fall', 'spring' or 'summer'")
 	}
 
 	if !isValidYear(req.Year) {
@@ -98,13 +100,34 @@ func (s *semesterService) GetAll() ([]dto.SemesterResponse, error) {
 	return response, nil
 }
 
+// GetByCode finds a semester by its Golestan term code, such as 4031
+func (s *semesterService) GetByCode(code string) (*dto.SemesterResponse, error) {
+	year, term, err := schema.ParseTermCode(code)
+	if err != nil {
+		return nil, errors.NewValidationError("term code")
+	}
+
+	semester, err := s.repo.FindByYearAndTerm(year, term)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, err
+		}
+		s.logger.Error("Failed to fetch semester by code",
+			zap.String("code", code),
+			zap.Error(err))
+		return nil, fmt.Errorf("failed to get semester: %w", err)
+	}
+
+	return mapSemesterToDTO(semester), nil
+}
+
 func (s *semesterService) Update(id uuid.UUID, req *dto.UpdateSemesterRequest) (*dto.SemesterResponse, error) {
 	if !isValidTerm(req.Term) {
-		return nil, errors.NewValidationError("term must be either 'spring' or 'fall'")
+		return nil, errors.NewValidationError("term must be 'fall', 'spring' or 'summer'")
 	}
 
 	if !isValidYear(req.Year) {
-		return nil, errors.NewValidationError("year must be between 1900 and 2200")
+		return nil, errors.NewValidationError("year must be between 1000 and 3000")
 	}
 
 	existing, err := s.repo.Find(id)
@@ -161,19 +184,33 @@ func (s *semesterService) Delete(id uuid.UUID) error {
 	return nil
 }
 
+var termLabels = map[string]string{
+	"fall":   "نیمسال اول",
+	"spring": "نیمسال دوم",
+	"summer": "ترم تابستان",
+}
+
 func isValidTerm(term string) bool {
-	return term == "spring" || term == "fall"
+	return schema.TermNumber(term) != 0
 }
 
 func isValidYear(year int) bool {
 	return year >= 1000 && year <= 3000
 }
 
+// semesterLabel names a semester by its Jalali academic year, e.g.
+// "1403-1404 نیمسال اول"
+func semesterLabel(year int, term string) string {
+	return fmt.Sprintf("%d-%d %s", year, year+1, termLabels[term])
+}
+
 func mapSemesterToDTO(semester *models.Semester) *dto.SemesterResponse {
 	return &dto.SemesterResponse{
 		ID:        semester.ID,
 		Year:      semester.Year,
 		Term:      semester.Term,
+		Code:      schema.TermCode(semester.Year, semester.Term),
+		Label:     semesterLabel(semester.Year, semester.Term),
 		CreatedAt: semester.CreatedAt,
 		UpdatedAt: semester.UpdatedAt,
 	}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/armanjr/termustat/api/repositories"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+// UniversitySemesterService manages each university's semester calendar: its
+// selection windows, exam period and which semester is current
+type UniversitySemesterService interface {
+	Set(universityID, semesterID uuid.UUID, req *dto.SetUniversitySemesterRequest) (*dto.UniversitySemesterResponse, error)
+	Get(universityID, semesterID uuid.UUID) (*dto.UniversitySemesterResponse, error)
+	GetAll(universityID uuid.UUID) ([]dto.UniversitySemesterResponse, error)
+	Delete(universityID, semesterID uuid.UUID) error
+	GetCurrent(universityID uuid.UUID) (*dto.UniversitySemesterResponse, error)
+	GetCurrentForUser(userID uuid.UUID) (*dto.UniversitySemesterResponse, error)
+	CheckSelectionWindow(universityID, semesterID uuid.UUID) error
+}
+
+type universitySemesterService struct {
+	repo              repositories.UniversitySemesterRepository
+	universityService UniversityService
+	semesterService   SemesterService
+	userService       AdminUserService
+	logger            *zap.Logger
+}
+
+func NewUniversitySemesterService(
+	repo repositories.UniversitySemesterRepository,
+	universityService UniversityService,
+	semesterService SemesterService,
+	userService AdminUserService,
+	logger *zap.Logger,
+) UniversitySemesterService {
+	return &universitySemesterService{
+		repo:              repo,
+		universityService: universityService,
+		semesterService:   semesterService,
+		userService:       userService,
+		logger:            logger,
+	}
+}
+
+func (s *universitySemesterService) Set(universityID, semesterID uuid.UUID, req *dto.SetUniversitySemesterRequest) (*dto.UniversitySemesterResponse, error) {
+	ctx := context.Background() // todo: remove and pass request context
+	if _, err := s.universityService.Get(ctx, universityID); err != nil {
+		return nil, errors.Wrap(err, "failed to find university")
+	}
+	if _, err := s.semesterService.Get(semesterID); err != nil {
+		return nil, errors.Wrap(err, "failed to find semester")
+	}
+
+	if err := checkWindow(req.RegistrationStart, req.RegistrationEnd, "registration"); err != nil {
+		return nil, err
+	}
+	if err := checkWindow(req.AddDropStart, req.AddDropEnd, "add/drop"); err != nil {
+		return nil, err
+	}
+
+	startDate, endDate, err := parseSemesterDates(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	examStart, examEnd, err := parseExamPeriod(req.ExamStart, req.ExamEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &models.UniversitySemester{
+		UniversityID:      universityID,
+		SemesterID:        semesterID,
+		StartDate:         startDate,
+		EndDate:           endDate,
+		RegistrationStart: req.RegistrationStart,
+		RegistrationEnd:   req.RegistrationEnd,
+		AddDropStart:      req.AddDropStart,
+		AddDropEnd:        req.AddDropEnd,
+		ExamStart:         examStart,
+		ExamEnd:           examEnd,
+		IsCurrent:         req.IsCurrent,
+	}
+	if err := s.repo.Save(calendar); err != nil {
+		s.logger.Error("Failed to save university semester",
+			zap.String("university_id", universityID.String()),
+			zap.String("semester_id", semesterID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	saved, err := s.repo.Find(universityID, semesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapUniversitySemesterToDTO(saved, time.Now()), nil
+}
+
+func (s *universitySemesterService) Get(universityID, semesterID uuid.UUID) (*dto.UniversitySemesterResponse, error) {
+	calendar, err := s.repo.Find(universityID, semesterID)
+	if err != nil {
+		return nil, err
+	}
+	return mapUniversitySemesterToDTO(calendar, time.Now()), nil
+}
+
+func (s *universitySemesterService) GetAll(universityID uuid.UUID) ([]dto.UniversitySemesterResponse, error) {
+	calendars, err := s.repo.FindByUniversity(universityID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	response := make([]dto.UniversitySemesterResponse, len(calendars))
+	for i := range calendars {
+		response[i] = *mapUniversitySemesterToDTO(&calendars[i], now)
+	}
+	return response, nil
+}
+
+func (s *universitySemesterService) Delete(universityID, semesterID uuid.UUID) error {
+	return s.repo.Delete(universityID, semesterID)
+}
+
+func (s *universitySemesterService) GetCurrent(universityID uuid.UUID) (*dto.UniversitySemesterResponse, error) {
+	calendar, err := s.repo.FindCurrent(universityID)
+	if err != nil {
+		return nil, err
+	}
+	return mapUniversitySemesterToDTO(calendar, time.Now()), nil
+}
+
+// GetCurrentForUser returns the current semester of the user's university
+func (s *universitySemesterService) GetCurrentForUser(userID uuid.UUID) (*dto.UniversitySemesterResponse, error) {
+	ctx := context.Background() // todo: remove and pass request context
+	user, err := s.userService.Get(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch user")
+	}
+
+	return s.GetCurrent(user.UniversityID)
+}
+
+// CheckSelectionWindow rejects course selection changes outside the
+// registration and add/drop windows. Semesters without a calendar or without
+// windows are always open.
+func (s *universitySemesterService) CheckSelectionWindow(universityID, semesterID uuid.UUID) error {
+	calendar, err := s.repo.Find(universityID, semesterID)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if !selectionOpen(calendar, time.Now()) {
+		return errors.NewForbiddenError("course selection outside the registration and add/drop windows")
+	}
+	return nil
+}
+
+func selectionOpen(calendar *models.UniversitySemester, now time.Time) bool {
+	if calendar.RegistrationStart == nil && calendar.AddDropStart == nil {
+		return true
+	}
+	return withinWindow(calendar.RegistrationStart, calendar.RegistrationEnd, now) ||
+		withinWindow(calendar.AddDropStart, calendar.AddDropEnd, now)
+}
+
+func withinWindow(start, end *time.Time, now time.Time) bool {
+	return start != nil && end != nil && !now.Before(*start) && now.Before(*end)
+}
+
+// checkWindow requires both ends of an optional window, in order
+func checkWindow(start, end *time.Time, name string) error {
+	if start == nil && end == nil {
+		return nil
+	}
+	if start == nil || end == nil {
+		return errors.NewValidationError(name + " window without both start and end")
+	}
+	if !end.After(*start) {
+		return errors.NewValidationError(name + " window ending before it starts")
+	}
+	return nil
+}
+
+// parseSemesterDates reads optional YYYY-MM-DD start and end dates, which
+// must be given together
+func parseSemesterDates(start, end string) (*time.Time, *time.Time, error) {
+	if start == "" && end == "" {
+		return nil, nil, nil
+	}
+	if start == "" || end == "" {
+		return nil, nil, errors.NewValidationError("start_date and end_date must be given together")
+	}
+
+	startDate, err := time.Parse(time.DateOnly, start)
+	if err != nil {
+		return nil, nil, errors.NewValidationError("start_date")
+	}
+	endDate, err := time.Parse(time.DateOnly, end)
+	if err != nil {
+		return nil, nil, errors.NewValidationError("end_date")
+	}
+	if !endDate.After(startDate) {
+		return nil, nil, errors.NewValidationError("end_date must be after start_date")
+	}
+
+	return &startDate, &endDate, nil
+}
+
+// parseExamPeriod reads optional YYYY-MM-DD exam period dates, which may fall
+// on the same day
+func parseExamPeriod(start, end string) (*time.Time, *time.Time, error) {
+	if start == "" && end == "" {
+		return nil, nil, nil
+	}
+	if start == "" || end == "" {
+		return nil, nil, errors.NewValidationError("exam_start and exam_end must be given together")
+	}
+
+	startDate, err := time.Parse(time.DateOnly, start)
+	if err != nil {
+		return nil, nil, errors.NewValidationError("exam_start")
+	}
+	endDate, err := time.Parse(time.DateOnly, end)
+	if err != nil {
+		return nil, nil, errors.NewValidationError("exam_end")
+	}
+	if endDate.Before(startDate) {
+		return nil, nil, errors.NewValidationError("exam_end must not be before exam_start")
+	}
+
+	return &startDate, &endDate, nil
+}
+
+func mapUniversitySemesterToDTO(calendar *models.UniversitySemester, now time.Time) *dto.UniversitySemesterResponse {
+	response := &dto.UniversitySemesterResponse{
+		UniversityID:      calendar.UniversityID,
+		Semester:          *mapSemesterToDTO(&calendar.Semester),
+		RegistrationStart: calendar.RegistrationStart,
+		RegistrationEnd:   calendar.RegistrationEnd,
+		AddDropStart:      calendar.AddDropStart,
+		AddDropEnd:        calendar.AddDropEnd,
+		IsCurrent:         calendar.IsCurrent,
+		SelectionOpen:     selectionOpen(calendar, now),
+		UpdatedAt:         calendar.UpdatedAt,
+	}
+	if calendar.StartDate != nil && calendar.EndDate != nil {
+		response.StartDate = calendar.StartDate.Format(time.DateOnly)
+		response.EndDate = calendar.EndDate.Format(time.DateOnly)
+	}
+	if calendar.ExamStart != nil && calendar.ExamEnd != nil {
+		response.ExamStart = calendar.ExamStart.Format(time.DateOnly)
+		response.ExamEnd = calendar.ExamEnd.Format(time.DateOnly)
+	}
+	return response
+}
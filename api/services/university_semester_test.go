@@ -0,0 +1,198 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/armanjr/termustat/api/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type MockUniversitySemesterRepository struct {
+	repositories.UniversitySemesterRepository
+	mock.Mock
+}
+
+func (m *MockUniversitySemesterRepository) Find(universityID, semesterID uuid.UUID) (*models.UniversitySemester, error) {
+	args := m.Called(universityID, semesterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UniversitySemester), args.Error(1)
+}
+
+type MockUniversitySemesterService struct {
+	UniversitySemesterService
+	mock.Mock
+}
+
+func (m *MockUniversitySemesterService) Get(universityID, semesterID uuid.UUID) (*dto.UniversitySemesterResponse, error) {
+	args := m.Called(universityID, semesterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UniversitySemesterResponse), args.Error(1)
+}
+
+func (m *MockUniversitySemesterService) CheckSelectionWindow(universityID, semesterID uuid.UUID) error {
+	return m.Called(universityID, semesterID).Error(0)
+}
+
+func TestWithinWindow(t *testing.T) {
+	start := time.Date(2025, 9, 6, 8, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 9, 10, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		start *time.Time
+		end   *time.Time
+		now   time.Time
+		want  bool
+	}{
+		{name: "before the start", start: &start, end: &end, now: start.Add(-time.Nanosecond), want: false},
+		{name: "at the start", start: &start, end: &end, now: start, want: true},
+		{name: "inside", start: &start, end: &end, now: start.Add(48 * time.Hour), want: true},
+		{name: "just before the end", start: &start, end: &end, now: end.Add(-time.Nanosecond), want: true},
+		{name: "at the end", start: &start, end: &end, now: end, want: false},
+		{name: "no window", now: start, want: false},
+		{name: "start only", start: &start, now: start, want: false},
+		{name: "end only", end: &end, now: start, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, withinWindow(tt.start, tt.end, tt.now))
+		})
+	}
+}
+
+func TestSelectionOpen(t *testing.T) {
+	at := func(day int) *time.Time {
+		t := time.Date(2025, 9, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	registration := &models.UniversitySemester{RegistrationStart: at(6), RegistrationEnd: at(10)}
+	addDrop := &models.UniversitySemester{AddDropStart: at(20), AddDropEnd: at(24)}
+	both := &models.UniversitySemester{
+		RegistrationStart: at(6),
+		RegistrationEnd:   at(10),
+		AddDropStart:      at(20),
+		AddDropEnd:        at(24),
+	}
+
+	tests := []struct {
+		name     string
+		calendar *models.UniversitySemester
+		now      time.Time
+		want     bool
+	}{
+		{name: "no windows", calendar: &models.UniversitySemester{}, now: *at(1), want: true},
+		{name: "registration only, at its start", calendar: registration, now: *at(6), want: true},
+		{name: "registration only, at its end", calendar: registration, now: *at(10), want: false},
+		{name: "registration only, in the add/drop period", calendar: registration, now: *at(21), want: false},
+		{name: "add/drop only, at its start", calendar: addDrop, now: *at(20), want: true},
+		{name: "add/drop only, before it", calendar: addDrop, now: *at(7), want: false},
+		{name: "add/drop only, at its end", calendar: addDrop, now: *at(24), want: false},
+		{name: "both, during registration", calendar: both, now: *at(8), want: true},
+		{name: "both, between the windows", calendar: both, now: *at(15), want: false},
+		{name: "both, during add/drop", calendar: both, now: *at(23), want: true},
+		{name: "both, after add/drop", calendar: both, now: *at(25), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectionOpen(tt.calendar, tt.now))
+		})
+	}
+}
+
+func TestClassDates(t *testing.T) {
+	tests := []struct {
+		name    string
+		start   string
+		end     string
+		wantErr bool
+	}{
+		{name: "unset"},
+		{name: "set", start: "2025-09-23", end: "2026-01-09"},
+		{name: "start only", start: "2025-09-23", wantErr: true},
+		{name: "end only", end: "2026-01-09", wantErr: true},
+		{name: "same day", start: "2025-09-23", end: "2025-09-23", wantErr: true},
+		{name: "jalali", start: "1404/07/01", end: "1404/10/19", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := parseSemesterDates(tt.start, tt.end)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errors.ErrInvalid)
+				return
+			}
+			require.NoError(t, err)
+
+			response := mapUniversitySemesterToDTO(&models.UniversitySemester{StartDate: start, EndDate: end}, time.Now())
+			assert.Equal(t, tt.start, response.StartDate)
+			assert.Equal(t, tt.end, response.EndDate)
+		})
+	}
+}
+
+func TestCheckSelectionWindow(t *testing.T) {
+	universityID, semesterID := uuid.New(), uuid.New()
+	past := time.Now().Add(-48 * time.Hour)
+	yesterday := time.Now().Add(-24 * time.Hour)
+	tomorrow := time.Now().Add(24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		calendar *models.UniversitySemester
+		err      error
+		wantErr  error
+	}{
+		{name: "no calendar", err: errors.ErrNotFound},
+		{name: "calendar without windows", calendar: &models.UniversitySemester{}},
+		{name: "open window", calendar: &models.UniversitySemester{RegistrationStart: &yesterday, RegistrationEnd: &tomorrow}},
+		{name: "closed window", calendar: &models.UniversitySemester{RegistrationStart: &past, RegistrationEnd: &yesterday}, wantErr: errors.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockUniversitySemesterRepository)
+			if tt.calendar != nil {
+				repo.On("Find", universityID, semesterID).Return(tt.calendar, nil)
+			} else {
+				repo.On("Find", universityID, semesterID).Return(nil, tt.err)
+			}
+			s := &universitySemesterService{repo: repo, logger: zap.NewNop()}
+
+			err := s.CheckSelectionWindow(universityID, semesterID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelectionWindowOnlyForActiveSchedule(t *testing.T) {
+	universityID := uuid.New()
+	active := &models.Schedule{ID: uuid.New(), SemesterID: uuid.New(), IsActive: true}
+	draft := &models.Schedule{ID: uuid.New(), SemesterID: active.SemesterID}
+
+	calendar := new(MockUniversitySemesterService)
+	calendar.On("CheckSelectionWindow", universityID, active.SemesterID).
+		Return(errors.NewForbiddenError("course selection outside the registration and add/drop windows"))
+	s := &userCourseService{calendarService: calendar, logger: zap.NewNop()}
+
+	require.NoError(t, s.checkSelectionWindow(draft, universityID))
+	assert.ErrorIs(t, s.checkSelectionWindow(active, universityID), errors.ErrForbidden)
+	calendar.AssertNumberOfCalls(t, "CheckSelectionWindow", 1)
+}
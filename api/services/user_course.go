@@ -9,99 +9,163 @@ import (
 	"github.com/armanjr/termustat/api/repositories"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"sort"
+	"strings"
 )
 
 type UserCourseService interface {
-	AddCourse(userID, courseID, semesterID uuid.UUID) error
-	RemoveCourse(userID, courseID uuid.UUID) error
-	GetUserCourses(userID uuid.UUID, semesterID uuid.UUID) ([]dto.CourseResponse, error)
-	ValidateTimeConflicts(userID, semesterID uuid.UUID, courseID uuid.UUID) error
+	AddCourse(userID, scheduleID, courseID uuid.UUID) ([]dto.ExamConflict, error)
+	RemoveCourse(userID, scheduleID, courseID uuid.UUID) error
+	GetUserCourses(userID, scheduleID uuid.UUID) ([]dto.CourseResponse, error)
+	ValidateTimeConflicts(userID, scheduleID, courseID uuid.UUID) error
 	ValidateGenderRestriction(userID uuid.UUID, courseID uuid.UUID) error
 	ValidateCapacity(courseID uuid.UUID) error
+	ValidateSelectionWindow(userID, scheduleID uuid.UUID) error
+	ValidateActivation(userID, scheduleID uuid.UUID) error
+	SwapSection(userID, scheduleID, fromCourseID, toCourseID uuid.UUID) ([]dto.ExamConflict, error)
+	ValidateExamConflicts(userID, scheduleID, courseID uuid.UUID) ([]dto.ExamConflict, error)
+	GetExamCalendar(userID, scheduleID uuid.UUID) (*dto.ExamCalendarResponse, error)
+	AlternativeSections(userID, scheduleID, courseID uuid.UUID) ([]dto.CourseResponse, error)
+	GenerateSchedules(userID uuid.UUID, req dto.GenerateSchedulesRequest) (*dto.GenerateSchedulesResponse, error)
+}
+
+// ExamConflictPolicy says how course selection treats two selected courses
+// whose exams overlap or fall on the same day: "error" rejects the selection,
+// "warning" allows it and reports the clash, "ignore" skips the check.
+type ExamConflictPolicy struct {
+	Overlap string
+	SameDay string
 }
 
 type userCourseService struct {
 	userCourseRepo  repositories.UserCourseRepository
+	scheduleRepo    repositories.ScheduleRepository
 	courseService   CourseService
 	userService     AdminUserService
 	semesterService SemesterService
+	calendarService UniversitySemesterService
+	examPolicy      ExamConflictPolicy
 	logger          *zap.Logger
 }
 
 func NewUserCourseService(
 	userCourseRepo repositories.UserCourseRepository,
+	scheduleRepo repositories.ScheduleRepository,
 	courseService CourseService,
 	userService AdminUserService,
 	semesterService SemesterService,
+	calendarService UniversitySemesterService,
+	examPolicy ExamConflictPolicy,
 	logger *zap.Logger,
 ) UserCourseService {
 	return &userCourseService{
 		userCourseRepo:  userCourseRepo,
+		scheduleRepo:    scheduleRepo,
 		courseService:   courseService,
 		userService:     userService,
 		semesterService: semesterService,
+		calendarService: calendarService,
+		examPolicy:      examPolicy,
 		logger:          logger,
 	}
 }
 
-func (s *userCourseService) AddCourse(userID, courseID, semesterID uuid.UUID) error {
+func (s *userCourseService) AddCourse(userID, scheduleID, courseID uuid.UUID) ([]dto.ExamConflict, error) {
+	schedule, err := findUserSchedule(s.scheduleRepo, userID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if course exists
 	course, err := s.courseService.Get(courseID)
 	if err != nil {
-		return errors.Wrap(err, "failed to find course")
+		return nil, errors.Wrap(err, "failed to find course")
 	}
 
-	// Validate semester
-	_, err = s.semesterService.Get(semesterID)
-	if err != nil {
-		return errors.Wrap(err, "failed to find semester")
+	if course.SemesterID != schedule.SemesterID {
+		return nil, errors.NewValidationError("course of another semester")
+	}
+
+	if err := s.checkSelectionWindow(schedule, course.UniversityID); err != nil {
+		return nil, err
 	}
 
 	// Check if already enrolled
-	exists, err := s.userCourseRepo.ExistsByCourseAndSemester(userID, courseID, semesterID)
+	exists, err := s.userCourseRepo.ExistsInSchedule(scheduleID, courseID)
 	if err != nil {
-		return errors.Wrap(err, "failed to check enrollment")
+		return nil, errors.Wrap(err, "failed to check enrollment")
 	}
 	if exists {
-		return errors.NewConflictError("already enrolled in this course")
+		return nil, errors.NewConflictError("already enrolled in this course")
+	}
+
+	// Only one section of a course can be selected; students swap sections instead
+	selected, err := s.userCourseRepo.FindByCatalogCourse(scheduleID, course.CatalogCourseID)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return nil, errors.Wrap(err, "failed to check selected sections")
+	}
+	if selected != nil {
+		return nil, errors.NewConflictError("another section of this course")
 	}
 
 	// Validate capacity
 	if err := s.ValidateCapacity(courseID); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Validate gender restriction
 	if err := s.ValidateGenderRestriction(userID, courseID); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Validate time conflicts
-	if err := s.ValidateTimeConflicts(userID, semesterID, courseID); err != nil {
-		return err
+	if err := s.checkTimeConflicts(scheduleID, course, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	// Validate exam conflicts
+	warnings, err := s.checkExamConflicts(scheduleID, course, uuid.Nil)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create enrollment
 	userCourse := &models.UserCourse{
 		UserID:     userID,
 		CourseID:   courseID,
-		SemesterID: semesterID,
+		SemesterID: schedule.SemesterID,
+		ScheduleID: scheduleID,
 	}
 
 	if err := s.userCourseRepo.Create(userCourse); err != nil {
-		return errors.Wrap(err, "failed to create enrollment")
+		return nil, errors.Wrap(err, "failed to create enrollment")
 	}
 
 	s.logger.Info("Course added successfully",
 		zap.String("user_id", userID.String()),
+		zap.String("schedule_id", scheduleID.String()),
 		zap.String("course_id", courseID.String()),
 		zap.String("course_name", course.Name))
 
-	return nil
+	return warnings, nil
 }
 
-func (s *userCourseService) RemoveCourse(userID, courseID uuid.UUID) error {
-	if err := s.userCourseRepo.Delete(userID, courseID); err != nil {
+func (s *userCourseService) RemoveCourse(userID, scheduleID, courseID uuid.UUID) error {
+	schedule, err := findUserSchedule(s.scheduleRepo, userID, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	course, err := s.courseService.Get(courseID)
+	if err != nil {
+		return errors.Wrap(err, "failed to find course")
+	}
+
+	if err := s.checkSelectionWindow(schedule, course.UniversityID); err != nil {
+		return err
+	}
+
+	if err := s.userCourseRepo.Delete(scheduleID, courseID); err != nil {
 		return errors.Wrap(err, "failed to remove course")
 	}
 
@@ -112,8 +176,12 @@ func (s *userCourseService) RemoveCourse(userID, courseID uuid.UUID) error {
 	return nil
 }
 
-func (s *userCourseService) GetUserCourses(userID uuid.UUID, semesterID uuid.UUID) ([]dto.CourseResponse, error) {
-	userCourses, err := s.userCourseRepo.FindByUserAndSemester(userID, semesterID)
+func (s *userCourseService) GetUserCourses(userID, scheduleID uuid.UUID) ([]dto.CourseResponse, error) {
+	if _, err := findUserSchedule(s.scheduleRepo, userID, scheduleID); err != nil {
+		return nil, err
+	}
+
+	userCourses, err := s.userCourseRepo.FindBySchedule(scheduleID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to fetch user courses")
 	}
@@ -133,11 +201,9 @@ func (s *userCourseService) GetUserCourses(userID uuid.UUID, semesterID uuid.UUI
 	return responses, nil
 }
 
-func (s *userCourseService) ValidateTimeConflicts(userID, semesterID uuid.UUID, courseID uuid.UUID) error {
-	// Get user's current courses
-	userCourses, err := s.userCourseRepo.FindByUserAndSemester(userID, semesterID)
-	if err != nil {
-		return errors.Wrap(err, "failed to fetch user courses")
+func (s *userCourseService) ValidateTimeConflicts(userID, scheduleID, courseID uuid.UUID) error {
+	if _, err := findUserSchedule(s.scheduleRepo, userID, scheduleID); err != nil {
+		return err
 	}
 
 	// Get new course
@@ -146,21 +212,155 @@ func (s *userCourseService) ValidateTimeConflicts(userID, semesterID uuid.UUID,
 		return errors.Wrap(err, "failed to fetch course")
 	}
 
+	return s.checkTimeConflicts(scheduleID, newCourse, uuid.Nil)
+}
+
+// checkTimeConflicts compares a course against a schedule's courses, skipping
+// ignoredCourseID so a section can be checked as a replacement for another
+func (s *userCourseService) checkTimeConflicts(scheduleID uuid.UUID, newCourse *dto.CourseResponse, ignoredCourseID uuid.UUID) error {
+	// Get the schedule's current courses
+	userCourses, err := s.userCourseRepo.FindBySchedule(scheduleID)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch user courses")
+	}
+
 	// Check for time conflicts
 	for _, uc := range userCourses {
+		if uc.CourseID == ignoredCourseID || uc.CourseID == newCourse.ID {
+			continue
+		}
+
 		course, err := s.courseService.Get(uc.CourseID)
 		if err != nil {
 			continue
 		}
 
 		if hasTimeConflict(course.CourseTimes, newCourse.CourseTimes) {
-			return fmt.Errorf("time conflict with course: %s", course.Name)
+			return errors.NewConflictError(fmt.Sprintf("time of %s", course.Name))
 		}
 	}
 
 	return nil
 }
 
+// ValidateSelectionWindow checks that the selection of a schedule can change
+// now, whether or not it is active, as when a draft is about to replace the
+// active schedule
+func (s *userCourseService) ValidateSelectionWindow(userID, scheduleID uuid.UUID) error {
+	schedule, err := findUserSchedule(s.scheduleRepo, userID, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	userCourses, err := s.userCourseRepo.FindBySchedule(scheduleID)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch user courses")
+	}
+
+	return s.checkCourseWindows(schedule.SemesterID, userCourses)
+}
+
+// checkCourseWindows applies the windows of the universities offering the
+// selected courses, as checkSelectionWindow does for a single course. A
+// schedule without courses changes no selection.
+func (s *userCourseService) checkCourseWindows(semesterID uuid.UUID, userCourses []models.UserCourse) error {
+	checked := make(map[uuid.UUID]bool)
+	for _, uc := range userCourses {
+		universityID := uc.Course.UniversityID
+		if checked[universityID] {
+			continue
+		}
+		checked[universityID] = true
+
+		if err := s.calendarService.CheckSelectionWindow(universityID, semesterID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSelectionWindow applies the registration and add/drop windows to
+// changes of the active schedule. Drafts can be planned at any time.
+func (s *userCourseService) checkSelectionWindow(schedule *models.Schedule, universityID uuid.UUID) error {
+	if !schedule.IsActive {
+		return nil
+	}
+	return s.calendarService.CheckSelectionWindow(universityID, schedule.SemesterID)
+}
+
+// ValidateActivation checks that a draft can replace the active schedule: the
+// selection of both can change now, and each of the draft's courses passes the
+// checks of AddCourse, since the seats of drafts are not taken. It names the
+// courses that don't.
+func (s *userCourseService) ValidateActivation(userID, scheduleID uuid.UUID) error {
+	schedule, err := findUserSchedule(s.scheduleRepo, userID, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	userCourses, err := s.userCourseRepo.FindBySchedule(scheduleID)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch user courses")
+	}
+	if err := s.checkCourseWindows(schedule.SemesterID, userCourses); err != nil {
+		return err
+	}
+
+	// the courses of the active schedule are dropped
+	active, err := s.scheduleRepo.FindActive(userID, schedule.SemesterID)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+	if active != nil && active.ID != schedule.ID {
+		if err := s.ValidateSelectionWindow(userID, active.ID); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background() // todo: remove and pass request context
+	user, err := s.userService.Get(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch user")
+	}
+
+	var failed []string
+	for _, uc := range userCourses {
+		course, err := s.courseService.Get(uc.CourseID)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch course")
+		}
+
+		// the user's own seat moves with the selection
+		full, err := s.isFull(course, userID)
+		if err != nil {
+			return err
+		}
+		if full || !genderAllowed(course, user.Gender) {
+			failed = append(failed, course.Name)
+			continue
+		}
+
+		if err := s.checkTimeConflicts(scheduleID, course, uuid.Nil); err != nil {
+			if !errors.Is(err, errors.ErrConflict) {
+				return err
+			}
+			failed = append(failed, course.Name)
+			continue
+		}
+		if _, err := s.checkExamConflicts(scheduleID, course, uuid.Nil); err != nil {
+			if !errors.Is(err, errors.ErrConflict) {
+				return err
+			}
+			failed = append(failed, course.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.NewConflictError(fmt.Sprintf("activating the schedule with %s", strings.Join(failed, ", ")))
+	}
+	return nil
+}
+
 func (s *userCourseService) ValidateGenderRestriction(userID uuid.UUID, courseID uuid.UUID) error {
 	ctx := context.Background() // todo: remove and pass request context
 	user, err := s.userService.Get(ctx, userID)
@@ -173,39 +373,314 @@ func (s *userCourseService) ValidateGenderRestriction(userID uuid.UUID, courseID
 		return errors.Wrap(err, "failed to fetch course")
 	}
 
-	if course.GenderRestriction != "mixed" && course.GenderRestriction != user.Gender {
+	if !genderAllowed(course, user.Gender) {
 		return fmt.Errorf("course is restricted to %s students", course.GenderRestriction)
 	}
 
 	return nil
 }
 
+// genderAllowed reports whether students of a gender can take a course
+func genderAllowed(course *dto.CourseResponse, gender string) bool {
+	return course.GenderRestriction == "mixed" || course.GenderRestriction == gender
+}
+
 func (s *userCourseService) ValidateCapacity(courseID uuid.UUID) error {
 	course, err := s.courseService.Get(courseID)
 	if err != nil {
 		return errors.Wrap(err, "failed to fetch course")
 	}
 
-	if course.Capacity <= 0 {
+	full, err := s.isFull(course, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	if full {
 		return errors.New("course is full")
 	}
 
-	enrollments, err := s.userCourseRepo.FindByCourseAndSemester(courseID, course.SemesterID)
+	return nil
+}
+
+// isFull reports whether the active schedules have taken every seat of a
+// course, not counting the seat of ignoredUserID
+func (s *userCourseService) isFull(course *dto.CourseResponse, ignoredUserID uuid.UUID) (bool, error) {
+	if course.Capacity <= 0 {
+		return true, nil
+	}
+
+	enrollments, err := s.userCourseRepo.FindByCourseAndSemester(course.ID, course.SemesterID)
 	if err != nil {
-		return errors.Wrap(err, "failed to check course capacity")
+		return false, errors.Wrap(err, "failed to check course capacity")
 	}
 
-	if len(enrollments) >= course.Capacity {
-		return errors.New("course is full")
+	taken := 0
+	for _, enrollment := range enrollments {
+		if enrollment.UserID != ignoredUserID {
+			taken++
+		}
 	}
+	return taken >= course.Capacity, nil
+}
 
-	return nil
+// SwapSection replaces a selected section with another section of the same course
+func (s *userCourseService) SwapSection(userID, scheduleID, fromCourseID, toCourseID uuid.UUID) ([]dto.ExamConflict, error) {
+	schedule, err := findUserSchedule(s.scheduleRepo, userID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := s.courseService.Get(fromCourseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find course")
+	}
+
+	to, err := s.courseService.Get(toCourseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find course")
+	}
+
+	if from.CatalogCourseID != to.CatalogCourseID || from.SemesterID != to.SemesterID {
+		return nil, errors.NewValidationError("sections of different courses")
+	}
+
+	if err := s.checkSelectionWindow(schedule, to.UniversityID); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.userCourseRepo.ExistsInSchedule(scheduleID, fromCourseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check enrollment")
+	}
+	if !exists {
+		return nil, errors.NewNotFoundError("user course", fromCourseID.String())
+	}
+
+	if fromCourseID == toCourseID {
+		return nil, nil
+	}
+
+	if err := s.ValidateCapacity(toCourseID); err != nil {
+		return nil, err
+	}
+
+	if err := s.ValidateGenderRestriction(userID, toCourseID); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTimeConflicts(scheduleID, to, fromCourseID); err != nil {
+		return nil, err
+	}
+
+	warnings, err := s.checkExamConflicts(scheduleID, to, fromCourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	userCourse := &models.UserCourse{
+		UserID:     userID,
+		CourseID:   toCourseID,
+		SemesterID: to.SemesterID,
+		ScheduleID: scheduleID,
+	}
+	if err := s.userCourseRepo.Swap(scheduleID, fromCourseID, userCourse); err != nil {
+		return nil, errors.Wrap(err, "failed to swap sections")
+	}
+
+	s.logger.Info("Section swapped successfully",
+		zap.String("user_id", userID.String()),
+		zap.String("schedule_id", scheduleID.String()),
+		zap.String("from_course_id", fromCourseID.String()),
+		zap.String("to_course_id", toCourseID.String()))
+
+	return warnings, nil
+}
+
+// AlternativeSections lists the other sections of a course the user could take
+// instead: open to their gender, not full and free of time conflicts with the
+// rest of the schedule
+func (s *userCourseService) AlternativeSections(userID, scheduleID, courseID uuid.UUID) ([]dto.CourseResponse, error) {
+	if _, err := findUserSchedule(s.scheduleRepo, userID, scheduleID); err != nil {
+		return nil, err
+	}
+
+	sections, err := s.courseService.GetSections(courseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch course sections")
+	}
+
+	selected, err := s.userCourseRepo.FindByCatalogCourse(scheduleID, sections.CatalogCourseID)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return nil, errors.Wrap(err, "failed to check selected sections")
+	}
+	ignoredCourseID := uuid.Nil
+	if selected != nil {
+		ignoredCourseID = selected.CourseID
+	}
+
+	alternatives := make([]dto.CourseResponse, 0, len(sections.Sections))
+	for _, section := range sections.Sections {
+		if section.ID == courseID || section.ID == ignoredCourseID {
+			continue
+		}
+		if err := s.ValidateCapacity(section.ID); err != nil {
+			continue
+		}
+		if err := s.ValidateGenderRestriction(userID, section.ID); err != nil {
+			continue
+		}
+		if err := s.checkTimeConflicts(scheduleID, section, ignoredCourseID); err != nil {
+			if errors.Is(err, errors.ErrConflict) {
+				continue
+			}
+			return nil, err
+		}
+		alternatives = append(alternatives, *section)
+	}
+
+	return alternatives, nil
+}
+
+// ValidateExamConflicts checks a course's exam against the exams of a
+// schedule's courses, returning the clashes the policy only warns about
+func (s *userCourseService) ValidateExamConflicts(userID, scheduleID, courseID uuid.UUID) ([]dto.ExamConflict, error) {
+	if _, err := findUserSchedule(s.scheduleRepo, userID, scheduleID); err != nil {
+		return nil, err
+	}
+
+	course, err := s.courseService.Get(courseID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch course")
+	}
+
+	return s.checkExamConflicts(scheduleID, course, uuid.Nil)
+}
+
+// checkExamConflicts applies the exam policy to a course against a schedule's
+// courses, skipping ignoredCourseID like checkTimeConflicts
+func (s *userCourseService) checkExamConflicts(scheduleID uuid.UUID, newCourse *dto.CourseResponse, ignoredCourseID uuid.UUID) ([]dto.ExamConflict, error) {
+	userCourses, err := s.userCourseRepo.FindBySchedule(scheduleID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch user courses")
+	}
+
+	var warnings []dto.ExamConflict
+	for _, uc := range userCourses {
+		if uc.CourseID == ignoredCourseID || uc.CourseID == newCourse.ID {
+			continue
+		}
+
+		course, err := s.courseService.Get(uc.CourseID)
+		if err != nil {
+			continue
+		}
+
+		kind := examConflictKind(newCourse, course)
+		if kind == "" {
+			continue
+		}
+
+		policy := s.examPolicy.SameDay
+		if kind == "overlap" {
+			policy = s.examPolicy.Overlap
+		}
+
+		switch policy {
+		case "error":
+			if kind == "overlap" {
+				return nil, errors.NewConflictError(fmt.Sprintf("exam time of %s", course.Name))
+			}
+			return nil, errors.NewConflictError(fmt.Sprintf("exam day of %s", course.Name))
+		case "warning":
+			warnings = append(warnings, dto.ExamConflict{
+				CourseID:   course.ID,
+				CourseName: course.Name,
+				Kind:       kind,
+			})
+		}
+	}
+
+	return warnings, nil
+}
+
+// GetExamCalendar lists the exams of a schedule's courses with the clashes
+// between them, whatever the selection policy
+func (s *userCourseService) GetExamCalendar(userID, scheduleID uuid.UUID) (*dto.ExamCalendarResponse, error) {
+	schedule, err := findUserSchedule(s.scheduleRepo, userID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := s.GetUserCourses(userID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &dto.ExamCalendarResponse{
+		SemesterID:  schedule.SemesterID,
+		ScheduleID:  scheduleID,
+		Exams:       []dto.ExamCalendarEntry{},
+		WithoutExam: []dto.CourseResponse{},
+	}
+
+	for i := range courses {
+		course := &courses[i]
+		if course.ExamStart.IsZero() {
+			calendar.WithoutExam = append(calendar.WithoutExam, *course)
+			continue
+		}
+
+		entry := dto.ExamCalendarEntry{
+			CourseID:       course.ID,
+			Code:           course.Code,
+			Name:           course.Name,
+			ExamStart:      course.ExamStart,
+			ExamEnd:        course.ExamEnd,
+			ExamDate:       course.ExamDate,
+			ExamDateJalali: course.ExamDateJalali,
+			Conflicts:      []dto.ExamConflict{},
+		}
+		for j := range courses {
+			if i == j {
+				continue
+			}
+			if kind := examConflictKind(course, &courses[j]); kind != "" {
+				entry.Conflicts = append(entry.Conflicts, dto.ExamConflict{
+					CourseID:   courses[j].ID,
+					CourseName: courses[j].Name,
+					Kind:       kind,
+				})
+			}
+		}
+		calendar.Exams = append(calendar.Exams, entry)
+	}
+
+	sort.Slice(calendar.Exams, func(i, j int) bool {
+		return calendar.Exams[i].ExamStart.Before(calendar.Exams[j].ExamStart)
+	})
+
+	return calendar, nil
+}
+
+// examConflictKind returns "overlap" if two exams overlap, "same_day" if they
+// are on the same local day, or "" if they don't clash or either has no exam
+func examConflictKind(c1, c2 *dto.CourseResponse) string {
+	if c1.ExamStart.IsZero() || c2.ExamStart.IsZero() {
+		return ""
+	}
+	if c1.ExamStart.Before(c2.ExamEnd) && c2.ExamStart.Before(c1.ExamEnd) {
+		return "overlap"
+	}
+	if c1.ExamDate == c2.ExamDate {
+		return "same_day"
+	}
+	return ""
 }
 
 func hasTimeConflict(times1, times2 []dto.CourseTimeResponse) bool {
 	for _, t1 := range times1 {
 		for _, t2 := range times2 {
-			if t1.DayOfWeek == t2.DayOfWeek {
+			if t1.DayOfWeek == t2.DayOfWeek && sessionsShareWeeks(t1, t2) {
 				if (t1.StartTime.Before(t2.EndTime) && t1.EndTime.After(t2.StartTime)) ||
 					(t2.StartTime.Before(t1.EndTime) && t2.EndTime.After(t1.StartTime)) {
 					return true
@@ -215,3 +690,16 @@ func hasTimeConflict(times1, times2 []dto.CourseTimeResponse) bool {
 	}
 	return false
 }
+
+// sessionsShareWeeks reports whether two sessions can fall in the same week:
+// odd and even week sessions never meet, nor do sessions in different halves
+// of the term
+func sessionsShareWeeks(t1, t2 dto.CourseTimeResponse) bool {
+	if t1.WeekParity != "" && t2.WeekParity != "" && t1.WeekParity != t2.WeekParity {
+		return false
+	}
+	if t1.HalfTerm != 0 && t2.HalfTerm != 0 && t1.HalfTerm != t2.HalfTerm {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxGeneratedSchedules bounds the enumeration, which grows with the
+	// product of the section counts
+	maxGeneratedSchedules = 5000
+	// maxVisitedSections bounds the sections the enumeration tries, so that
+	// combinations which all end in a conflict don't go on unbounded either
+	maxVisitedSections      = 100000
+	defaultScheduleLimit    = 10
+	teachingDays            = 6 // Saturday to Thursday
+	freeDayScore            = 10
+	preferredProfessorScore = 4
+	earlyClassPenalty       = 3
+	examWarningPenalty      = 2
+	gapPenaltyMinutes       = 30
+)
+
+// GenerateSchedules enumerates the section combinations of the requested
+// courses that the user can take together, ranked by their preferences. Only
+// the requested courses are considered, not the user's current selection.
+func (s *userCourseService) GenerateSchedules(userID uuid.UUID, req dto.GenerateSchedulesRequest) (*dto.GenerateSchedulesResponse, error) {
+	ctx := context.Background() // todo: remove and pass request context
+	user, err := s.userService.Get(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch user")
+	}
+
+	if _, err := s.semesterService.Get(req.SemesterID); err != nil {
+		return nil, errors.Wrap(err, "failed to find semester")
+	}
+
+	var earliestStart time.Time
+	if req.Preferences.EarliestStart != "" {
+		earliestStart, err = time.Parse("15:04", req.Preferences.EarliestStart)
+		if err != nil {
+			return nil, errors.NewValidationError("earliest start")
+		}
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultScheduleLimit
+	}
+
+	response := &dto.GenerateSchedulesResponse{
+		SemesterID:  req.SemesterID,
+		Schedules:   []dto.GeneratedSchedule{},
+		Unavailable: []string{},
+	}
+
+	var requested []*dto.CourseSectionsResponse
+	var sectionIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, code := range req.CourseCodes {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			return nil, errors.NewValidationError("course code")
+		}
+
+		sections, err := s.courseService.GetSectionsByCode(user.UniversityID, req.SemesterID, code)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch course sections")
+		}
+		if seen[sections.CatalogCourseID] {
+			continue
+		}
+		seen[sections.CatalogCourseID] = true
+
+		requested = append(requested, sections)
+		for _, section := range sections.Sections {
+			sectionIDs = append(sectionIDs, section.ID)
+		}
+	}
+
+	// Keep the sections AddCourse would accept by capacity and gender, with
+	// the seats of all of them counted at once
+	taken, err := s.userCourseRepo.CountTakenSeats(sectionIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check course capacity")
+	}
+
+	var candidates [][]*dto.CourseResponse
+	for _, sections := range requested {
+		var eligible []*dto.CourseResponse
+		for _, section := range sections.Sections {
+			if taken[section.ID] >= section.Capacity || !genderAllowed(section, user.Gender) {
+				continue
+			}
+			eligible = append(eligible, section)
+		}
+		if len(eligible) == 0 {
+			response.Unavailable = append(response.Unavailable, sections.Code)
+			continue
+		}
+		candidates = append(candidates, eligible)
+	}
+
+	if len(response.Unavailable) > 0 {
+		return response, nil
+	}
+
+	// Courses with fewer sections first, so conflicts prune the search early
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len(candidates[i]) < len(candidates[j])
+	})
+
+	var schedules [][]*dto.CourseResponse
+	chosen := make([]*dto.CourseResponse, 0, len(candidates))
+	visited := 0
+	var enumerate func(depth int) bool
+	enumerate = func(depth int) bool {
+		if depth == len(candidates) {
+			schedules = append(schedules, append([]*dto.CourseResponse(nil), chosen...))
+			return len(schedules) < maxGeneratedSchedules
+		}
+		for _, section := range candidates[depth] {
+			if visited++; visited > maxVisitedSections {
+				return false
+			}
+			if !s.fitsSchedule(chosen, section) {
+				continue
+			}
+			chosen = append(chosen, section)
+			more := enumerate(depth + 1)
+			chosen = chosen[:len(chosen)-1]
+			if !more {
+				return false
+			}
+		}
+		return true
+	}
+	response.Truncated = !enumerate(0)
+	response.Total = len(schedules)
+
+	preferred := make(map[uuid.UUID]bool, len(req.Preferences.PreferredProfessors))
+	for _, id := range req.Preferences.PreferredProfessors {
+		preferred[id] = true
+	}
+
+	ranked := make([]dto.GeneratedSchedule, 0, len(schedules))
+	for _, courses := range schedules {
+		ranked = append(ranked, s.rankSchedule(courses, req.Preferences, earliestStart, preferred))
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].GapMinutes < ranked[j].GapMinutes
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	response.Schedules = ranked
+
+	s.logger.Info("Schedules generated",
+		zap.String("user_id", userID.String()),
+		zap.String("semester_id", req.SemesterID.String()),
+		zap.Int("total", response.Total),
+		zap.Bool("truncated", response.Truncated))
+
+	return response, nil
+}
+
+// fitsSchedule reports whether a section can join the chosen ones without a
+// class time clash or an exam clash the policy rejects
+func (s *userCourseService) fitsSchedule(chosen []*dto.CourseResponse, section *dto.CourseResponse) bool {
+	for _, course := range chosen {
+		if hasTimeConflict(course.CourseTimes, section.CourseTimes) {
+			return false
+		}
+		if s.examClashPolicy(course, section) == "error" {
+			return false
+		}
+	}
+	return true
+}
+
+// examClashPolicy returns the policy that applies to the exams of two
+// courses, or "" if they don't clash
+func (s *userCourseService) examClashPolicy(c1, c2 *dto.CourseResponse) string {
+	switch examConflictKind(c1, c2) {
+	case "overlap":
+		return s.examPolicy.Overlap
+	case "same_day":
+		return s.examPolicy.SameDay
+	default:
+		return ""
+	}
+}
+
+func (s *userCourseService) rankSchedule(courses []*dto.CourseResponse, prefs dto.SchedulePreferences, earliestStart time.Time, preferred map[uuid.UUID]bool) dto.GeneratedSchedule {
+	schedule := dto.GeneratedSchedule{
+		Courses:  make([]dto.CourseResponse, 0, len(courses)),
+		FreeDays: []int{},
+	}
+
+	days := make(map[int][]dto.CourseTimeResponse)
+	var first time.Time
+	for i, course := range courses {
+		schedule.Courses = append(schedule.Courses, *course)
+		if preferred[course.ProfessorID] {
+			schedule.Score += preferredProfessorScore
+		}
+
+		for _, courseTime := range course.CourseTimes {
+			days[courseTime.DayOfWeek] = append(days[courseTime.DayOfWeek], courseTime)
+			start := clockTime(courseTime.StartTime)
+			if first.IsZero() || start.Before(first) {
+				first = start
+			}
+			if !earliestStart.IsZero() && start.Before(earliestStart) {
+				schedule.Score -= earlyClassPenalty
+			}
+		}
+
+		for _, other := range courses[i+1:] {
+			if s.examClashPolicy(course, other) == "warning" {
+				schedule.Warnings = append(schedule.Warnings, dto.ExamConflict{
+					CourseID:   other.ID,
+					CourseName: other.Name,
+					Kind:       examConflictKind(course, other),
+				})
+				schedule.Score -= examWarningPenalty
+			}
+		}
+	}
+
+	if !first.IsZero() {
+		schedule.EarliestStart = first.Format("15:04")
+	}
+
+	for day := 0; day < teachingDays; day++ {
+		if len(days[day]) == 0 {
+			schedule.FreeDays = append(schedule.FreeDays, day)
+		}
+	}
+	for _, day := range prefs.FreeDays {
+		if len(days[day]) == 0 {
+			schedule.Score += freeDayScore
+		}
+	}
+
+	for _, sessions := range days {
+		schedule.GapMinutes += gapMinutes(sessions)
+	}
+	if prefs.MinimizeGaps {
+		schedule.Score -= schedule.GapMinutes / gapPenaltyMinutes
+	}
+
+	return schedule
+}
+
+// gapMinutes sums the idle time between the sessions of a day
+func gapMinutes(sessions []dto.CourseTimeResponse) int {
+	sort.Slice(sessions, func(i, j int) bool {
+		return clockTime(sessions[i].StartTime).Before(clockTime(sessions[j].StartTime))
+	})
+
+	// clockTime dates times in year 0, before the zero time, so the first
+	// session sets end rather than comparing with it
+	total := 0
+	var end time.Time
+	for i, session := range sessions {
+		start := clockTime(session.StartTime)
+		if i > 0 && start.After(end) {
+			total += int(start.Sub(end).Minutes())
+		}
+		if sessionEnd := clockTime(session.EndTime); i == 0 || sessionEnd.After(end) {
+			end = sessionEnd
+		}
+	}
+	return total
+}
+
+// clockTime drops the date of a course time so times compare by clock only
+func clockTime(t time.Time) time.Time {
+	return time.Date(0, 1, 1, t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type MockAdminUserService struct {
+	AdminUserService
+	mock.Mock
+}
+
+func (m *MockAdminUserService) Get(ctx context.Context, id uuid.UUID) (*dto.AdminUserResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AdminUserResponse), args.Error(1)
+}
+
+type MockSemesterService struct {
+	SemesterService
+	mock.Mock
+}
+
+func (m *MockSemesterService) Get(id uuid.UUID) (*dto.SemesterResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SemesterResponse), args.Error(1)
+}
+
+func TestGapMinutes(t *testing.T) {
+	tests := []struct {
+		name     string
+		sessions []dto.CourseTimeResponse
+		want     int
+	}{
+		{name: "no sessions", want: 0},
+		{
+			name:     "back to back",
+			sessions: []dto.CourseTimeResponse{session(0, "08:00", "10:00", "", 0), session(0, "10:00", "12:00", "", 0)},
+			want:     0,
+		},
+		{
+			name:     "unsorted with a gap",
+			sessions: []dto.CourseTimeResponse{session(0, "13:00", "15:00", "", 0), session(0, "08:00", "10:00", "", 0)},
+			want:     180,
+		},
+		{
+			name: "session inside a longer one",
+			sessions: []dto.CourseTimeResponse{
+				session(0, "08:00", "12:00", "", 0), session(0, "09:00", "10:00", "", 0), session(0, "13:00", "14:00", "", 0),
+			},
+			want: 60,
+		},
+		{
+			name: "odd and even sessions in the same slot",
+			sessions: []dto.CourseTimeResponse{
+				session(0, "08:00", "10:00", "odd", 0), session(0, "08:00", "10:00", "even", 0), session(0, "12:00", "14:00", "", 0),
+			},
+			want: 120,
+		},
+		{
+			name: "odd session followed by an even one",
+			sessions: []dto.CourseTimeResponse{
+				session(0, "08:00", "10:00", "odd", 0), session(0, "10:30", "12:00", "even", 0),
+			},
+			want: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, gapMinutes(tt.sessions))
+		})
+	}
+}
+
+func TestRankSchedule(t *testing.T) {
+	loc := tehran(t)
+	preferredProfessor := uuid.New()
+
+	c1 := examCourse("c1", time.Date(2025, 6, 28, 8, 0, 0, 0, loc), 2*time.Hour, loc)
+	c1.ProfessorID = preferredProfessor
+	c1.CourseTimes = []dto.CourseTimeResponse{session(0, "08:00", "10:00", "", 0), session(0, "11:30", "13:00", "", 0)}
+	c2 := examCourse("c2", time.Date(2025, 6, 28, 14, 0, 0, 0, loc), 2*time.Hour, loc)
+	c2.ProfessorID = uuid.New()
+	c2.CourseTimes = []dto.CourseTimeResponse{session(2, "10:00", "12:00", "", 0)}
+
+	s := &userCourseService{examPolicy: ExamConflictPolicy{Overlap: "error", SameDay: "warning"}}
+	earliestStart, _ := time.Parse("15:04", "09:00")
+	prefs := dto.SchedulePreferences{FreeDays: []int{1, 2}, MinimizeGaps: true}
+
+	schedule := s.rankSchedule([]*dto.CourseResponse{c1, c2}, prefs, earliestStart, map[uuid.UUID]bool{preferredProfessor: true})
+
+	// +4 preferred professor, -3 class before 09:00, +10 free Sunday,
+	// -2 exams on the same day, -3 for 90 minutes of gaps
+	assert.Equal(t, 6, schedule.Score)
+	assert.Equal(t, []int{1, 3, 4, 5}, schedule.FreeDays)
+	assert.Equal(t, "08:00", schedule.EarliestStart)
+	assert.Equal(t, 90, schedule.GapMinutes)
+	assert.Equal(t, []dto.ExamConflict{{CourseID: c2.ID, CourseName: "c2", Kind: "same_day"}}, schedule.Warnings)
+	assert.Len(t, schedule.Courses, 2)
+}
+
+// generatorFixture serves the sections of course codes to a schedule generator
+type generatorFixture struct {
+	service     *userCourseService
+	courses     *MockCourseService
+	userCourses *MockUserCourseRepository
+	taken       map[uuid.UUID]int
+	user        *dto.AdminUserResponse
+	semesterID  uuid.UUID
+}
+
+func newGeneratorFixture() *generatorFixture {
+	f := &generatorFixture{
+		courses:     new(MockCourseService),
+		userCourses: new(MockUserCourseRepository),
+		taken:       make(map[uuid.UUID]int),
+		user:        &dto.AdminUserResponse{ID: uuid.New(), UniversityID: uuid.New(), Gender: "female"},
+		semesterID:  uuid.New(),
+	}
+
+	users := new(MockAdminUserService)
+	users.On("Get", mock.Anything, f.user.ID).Return(f.user, nil)
+	semesters := new(MockSemesterService)
+	semesters.On("Get", f.semesterID).Return(&dto.SemesterResponse{ID: f.semesterID}, nil)
+	f.userCourses.On("CountTakenSeats", mock.Anything).Return(f.taken, nil)
+
+	f.service = &userCourseService{
+		userCourseRepo:  f.userCourses,
+		courseService:   f.courses,
+		userService:     users,
+		semesterService: semesters,
+		examPolicy:      ExamConflictPolicy{Overlap: "error", SameDay: "warning"},
+		logger:          zap.NewNop(),
+	}
+	return f
+}
+
+// section adds a mixed-gender section with room left to the sections of code
+func (f *generatorFixture) section(sections *dto.CourseSectionsResponse, times ...dto.CourseTimeResponse) *dto.CourseResponse {
+	section := &dto.CourseResponse{
+		ID:                uuid.New(),
+		SemesterID:        f.semesterID,
+		Code:              fmt.Sprintf("%s_%02d", sections.Code, len(sections.Sections)+1),
+		Capacity:          30,
+		GenderRestriction: "mixed",
+		CourseTimes:       times,
+	}
+	sections.Sections = append(sections.Sections, section)
+	return section
+}
+
+func (f *generatorFixture) course(code string) *dto.CourseSectionsResponse {
+	sections := &dto.CourseSectionsResponse{CatalogCourseID: uuid.New(), SemesterID: f.semesterID, Code: code}
+	f.courses.On("GetSectionsByCode", f.user.UniversityID, f.semesterID, code).Return(sections, nil)
+	return sections
+}
+
+func TestGenerateSchedulesPrunesAndRanks(t *testing.T) {
+	f := newGeneratorFixture()
+
+	math := f.course("1211003")
+	a1 := f.section(math, session(0, "08:00", "10:00", "", 0))
+	a2 := f.section(math, session(1, "08:00", "10:00", "", 0))
+	full := f.section(math, session(3, "08:00", "10:00", "", 0))
+	f.taken[full.ID] = full.Capacity
+	f.taken[a1.ID] = full.Capacity - 1
+
+	physics := f.course("1213001")
+	b1 := f.section(physics, session(0, "09:00", "11:00", "", 0))
+	b2 := f.section(physics, session(2, "08:00", "10:00", "", 0))
+	male := f.section(physics, session(4, "08:00", "10:00", "", 0))
+	male.GenderRestriction = "male"
+
+	response, err := f.service.GenerateSchedules(f.user.ID, dto.GenerateSchedulesRequest{
+		SemesterID:  f.semesterID,
+		CourseCodes: []string{"1211003", "1213001"},
+		Preferences: dto.SchedulePreferences{FreeDays: []int{0}},
+	})
+	require.NoError(t, err)
+
+	// a1 and b1 clash, the full and the male-only sections are skipped
+	assert.Equal(t, 3, response.Total)
+	assert.False(t, response.Truncated)
+	assert.Empty(t, response.Unavailable)
+	require.Len(t, response.Schedules, 3)
+
+	best := response.Schedules[0]
+	assert.Equal(t, freeDayScore, best.Score)
+	assert.Equal(t, []uuid.UUID{a2.ID, b2.ID}, []uuid.UUID{best.Courses[0].ID, best.Courses[1].ID})
+	for _, schedule := range response.Schedules {
+		var ids []uuid.UUID
+		for _, course := range schedule.Courses {
+			ids = append(ids, course.ID)
+		}
+		assert.False(t, assert.ObjectsAreEqual([]uuid.UUID{a1.ID, b1.ID}, ids))
+		assert.NotContains(t, ids, full.ID)
+		assert.NotContains(t, ids, male.ID)
+	}
+	f.userCourses.AssertNumberOfCalls(t, "CountTakenSeats", 1)
+	f.userCourses.AssertCalled(t, "CountTakenSeats", []uuid.UUID{a1.ID, a2.ID, full.ID, b1.ID, b2.ID, male.ID})
+}
+
+func TestGenerateSchedulesUnavailable(t *testing.T) {
+	f := newGeneratorFixture()
+
+	f.section(f.course("1211003"), session(0, "08:00", "10:00", "", 0))
+	f.section(f.course("1213001"), session(1, "08:00", "10:00", "", 0)).Capacity = 0
+
+	response, err := f.service.GenerateSchedules(f.user.ID, dto.GenerateSchedulesRequest{
+		SemesterID:  f.semesterID,
+		CourseCodes: []string{"1211003", "1213001"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1213001"}, response.Unavailable)
+	assert.Empty(t, response.Schedules)
+	assert.Zero(t, response.Total)
+}
+
+func TestGenerateSchedulesTruncates(t *testing.T) {
+	f := newGeneratorFixture()
+
+	// 10^4 combinations of sections without class times or exams
+	codes := []string{"1211001", "1211002", "1211003", "1211004"}
+	for _, code := range codes {
+		sections := f.course(code)
+		for i := 0; i < 10; i++ {
+			f.section(sections)
+		}
+	}
+
+	response, err := f.service.GenerateSchedules(f.user.ID, dto.GenerateSchedulesRequest{
+		SemesterID:  f.semesterID,
+		CourseCodes: codes,
+		Limit:       5,
+	})
+	require.NoError(t, err)
+	assert.True(t, response.Truncated)
+	assert.Equal(t, maxGeneratedSchedules, response.Total)
+	assert.Len(t, response.Schedules, 5)
+}
+
+func TestGenerateSchedulesTruncatesDeadEnds(t *testing.T) {
+	f := newGeneratorFixture()
+
+	// 10^5 combinations of five courses, none of which the last course fits
+	codes := []string{"1211000"}
+	f.section(f.course("1211000"), session(0, "08:00", "10:00", "", 0))
+	for _, code := range []string{"1211001", "1211002", "1211003", "1211004", "1211005"} {
+		codes = append(codes, code)
+		sections := f.course(code)
+		for i := 0; i < 10; i++ {
+			f.section(sections)
+		}
+	}
+	clashing := f.course("1213001")
+	for i := 0; i < 11; i++ {
+		f.section(clashing, session(0, "08:00", "10:00", "", 0))
+	}
+	codes = append(codes, "1213001")
+
+	response, err := f.service.GenerateSchedules(f.user.ID, dto.GenerateSchedulesRequest{
+		SemesterID:  f.semesterID,
+		CourseCodes: codes,
+	})
+	require.NoError(t, err)
+	assert.True(t, response.Truncated)
+	assert.Zero(t, response.Total)
+	assert.Empty(t, response.Schedules)
+}
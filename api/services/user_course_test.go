@@ -0,0 +1,490 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/errors"
+	"github.com/armanjr/termustat/api/models"
+	"github.com/armanjr/termustat/api/repositories"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// --- Mocks, implementing only what the tests call ---
+
+type MockUserCourseRepository struct {
+	repositories.UserCourseRepository
+	mock.Mock
+}
+
+func (m *MockUserCourseRepository) FindBySchedule(scheduleID uuid.UUID) ([]models.UserCourse, error) {
+	args := m.Called(scheduleID)
+	return args.Get(0).([]models.UserCourse), args.Error(1)
+}
+
+func (m *MockUserCourseRepository) FindByCourseAndSemester(courseID, semesterID uuid.UUID) ([]models.UserCourse, error) {
+	args := m.Called(courseID, semesterID)
+	return args.Get(0).([]models.UserCourse), args.Error(1)
+}
+
+func (m *MockUserCourseRepository) ExistsInSchedule(scheduleID, courseID uuid.UUID) (bool, error) {
+	args := m.Called(scheduleID, courseID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockUserCourseRepository) FindByCatalogCourse(scheduleID, catalogCourseID uuid.UUID) (*models.UserCourse, error) {
+	args := m.Called(scheduleID, catalogCourseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserCourse), args.Error(1)
+}
+
+func (m *MockUserCourseRepository) Swap(scheduleID, fromCourseID uuid.UUID, to *models.UserCourse) error {
+	return m.Called(scheduleID, fromCourseID, to).Error(0)
+}
+
+func (m *MockUserCourseRepository) CountTakenSeats(courseIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	args := m.Called(courseIDs)
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
+
+type MockCourseService struct {
+	CourseService
+	mock.Mock
+}
+
+func (m *MockCourseService) Get(id uuid.UUID) (*dto.CourseResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CourseResponse), args.Error(1)
+}
+
+func (m *MockCourseService) GetSectionsByCode(universityID, semesterID uuid.UUID, code string) (*dto.CourseSectionsResponse, error) {
+	args := m.Called(universityID, semesterID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CourseSectionsResponse), args.Error(1)
+}
+
+func (m *MockCourseService) GetSections(id uuid.UUID) (*dto.CourseSectionsResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CourseSectionsResponse), args.Error(1)
+}
+
+func tehran(t *testing.T) *time.Location {
+	loc, err := time.LoadLocation("Asia/Tehran")
+	require.NoError(t, err)
+	return loc
+}
+
+// examCourse is a course whose exam runs from start for the given length,
+// shown in loc the way mapCourseToResponse shows it
+func examCourse(name string, start time.Time, length time.Duration, loc *time.Location) *dto.CourseResponse {
+	course := &dto.CourseResponse{ID: uuid.New(), Name: name}
+	if !start.IsZero() {
+		setExamFields(course, start.UTC(), start.Add(length).UTC(), loc)
+	}
+	return course
+}
+
+func session(day int, start, end, parity string, halfTerm int) dto.CourseTimeResponse {
+	startTime, _ := time.Parse("15:04", start)
+	endTime, _ := time.Parse("15:04", end)
+	return dto.CourseTimeResponse{
+		DayOfWeek:  day,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		WeekParity: parity,
+		HalfTerm:   halfTerm,
+	}
+}
+
+func TestHasTimeConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		t1, t2   dto.CourseTimeResponse
+		conflict bool
+	}{
+		{name: "weekly overlap", t1: session(1, "08:00", "10:00", "", 0), t2: session(1, "09:00", "11:00", "", 0), conflict: true},
+		{name: "back to back", t1: session(1, "08:00", "10:00", "", 0), t2: session(1, "10:00", "12:00", "", 0), conflict: false},
+		{name: "other day", t1: session(1, "08:00", "10:00", "", 0), t2: session(2, "08:00", "10:00", "", 0), conflict: false},
+		{name: "odd and even", t1: session(1, "08:00", "10:00", "odd", 0), t2: session(1, "08:00", "10:00", "even", 0), conflict: false},
+		{name: "odd and odd", t1: session(1, "08:00", "10:00", "odd", 0), t2: session(1, "08:00", "10:00", "odd", 0), conflict: true},
+		{name: "even and weekly", t1: session(1, "08:00", "10:00", "even", 0), t2: session(1, "09:00", "10:00", "", 0), conflict: true},
+		{name: "first and second half", t1: session(1, "08:00", "10:00", "", 1), t2: session(1, "08:00", "10:00", "", 2), conflict: false},
+		{name: "second half and second half", t1: session(1, "08:00", "10:00", "", 2), t2: session(1, "08:00", "10:00", "", 2), conflict: true},
+		{name: "first half and whole term", t1: session(1, "08:00", "10:00", "", 1), t2: session(1, "08:00", "10:00", "", 0), conflict: true},
+		{name: "odd first half and even whole term", t1: session(1, "08:00", "10:00", "odd", 1), t2: session(1, "08:00", "10:00", "even", 0), conflict: false},
+		{name: "odd first half and odd second half", t1: session(1, "08:00", "10:00", "odd", 1), t2: session(1, "08:00", "10:00", "odd", 2), conflict: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.conflict, hasTimeConflict([]dto.CourseTimeResponse{tt.t1}, []dto.CourseTimeResponse{tt.t2}))
+			assert.Equal(t, tt.conflict, hasTimeConflict([]dto.CourseTimeResponse{tt.t2}, []dto.CourseTimeResponse{tt.t1}))
+		})
+	}
+}
+
+func TestExamConflictKind(t *testing.T) {
+	loc := tehran(t)
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2025, 6, day, hour, min, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name   string
+		c1, c2 *dto.CourseResponse
+		want   string
+	}{
+		{
+			name: "overlapping exams",
+			c1:   examCourse("a", at(28, 8, 0), 2*time.Hour, loc),
+			c2:   examCourse("b", at(28, 9, 0), 2*time.Hour, loc),
+			want: "overlap",
+		},
+		{
+			name: "back to back exams",
+			c1:   examCourse("a", at(28, 8, 0), 2*time.Hour, loc),
+			c2:   examCourse("b", at(28, 10, 0), 2*time.Hour, loc),
+			want: "same_day",
+		},
+		{
+			name: "same Tehran day, different UTC days",
+			c1:   examCourse("a", at(28, 0, 30), 90*time.Minute, loc),
+			c2:   examCourse("b", at(28, 14, 0), 2*time.Hour, loc),
+			want: "same_day",
+		},
+		{
+			name: "same UTC day, different Tehran days",
+			c1:   examCourse("a", at(27, 22, 0), 90*time.Minute, loc),
+			c2:   examCourse("b", at(28, 1, 0), time.Hour, loc),
+			want: "",
+		},
+		{
+			name: "exam running past midnight",
+			c1:   examCourse("a", at(27, 23, 0), 2*time.Hour, loc),
+			c2:   examCourse("b", at(28, 0, 30), time.Hour, loc),
+			want: "overlap",
+		},
+		{
+			name: "different days",
+			c1:   examCourse("a", at(28, 8, 0), 2*time.Hour, loc),
+			c2:   examCourse("b", at(29, 8, 0), 2*time.Hour, loc),
+			want: "",
+		},
+		{
+			name: "course without an exam",
+			c1:   examCourse("a", at(28, 8, 0), 2*time.Hour, loc),
+			c2:   examCourse("b", time.Time{}, 0, loc),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, examConflictKind(tt.c1, tt.c2))
+			assert.Equal(t, tt.want, examConflictKind(tt.c2, tt.c1))
+		})
+	}
+}
+
+func TestCheckExamConflictsPolicy(t *testing.T) {
+	loc := tehran(t)
+	newCourse := examCourse("new", time.Date(2025, 6, 28, 8, 0, 0, 0, loc), 2*time.Hour, loc)
+	overlapping := examCourse("overlapping", time.Date(2025, 6, 28, 9, 0, 0, 0, loc), 2*time.Hour, loc)
+	sameDay := examCourse("same day", time.Date(2025, 6, 28, 14, 0, 0, 0, loc), 2*time.Hour, loc)
+	noExam := examCourse("thesis", time.Time{}, 0, loc)
+
+	tests := []struct {
+		name     string
+		policy   ExamConflictPolicy
+		selected []*dto.CourseResponse
+		wantErr  string
+		warnings []dto.ExamConflict
+	}{
+		{
+			name:     "overlap rejected",
+			policy:   ExamConflictPolicy{Overlap: "error", SameDay: "warning"},
+			selected: []*dto.CourseResponse{overlapping},
+			wantErr:  "exam time of overlapping",
+		},
+		{
+			name:     "same day rejected",
+			policy:   ExamConflictPolicy{Overlap: "warning", SameDay: "error"},
+			selected: []*dto.CourseResponse{sameDay},
+			wantErr:  "exam day of same day",
+		},
+		{
+			name:     "both warned about",
+			policy:   ExamConflictPolicy{Overlap: "warning", SameDay: "warning"},
+			selected: []*dto.CourseResponse{overlapping, sameDay, noExam},
+			warnings: []dto.ExamConflict{
+				{CourseID: overlapping.ID, CourseName: "overlapping", Kind: "overlap"},
+				{CourseID: sameDay.ID, CourseName: "same day", Kind: "same_day"},
+			},
+		},
+		{
+			name:     "both ignored",
+			policy:   ExamConflictPolicy{Overlap: "ignore", SameDay: "ignore"},
+			selected: []*dto.CourseResponse{overlapping, sameDay},
+		},
+		{
+			name:     "course without an exam never clashes",
+			policy:   ExamConflictPolicy{Overlap: "error", SameDay: "error"},
+			selected: []*dto.CourseResponse{noExam},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduleID := uuid.New()
+			userCourses := new(MockUserCourseRepository)
+			courses := new(MockCourseService)
+			var selected []models.UserCourse
+			for _, course := range tt.selected {
+				selected = append(selected, models.UserCourse{CourseID: course.ID, ScheduleID: scheduleID})
+				courses.On("Get", course.ID).Return(course, nil)
+			}
+			userCourses.On("FindBySchedule", scheduleID).Return(selected, nil)
+
+			s := &userCourseService{
+				userCourseRepo: userCourses,
+				courseService:  courses,
+				examPolicy:     tt.policy,
+				logger:         zap.NewNop(),
+			}
+			warnings, err := s.checkExamConflicts(scheduleID, newCourse, uuid.Nil)
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, errors.ErrConflict)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.warnings, warnings)
+		})
+	}
+}
+
+// sectionFixture is a schedule of two courses: a section of a catalog course
+// with more sections, and another course
+type sectionFixture struct {
+	service    *userCourseService
+	repo       *MockUserCourseRepository
+	courses    *MockCourseService
+	calendar   *MockUniversitySemesterService
+	userID     uuid.UUID
+	scheduleID uuid.UUID
+	selected   *dto.CourseResponse
+	other      *dto.CourseResponse
+}
+
+func newSectionFixture() *sectionFixture {
+	f := &sectionFixture{
+		repo:       new(MockUserCourseRepository),
+		courses:    new(MockCourseService),
+		calendar:   new(MockUniversitySemesterService),
+		userID:     uuid.New(),
+		scheduleID: uuid.New(),
+	}
+	semesterID := uuid.New()
+	f.selected = f.section(uuid.New(), semesterID, 0, "08:00", "10:00")
+	f.other = f.section(uuid.New(), semesterID, 1, "08:00", "10:00")
+
+	f.repo.On("FindBySchedule", f.scheduleID).Return([]models.UserCourse{
+		{UserID: f.userID, CourseID: f.selected.ID, SemesterID: semesterID, ScheduleID: f.scheduleID},
+		{UserID: f.userID, CourseID: f.other.ID, SemesterID: semesterID, ScheduleID: f.scheduleID},
+	}, nil)
+	f.repo.On("ExistsInSchedule", f.scheduleID, f.selected.ID).Return(true, nil)
+	f.repo.On("FindByCatalogCourse", f.scheduleID, f.selected.CatalogCourseID).
+		Return(&models.UserCourse{UserID: f.userID, CourseID: f.selected.ID, SemesterID: semesterID, ScheduleID: f.scheduleID}, nil)
+
+	f.calendar.On("CheckSelectionWindow", uuid.Nil, semesterID).Return(nil)
+	users := new(MockAdminUserService)
+	users.On("Get", mock.Anything, f.userID).Return(&dto.AdminUserResponse{ID: f.userID, Gender: "female"}, nil)
+
+	schedules := &scheduleStore{schedules: []*models.Schedule{
+		{ID: f.scheduleID, UserID: f.userID, SemesterID: semesterID, Name: "Plan A", IsActive: true},
+	}}
+
+	f.service = &userCourseService{
+		userCourseRepo:  f.repo,
+		scheduleRepo:    schedules,
+		courseService:   f.courses,
+		userService:     users,
+		calendarService: f.calendar,
+		logger:          zap.NewNop(),
+	}
+	return f
+}
+
+// section adds a course with one weekly session and no enrollments
+func (f *sectionFixture) section(catalogCourseID, semesterID uuid.UUID, day int, start, end string) *dto.CourseResponse {
+	course := &dto.CourseResponse{
+		ID:                uuid.New(),
+		CatalogCourseID:   catalogCourseID,
+		SemesterID:        semesterID,
+		Name:              "ریاضی عمومی 1",
+		Capacity:          40,
+		GenderRestriction: "mixed",
+		CourseTimes:       []dto.CourseTimeResponse{session(day, start, end, "", 0)},
+	}
+	f.courses.On("Get", course.ID).Return(course, nil)
+	f.repo.On("FindByCourseAndSemester", course.ID, semesterID).Return([]models.UserCourse{}, nil)
+	return course
+}
+
+func TestSwapSectionReplacesSelectedSection(t *testing.T) {
+	f := newSectionFixture()
+	// the same time as the section it replaces
+	replacement := f.section(f.selected.CatalogCourseID, f.selected.SemesterID, 0, "08:00", "10:00")
+	f.repo.On("Swap", f.scheduleID, f.selected.ID, mock.Anything).Return(nil)
+
+	_, err := f.service.SwapSection(f.userID, f.scheduleID, f.selected.ID, replacement.ID)
+	require.NoError(t, err)
+	f.repo.AssertCalled(t, "Swap", f.scheduleID, f.selected.ID, &models.UserCourse{
+		UserID:     f.userID,
+		CourseID:   replacement.ID,
+		SemesterID: replacement.SemesterID,
+		ScheduleID: f.scheduleID,
+	})
+}
+
+func TestSwapSectionRejects(t *testing.T) {
+	f := newSectionFixture()
+	otherCourse := f.section(uuid.New(), f.selected.SemesterID, 2, "08:00", "10:00")
+	clashing := f.section(f.selected.CatalogCourseID, f.selected.SemesterID, 1, "09:00", "11:00")
+	maleOnly := f.section(f.selected.CatalogCourseID, f.selected.SemesterID, 2, "08:00", "10:00")
+	maleOnly.GenderRestriction = "male"
+	notSelected := f.section(uuid.New(), f.selected.SemesterID, 3, "08:00", "10:00")
+	f.repo.On("ExistsInSchedule", f.scheduleID, notSelected.ID).Return(false, nil)
+	notSelectedSibling := f.section(notSelected.CatalogCourseID, notSelected.SemesterID, 4, "08:00", "10:00")
+	otherCampus := f.section(f.selected.CatalogCourseID, f.selected.SemesterID, 3, "08:00", "10:00")
+	otherCampus.UniversityID = uuid.New()
+	f.calendar.On("CheckSelectionWindow", otherCampus.UniversityID, otherCampus.SemesterID).
+		Return(errors.NewForbiddenError("course selection outside the registration and add/drop windows"))
+
+	_, err := f.service.SwapSection(f.userID, f.scheduleID, f.selected.ID, otherCourse.ID)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = f.service.SwapSection(f.userID, f.scheduleID, notSelected.ID, notSelectedSibling.ID)
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+	_, err = f.service.SwapSection(f.userID, f.scheduleID, f.selected.ID, clashing.ID)
+	assert.ErrorIs(t, err, errors.ErrConflict)
+	_, err = f.service.SwapSection(f.userID, f.scheduleID, f.selected.ID, maleOnly.ID)
+	assert.Error(t, err)
+	_, err = f.service.SwapSection(f.userID, f.scheduleID, f.selected.ID, otherCampus.ID)
+	assert.ErrorIs(t, err, errors.ErrForbidden)
+	f.repo.AssertNotCalled(t, "Swap", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAlternativeSections(t *testing.T) {
+	f := newSectionFixture()
+	free := f.section(f.selected.CatalogCourseID, f.selected.SemesterID, 2, "08:00", "10:00")
+	clashing := f.section(f.selected.CatalogCourseID, f.selected.SemesterID, 1, "09:00", "11:00")
+	full := f.section(f.selected.CatalogCourseID, f.selected.SemesterID, 3, "08:00", "10:00")
+	full.Capacity = 0
+	f.courses.On("GetSections", f.selected.ID).Return(&dto.CourseSectionsResponse{
+		CatalogCourseID: f.selected.CatalogCourseID,
+		SemesterID:      f.selected.SemesterID,
+		Sections:        []*dto.CourseResponse{f.selected, free, clashing, full},
+	}, nil)
+
+	alternatives, err := f.service.AlternativeSections(f.userID, f.scheduleID, f.selected.ID)
+	require.NoError(t, err)
+	require.Len(t, alternatives, 1)
+	assert.Equal(t, free.ID, alternatives[0].ID)
+}
+
+func TestValidateActivationRejectsFullSections(t *testing.T) {
+	userID, semesterID, universityID := uuid.New(), uuid.New(), uuid.New()
+	draft := &models.Schedule{ID: uuid.New(), UserID: userID, SemesterID: semesterID, Name: "Plan B"}
+
+	section := func(name string, day int) *dto.CourseResponse {
+		return &dto.CourseResponse{
+			ID: uuid.New(), SemesterID: semesterID, Name: name, Capacity: 1, GenderRestriction: "mixed",
+			CourseTimes: []dto.CourseTimeResponse{session(day, "08:00", "10:00", "", 0)},
+		}
+	}
+	free := section("ریاضی عمومی 1", 0)
+	full := section("فیزیک 1", 1)
+	held := section("شیمی عمومی", 2)
+
+	userCourses := new(MockUserCourseRepository)
+	courses := new(MockCourseService)
+	var selected []models.UserCourse
+	for _, course := range []*dto.CourseResponse{free, full, held} {
+		selected = append(selected, models.UserCourse{UserID: userID, CourseID: course.ID, ScheduleID: draft.ID, Course: models.Course{UniversityID: universityID}})
+		courses.On("Get", course.ID).Return(course, nil)
+	}
+	userCourses.On("FindBySchedule", draft.ID).Return(selected, nil)
+	userCourses.On("FindByCourseAndSemester", free.ID, semesterID).Return([]models.UserCourse{}, nil)
+	// the last seat of full went to another student, while the user's
+	// active schedule holds the seat of held
+	userCourses.On("FindByCourseAndSemester", full.ID, semesterID).Return([]models.UserCourse{{UserID: uuid.New()}}, nil)
+	userCourses.On("FindByCourseAndSemester", held.ID, semesterID).Return([]models.UserCourse{{UserID: userID}}, nil)
+
+	users := new(MockAdminUserService)
+	users.On("Get", mock.Anything, userID).Return(&dto.AdminUserResponse{ID: userID, UniversityID: uuid.New(), Gender: "female"}, nil)
+	calendar := new(MockUniversitySemesterService)
+	calendar.On("CheckSelectionWindow", universityID, semesterID).Return(nil)
+
+	s := &userCourseService{
+		userCourseRepo:  userCourses,
+		scheduleRepo:    &scheduleStore{schedules: []*models.Schedule{draft}},
+		courseService:   courses,
+		userService:     users,
+		calendarService: calendar,
+		examPolicy:      ExamConflictPolicy{Overlap: "error", SameDay: "warning"},
+		logger:          zap.NewNop(),
+	}
+
+	err := s.ValidateActivation(userID, draft.ID)
+	assert.ErrorIs(t, err, errors.ErrConflict)
+	assert.EqualError(t, err, "activating the schedule with فیزیک 1 is conflicting")
+
+	full.Capacity = 2
+	assert.NoError(t, s.ValidateActivation(userID, draft.ID))
+}
+
+func TestSelectionWindowOfCourseUniversity(t *testing.T) {
+	userID, semesterID := uuid.New(), uuid.New()
+	open, closed := uuid.New(), uuid.New()
+	active := &models.Schedule{ID: uuid.New(), UserID: userID, SemesterID: semesterID, Name: "Plan A", IsActive: true}
+	draft := &models.Schedule{ID: uuid.New(), UserID: userID, SemesterID: semesterID, Name: "Plan B"}
+	empty := &models.Schedule{ID: uuid.New(), UserID: userID, SemesterID: semesterID, Name: "Plan C"}
+
+	userCourses := new(MockUserCourseRepository)
+	userCourses.On("FindBySchedule", draft.ID).Return([]models.UserCourse{{ScheduleID: draft.ID, Course: models.Course{UniversityID: open}}}, nil)
+	userCourses.On("FindBySchedule", active.ID).Return([]models.UserCourse{{ScheduleID: active.ID, Course: models.Course{UniversityID: closed}}}, nil)
+	userCourses.On("FindBySchedule", empty.ID).Return([]models.UserCourse{}, nil)
+
+	calendar := new(MockUniversitySemesterService)
+	calendar.On("CheckSelectionWindow", open, semesterID).Return(nil)
+	calendar.On("CheckSelectionWindow", closed, semesterID).
+		Return(errors.NewForbiddenError("course selection outside the registration and add/drop windows"))
+
+	s := &userCourseService{
+		userCourseRepo:  userCourses,
+		scheduleRepo:    &scheduleStore{schedules: []*models.Schedule{active, draft, empty}},
+		calendarService: calendar,
+		logger:          zap.NewNop(),
+	}
+
+	require.NoError(t, s.ValidateSelectionWindow(userID, draft.ID))
+	require.NoError(t, s.ValidateSelectionWindow(userID, empty.ID))
+	assert.ErrorIs(t, s.ValidateSelectionWindow(userID, active.ID), errors.ErrForbidden)
+	// activating the draft drops the courses of the active schedule
+	assert.ErrorIs(t, s.ValidateActivation(userID, draft.ID), errors.ErrForbidden)
+}
@@ -0,0 +1,261 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/armanjr/termustat/api/dto"
+	"github.com/armanjr/termustat/api/handlers"
+	"github.com/armanjr/termustat/api/services"
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// --- Mock CourseService ---
+type MockCourseService struct {
+	services.CourseService
+	mock.Mock
+}
+
+func (m *MockCourseService) ImportFromEngine(universityID, semesterID uuid.UUID, courses []schema.Course) ([]*dto.CourseResponse, error) {
+	args := m.Called(universityID, semesterID, courses)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.CourseResponse), args.Error(1)
+}
+
+func (m *MockCourseService) PreviewImport(opts dto.CourseImportOptions, rows []dto.CourseImportRow, createdBy uuid.UUID) (*dto.CourseImportPreviewResponse, error) {
+	args := m.Called(opts, rows, createdBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.CourseImportPreviewResponse), args.Error(1)
+}
+
+// a report with reordered columns and an English header, which the default
+// profile rejects
+const reorderedReport = `<table>
+<tr class="DTitle"><td>Code</td><td>Title</td><td>Units</td><td>Teacher</td><td>Faculty</td>
+<td>Capacity</td><td>Gender</td><td>Schedule</td></tr>
+<tr><td>1211003_02</td><td>ریاضی عمومی 1</td><td>3</td><td>الیاسی نیره</td><td>12</td>
+<td>40</td><td>مختلط</td><td>درس(ت): شنبه 08:00-10:00 امتحان(1404/04/07) ساعت : 08:00-10:00</td></tr>
+</table>`
+
+func setupCourseHandlerWithProfiles(t *testing.T) (*handlers.CourseHandler, *MockCourseService) {
+	profiles := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(profiles, "tabriz.json"), []byte(`{"columns": {
+		"course_id": ["Code"], "name": ["Title"], "weight": ["Units"], "professor": ["Teacher"],
+		"faculty": ["Faculty"], "capacity": ["Capacity"], "gender": ["Gender"], "schedule": ["Schedule"]
+	}}`), 0644))
+
+	mockService := new(MockCourseService)
+	return handlers.NewCourseHandler(mockService, nil, profiles, zap.NewNop()), mockService
+}
+
+func importRequest(t *testing.T, fields map[string]string, filename, content string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for key, value := range fields {
+		require.NoError(t, writer.WriteField(key, value))
+	}
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, "/courses/import", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestImportCourses_Profile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mockService := setupCourseHandlerWithProfiles(t)
+	universityID, semesterID := uuid.New(), uuid.New()
+
+	mockService.On("ImportFromEngine", universityID, semesterID, mock.MatchedBy(func(courses []schema.Course) bool {
+		return len(courses) == 1 && courses[0].Code == "1211003_02" && courses[0].Professor == "الیاسی نیره"
+	})).Return([]*dto.CourseResponse{{Code: "1211003_02"}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = importRequest(t, map[string]string{
+		"university_id": universityID.String(),
+		"semester_id":   semesterID.String(),
+		"profile":       "tabriz",
+	}, "courses.html", reorderedReport)
+
+	handler.Import(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestImportCourses_DefaultProfileRejectsLayout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mockService := setupCourseHandlerWithProfiles(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = importRequest(t, map[string]string{
+		"university_id": uuid.New().String(),
+		"semester_id":   uuid.New().String(),
+	}, "courses.html", reorderedReport)
+
+	handler.Import(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unrecognized report layout")
+	mockService.AssertNotCalled(t, "ImportFromEngine", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportCourses_UnknownProfile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mockService := setupCourseHandlerWithProfiles(t)
+
+	for _, profile := range []string{"missing", "../tabriz"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = importRequest(t, map[string]string{
+			"university_id": uuid.New().String(),
+			"semester_id":   uuid.New().String(),
+			"profile":       profile,
+		}, "courses.html", reorderedReport)
+
+		handler.Import(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, profile)
+		assert.Contains(t, w.Body.String(), "unknown profile")
+	}
+	mockService.AssertNotCalled(t, "ImportFromEngine", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// a report with a clean row, a row without an exam and a row of unknown gender
+const reportWithIssues = `<table>
+<tr class="DTitle"><td>Code</td><td>Title</td><td>Units</td><td>Teacher</td><td>Faculty</td>
+<td>Capacity</td><td>Gender</td><td>Schedule</td></tr>
+<tr><td>1211003_02</td><td>ریاضی عمومی 1</td><td>3</td><td>الیاسی نیره</td><td>12</td>
+<td>40</td><td>مختلط</td><td>درس(ت): شنبه 08:00-10:00 امتحان(1404/04/07) ساعت : 08:00-10:00</td></tr>
+<tr><td>1211004_01</td><td>ریاضی عمومی 2</td><td>3</td><td>الیاسی نیره</td><td>12</td>
+<td>40</td><td>مختلط</td><td>درس(ت): دوشنبه 08:00-10:00</td></tr>
+<tr><td>1211005_01</td><td>معادلات دیفرانسیل</td><td>3</td><td>الیاسی نیره</td><td>12</td>
+<td>40</td><td>نامعلوم</td><td>درس(ت): دوشنبه 08:00-10:00</td></tr>
+</table>`
+
+func TestPreviewImport_ListsRowIssues(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mockService := setupCourseHandlerWithProfiles(t)
+	userID := uuid.New()
+
+	var rows []dto.CourseImportRow
+	mockService.On("PreviewImport", mock.Anything, mock.Anything, userID).
+		Run(func(args mock.Arguments) { rows = args.Get(1).([]dto.CourseImportRow) }).
+		Return(&dto.CourseImportPreviewResponse{Valid: false}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", userID.String())
+	c.Request = importRequest(t, map[string]string{
+		"university_id": uuid.New().String(),
+		"semester_id":   uuid.New().String(),
+		"profile":       "tabriz",
+	}, "courses.html", reportWithIssues)
+
+	handler.PreviewImport(c)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var preview dto.CourseImportPreviewResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &preview))
+	assert.False(t, preview.Valid)
+
+	require.Len(t, rows, 3)
+	assert.Equal(t, []int{2, 3, 4}, []int{rows[0].Index, rows[1].Index, rows[2].Index})
+	assert.NotNil(t, rows[0].Course)
+	assert.Empty(t, rows[0].Errors)
+	assert.Empty(t, rows[0].Warnings)
+
+	assert.NotNil(t, rows[1].Course)
+	assert.Empty(t, rows[1].Errors)
+	assert.Equal(t, []string{"no exam listed"}, rows[1].Warnings)
+
+	assert.Nil(t, rows[2].Course)
+	assert.Equal(t, "1211005_01", rows[2].Code)
+	assert.Equal(t, "معادلات دیفرانسیل", rows[2].Name)
+	require.Len(t, rows[2].Errors, 1)
+	assert.Contains(t, rows[2].Errors[0], "unknown gender")
+}
+
+func TestPreviewImport_NumbersJSONRowsFromOne(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mockService := setupCourseHandlerWithProfiles(t)
+	userID := uuid.New()
+
+	var rows []dto.CourseImportRow
+	mockService.On("PreviewImport", mock.Anything, mock.Anything, userID).
+		Run(func(args mock.Arguments) { rows = args.Get(1).([]dto.CourseImportRow) }).
+		Return(&dto.CourseImportPreviewResponse{Valid: true}, nil)
+
+	course := func(code string) schema.Course {
+		return schema.Course{
+			Code: code, Name: "ریاضی عمومی 1", Weight: 3, Capacity: 40,
+			Gender: schema.GenderMixed, Professor: "الیاسی نیره", Faculty: "12",
+		}
+	}
+	document, err := json.Marshal(schema.Document{
+		Version: schema.Version,
+		Courses: []schema.Course{course("1211003_01"), course("1211003_02")},
+	})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", userID.String())
+	c.Request = importRequest(t, map[string]string{
+		"university_id": uuid.New().String(),
+		"semester_id":   uuid.New().String(),
+	}, "courses.json", string(document))
+
+	handler.PreviewImport(c)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, rows, 2)
+	assert.Equal(t, 1, rows[0].Index)
+	assert.Equal(t, "1211003_01", rows[0].Code)
+	assert.Equal(t, 2, rows[1].Index)
+	assert.Equal(t, "1211003_02", rows[1].Code)
+}
+
+func TestImportCourses_RejectsRowErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mockService := setupCourseHandlerWithProfiles(t)
+
+	for _, mode := range []string{"create", "sync"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = importRequest(t, map[string]string{
+			"university_id": uuid.New().String(),
+			"semester_id":   uuid.New().String(),
+			"profile":       "tabriz",
+			"mode":          mode,
+		}, "courses.html", reportWithIssues)
+
+		handler.Import(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, mode)
+		assert.Contains(t, w.Body.String(), "1 rows of the report have errors (row 4: unknown gender")
+	}
+	mockService.AssertNotCalled(t, "ImportFromEngine", mock.Anything, mock.Anything, mock.Anything)
+}
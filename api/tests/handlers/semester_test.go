@@ -48,6 +48,14 @@ func (m *MockSemesterService) GetAll() ([]dto.SemesterResponse, error) {
 	return args.Get(0).([]dto.SemesterResponse), args.Error(1)
 }
 
+func (m *MockSemesterService) GetByCode(code string) (*dto.SemesterResponse, error) {
+	args := m.Called(code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.SemesterResponse), args.Error(1)
+}
+
 func (m *MockSemesterService) Update(id uuid.UUID, req *dto.UpdateSemesterRequest) (*dto.SemesterResponse, error) {
 	args := m.Called(id, req)
 	if args.Get(0) == nil {
@@ -119,7 +127,7 @@ func TestCreateSemester_InvalidInput(t *testing.T) {
 
 	reqBody := dto.CreateSemesterRequest{
 		Year: 1404,
-		Term: "summer", // Invalid term
+		Term: "winter", // Invalid term
 	}
 
 	jsonBody, _ := json.Marshal(reqBody)
@@ -242,6 +250,54 @@ func TestGetSemester_InvalidUUID(t *testing.T) {
 	assert.Equal(t, "Invalid semester ID", respBody["error"])
 }
 
+func TestGetSemesterByCode_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mockService := setupSemesterHandlerWithMocks(t)
+
+	expectedResponse := &dto.SemesterResponse{
+		ID:    uuid.New(),
+		Year:  1403,
+		Term:  "summer",
+		Code:  "4033",
+		Label: "1403-1404 ترم تابستان",
+	}
+
+	mockService.On("GetByCode", "4033").Return(expectedResponse, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "code", Value: "4033"}}
+	c.Request, _ = http.NewRequest(http.MethodGet, "/semesters/code/4033", nil)
+
+	handler.GetByCode(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var actualResp dto.SemesterResponse
+	err := json.Unmarshal(w.Body.Bytes(), &actualResp)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponse.ID, actualResp.ID)
+	assert.Equal(t, expectedResponse.Code, actualResp.Code)
+	assert.Equal(t, expectedResponse.Label, actualResp.Label)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetSemesterByCode_Invalid(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mockService := setupSemesterHandlerWithMocks(t)
+
+	mockService.On("GetByCode", "40x1").Return(nil, errors.NewValidationError("term code"))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{gin.Param{Key: "code", Value: "40x1"}}
+	c.Request, _ = http.NewRequest(http.MethodGet, "/semesters/code/40x1", nil)
+
+	handler.GetByCode(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
 func TestGetAllSemesters_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	handler, mockService := setupSemesterHandlerWithMocks(t)
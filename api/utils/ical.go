@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icalLocalLayout = "20060102T150405"
+	icalUTCLayout   = "20060102T150405Z"
+	icalLineLength  = 75
+)
+
+// ICalEvent is a VEVENT. Start and End are written as local times in the
+// calendar's timezone; RRule is written as is when set.
+type ICalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	RRule       string
+}
+
+// ICalendar builds an RFC 5545 calendar whose events use a single timezone
+type ICalendar struct {
+	builder strings.Builder
+	loc     *time.Location
+	stamp   time.Time
+}
+
+// NewICalendar starts a calendar in loc. The VTIMEZONE describes loc by its
+// offset at ref, which is exact for zones without daylight saving time such
+// as Asia/Tehran since 2022.
+func NewICalendar(name string, loc *time.Location, ref time.Time) *ICalendar {
+	c := &ICalendar{loc: loc, stamp: time.Now().UTC()}
+
+	_, offset := ref.In(loc).Zone()
+	tzOffset := formatICalOffset(offset)
+
+	c.line("BEGIN:VCALENDAR")
+	c.line("VERSION:2.0")
+	c.line("PRODID:-//Termustat//Schedule//EN")
+	c.line("CALSCALE:GREGORIAN")
+	c.line("METHOD:PUBLISH")
+	c.line("X-WR-CALNAME:" + EscapeICalText(name))
+	c.line("X-WR-TIMEZONE:" + loc.String())
+	c.line("BEGIN:VTIMEZONE")
+	c.line("TZID:" + loc.String())
+	c.line("BEGIN:STANDARD")
+	c.line("DTSTART:19700101T000000")
+	c.line("TZOFFSETFROM:" + tzOffset)
+	c.line("TZOFFSETTO:" + tzOffset)
+	c.line("TZNAME:" + tzOffset)
+	c.line("END:STANDARD")
+	c.line("END:VTIMEZONE")
+	return c
+}
+
+func (c *ICalendar) AddEvent(event ICalEvent) {
+	c.line("BEGIN:VEVENT")
+	c.line("UID:" + event.UID)
+	c.line("DTSTAMP:" + c.stamp.Format(icalUTCLayout))
+	c.line(fmt.Sprintf("DTSTART;TZID=%s:%s", c.loc, event.Start.In(c.loc).Format(icalLocalLayout)))
+	c.line(fmt.Sprintf("DTEND;TZID=%s:%s", c.loc, event.End.In(c.loc).Format(icalLocalLayout)))
+	if event.RRule != "" {
+		c.line("RRULE:" + event.RRule)
+	}
+	c.line("SUMMARY:" + EscapeICalText(event.Summary))
+	if event.Location != "" {
+		c.line("LOCATION:" + EscapeICalText(event.Location))
+	}
+	if event.Description != "" {
+		c.line("DESCRIPTION:" + EscapeICalText(event.Description))
+	}
+	c.line("END:VEVENT")
+}
+
+// Bytes closes the calendar and returns it
+func (c *ICalendar) Bytes() []byte {
+	c.line("END:VCALENDAR")
+	return []byte(c.builder.String())
+}
+
+// ICalUntil formats the end of an RRULE, which must be in UTC when DTSTART has a TZID
+func ICalUntil(t time.Time) string {
+	return t.UTC().Format(icalUTCLayout)
+}
+
+// EscapeICalText escapes a TEXT value
+func EscapeICalText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// line writes a content line folded at 75 octets without splitting UTF-8
+// sequences, as Persian text would otherwise be corrupted
+func (c *ICalendar) line(content string) {
+	limit := icalLineLength
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		c.builder.WriteString(content[:cut])
+		c.builder.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space that counts towards the limit
+		limit = icalLineLength - 1
+	}
+	c.builder.WriteString(content)
+	c.builder.WriteString("\r\n")
+}
+
+func formatICalOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeICalText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "plain text", text: "ریاضی عمومی 1", want: "ریاضی عمومی 1"},
+		{name: "separators", text: "a;b,c", want: `a\;b\,c`},
+		{name: "backslash first", text: `a\;`, want: `a\\\;`},
+		{name: "newlines", text: "a\nb\r\nc", want: `a\nb\nc`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeICalText(tt.text); got != tt.want {
+				t.Errorf("EscapeICalText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestICalendarFoldsLines(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Tehran")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two-byte Persian letters after an odd-length prefix put a rune across
+	// every 75th octet
+	summary := "x" + strings.Repeat("برنامه‌نویسی پیشرفته ", 10)
+	calendar := NewICalendar("Plan A", loc, time.Date(2025, 9, 23, 0, 0, 0, 0, loc))
+	calendar.AddEvent(ICalEvent{
+		UID:     "1@termustat",
+		Summary: summary,
+		Start:   time.Date(2025, 9, 23, 8, 0, 0, 0, loc),
+		End:     time.Date(2025, 9, 23, 10, 0, 0, 0, loc),
+	})
+	text := string(calendar.Bytes())
+
+	if !strings.HasSuffix(text, "\r\n") {
+		t.Fatalf("calendar does not end with CRLF")
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\r\n"), "\r\n")
+	folded := 0
+	for _, line := range lines {
+		if len(line) > icalLineLength {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a rune: %q", line)
+		}
+		if strings.HasPrefix(line, " ") {
+			folded++
+		}
+	}
+	if folded == 0 {
+		t.Errorf("long summary was not folded")
+	}
+
+	unfolded := strings.ReplaceAll(text, "\r\n ", "")
+	if !strings.Contains(unfolded, "\r\nSUMMARY:"+summary+"\r\n") {
+		t.Errorf("unfolded calendar lost the summary:\n%s", unfolded)
+	}
+	if !strings.Contains(unfolded, "\r\nDTSTART;TZID=Asia/Tehran:20250923T080000\r\n") {
+		t.Errorf("start not written in the calendar's timezone:\n%s", unfolded)
+	}
+	if !strings.Contains(unfolded, "\r\nTZOFFSETTO:+0330\r\n") {
+		t.Errorf("timezone offset missing:\n%s", unfolded)
+	}
+}
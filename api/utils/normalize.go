@@ -1,32 +1,60 @@
 package utils
 
 import (
-	"golang.org/x/text/unicode/norm"
+	"github.com/armanjr/termustat/engine/parser"
+	"sort"
 	"strings"
 )
 
-var arabicToPersian = map[rune]rune{
-	'ك': 'ک',
-	'ي': 'ی',
-	'ۀ': 'ه',
-	'ۂ': 'ه',
-	'ۃ': 'ه',
-	'ة': 'ه',
+var persianDigits = strings.NewReplacer(
+	"0", "۰", "1", "۱", "2", "۲", "3", "۳", "4", "۴",
+	"5", "۵", "6", "۶", "7", "۷", "8", "۸", "9", "۹",
+)
+
+// professorTitles are honorifics that some exports put before a name
+var professorTitles = map[string]bool{
+	"دکتر":    true,
+	"مهندس":   true,
+	"استاد":   true,
+	"پروفسور": true,
+	"آقای":    true,
+	"خانم":    true,
+	"سرکار":   true,
+	"جناب":    true,
+	"dr":      true,
+	"prof":    true,
+	"eng":     true,
+	"mr":      true,
+	"mrs":     true,
+	"ms":      true,
 }
 
 func NormalizeProfessor(name string) string {
-	var builder strings.Builder
-	builder.Grow(len(name)) // Optimize memory allocation
+	return NormalizeText(name)
+}
 
-	for _, r := range name {
-		if p, ok := arabicToPersian[r]; ok {
-			builder.WriteRune(p)
-		} else {
-			builder.WriteRune(r)
+// ProfessorMatchKey reduces a professor's name to what identifies the person:
+// titles dropped and each word as its SearchKey, sorted so that swapped first
+// and last names match
+func ProfessorMatchKey(name string) string {
+	var words []string
+	for _, word := range strings.Fields(NormalizeSearch(name)) {
+		word = strings.Trim(word, ".")
+		if word == "" || professorTitles[word] {
+			continue
 		}
+		words = append(words, SearchKey(word))
 	}
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
 
-	normalized := norm.NFC.String(builder.String())
+// NormalizeText maps Arabic letter variants to Persian and collapses whitespace
+func NormalizeText(text string) string {
+	return parser.NormalizeName(text)
+}
 
-	return strings.Join(strings.Fields(normalized), " ")
+// PersianDigits replaces ASCII digits with Persian ones for display
+func PersianDigits(text string) string {
+	return persianDigits.Replace(text)
 }
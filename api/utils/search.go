@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"github.com/armanjr/termustat/engine/parser"
+	"strings"
+	"unicode"
+)
+
+// searchLetters completes the Arabic to Persian mapping for search; runes
+// mapped to -1 are dropped. The search_normalize SQL function mirrors it.
+var searchLetters = map[rune]rune{
+	'ى':      'ی',
+	'أ':      'ا',
+	'إ':      'ا',
+	'ؤ':      'و',
+	'\u064b': -1, // tanween and harakat
+	'\u064c': -1,
+	'\u064d': -1,
+	'\u064e': -1,
+	'\u064f': -1,
+	'\u0650': -1,
+	'\u0651': -1,
+	'\u0652': -1,
+	'\u0670': -1,
+}
+
+// searchHomophones folds letters that sound alike, so misspellings and
+// Finglish land on the same key. The search_key SQL function mirrors it.
+var searchHomophones = map[rune]rune{
+	'ث': 'س',
+	'ص': 'س',
+	'ذ': 'ز',
+	'ض': 'ز',
+	'ظ': 'ز',
+	'ط': 'ت',
+	'ح': 'ه',
+	'غ': 'ق',
+	'آ': -1, // long vowels Finglish rarely spells consistently
+	'ا': -1,
+	'ع': -1,
+	' ': -1,
+}
+
+var finglishDigraphs = strings.NewReplacer(
+	"kh", "خ", "sh", "ش", "ch", "چ", "zh", "ژ", "gh", "ق", "ph", "ف", "th", "ت",
+	"oo", "و", "ou", "و", "ee", "ی", "ei", "ی", "ey", "ی",
+)
+
+var finglishLetters = map[rune]string{
+	'b': "ب", 'p': "پ", 't': "ت", 'j': "ج", 'd': "د", 'r': "ر", 'z': "ز",
+	's': "س", 'f': "ف", 'q': "ق", 'k': "ک", 'c': "ک", 'g': "گ", 'l': "ل",
+	'm': "م", 'n': "ن", 'v': "و", 'w': "و", 'h': "ه", 'y': "ی", 'i': "ی",
+	'u': "و", 'x': "کس", 'a': "", 'e': "", 'o': "",
+}
+
+// NormalizeSearch prepares text for the full-text index: the engine's digit
+// and letter cleanup, the professor name mapping, lower case and no diacritics
+func NormalizeSearch(text string) string {
+	text = strings.ToLower(NormalizeText(parser.CleanText(text)))
+	return strings.Map(func(r rune) rune {
+		if mapped, ok := searchLetters[r]; ok {
+			return mapped
+		}
+		return r
+	}, text)
+}
+
+// SearchTerms splits normalized text into the words of a full-text query
+func SearchTerms(text string) []string {
+	return strings.FieldsFunc(NormalizeSearch(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// SearchKey reduces text to the skeleton matched by trigram search: no
+// spaces, ZWNJ or long vowels, homophones folded and doubled letters merged
+func SearchKey(text string) string {
+	var builder strings.Builder
+	var last rune
+	for _, r := range NormalizeSearch(text) {
+		if mapped, ok := searchHomophones[r]; ok {
+			r = mapped
+		}
+		if r == -1 || r == last {
+			continue
+		}
+		builder.WriteRune(r)
+		last = r
+	}
+	return builder.String()
+}
+
+// FinglishKey transliterates Latin-script Persian, e.g. "riazi omoomi", to
+// the SearchKey of its Persian spelling. Short vowels are dropped as Persian
+// does not write them, except a final e which is written as ه.
+func FinglishKey(text string) string {
+	var builder strings.Builder
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		if strings.HasSuffix(word, "e") && len(word) > 1 {
+			word = strings.TrimSuffix(word, "e") + "ه"
+		}
+		for _, r := range finglishDigraphs.Replace(word) {
+			if persian, ok := finglishLetters[r]; ok {
+				builder.WriteString(persian)
+			} else {
+				builder.WriteRune(r)
+			}
+		}
+		builder.WriteRune(' ')
+	}
+	return SearchKey(builder.String())
+}
+
+// HasLatin reports whether text contains Latin letters
+func HasLatin(text string) bool {
+	for _, r := range text {
+		if unicode.Is(unicode.Latin, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Similarity is the share of character trigrams two texts have in common,
+// counted per word the way pg_trgm does
+func Similarity(a, b string) float64 {
+	return NewTrigrams(a).Similarity(NewTrigrams(b))
+}
+
+// Trigrams is the set of character trigrams of a text. Comparing many texts
+// with each other, build each set once and compare the sets.
+type Trigrams map[string]bool
+
+func NewTrigrams(text string) Trigrams {
+	set := make(Trigrams)
+	for _, word := range strings.Fields(text) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			set[string(runes[i:i+3])] = true
+		}
+	}
+	return set
+}
+
+// Similarity is the share of trigrams the two sets have in common
+func (t Trigrams) Similarity(other Trigrams) float64 {
+	if len(t) == 0 || len(other) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for trigram := range t {
+		if other[trigram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(t)+len(other)-shared)
+}
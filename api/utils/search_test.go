@@ -0,0 +1,65 @@
+package utils
+
+import "testing"
+
+func TestSearchKey(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "folds Arabic letters and digits", text: "رياضي ۱", want: "ریزی1"},
+		{name: "ignores ZWNJ and spaces", text: "برنامه‌نویسی پیشرفته", want: "برنمهنویسیپیشرفته"},
+		{name: "merges doubled letters", text: "محمّد", want: "مهمد"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SearchKey(tt.text); got != tt.want {
+				t.Errorf("SearchKey(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinglishKey(t *testing.T) {
+	tests := []struct {
+		finglish string
+		persian  string
+	}{
+		{finglish: "riazi omoomi", persian: "ریاضی عمومی"},
+		{finglish: "barname nevisi", persian: "برنامه نویسی"},
+		{finglish: "mohammad", persian: "محمد"},
+		{finglish: "shimi", persian: "شیمی"},
+		{finglish: "Fizik 1", persian: "فیزیک ۱"},
+		{finglish: "adabiat", persian: "ادبیات"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.finglish, func(t *testing.T) {
+			if got, want := FinglishKey(tt.finglish), SearchKey(tt.persian); got != want {
+				t.Errorf("FinglishKey(%q) = %q, want %q", tt.finglish, got, want)
+			}
+		})
+	}
+}
+
+func TestProfessorMatchKey(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+	}{
+		{name: "drops titles", a: "دکتر علی رضایی", b: "علی رضایی"},
+		{name: "ignores word order", a: "رضایی علی", b: "علی  رضائي"},
+		{name: "drops Latin titles", a: "Dr. Ali Rezaei", b: "ali rezaei"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if a, b := ProfessorMatchKey(tt.a), ProfessorMatchKey(tt.b); a != b {
+				t.Errorf("ProfessorMatchKey(%q) = %q, ProfessorMatchKey(%q) = %q", tt.a, a, tt.b, b)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Package export writes parsed Golestan reports in the formats the engine
+// CLI produces: JSON and JSON Lines of the typed schema, CSV of the raw
+// records and SQL for the API's PostgreSQL schema.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/armanjr/termustat/engine/parser"
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/google/uuid"
+	"io"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatJSONL Format = "jsonl"
+	FormatCSV   Format = "csv"
+	FormatSQL   Format = "sql"
+)
+
+// Formats lists the supported formats in the order the CLI documents them
+var Formats = []Format{FormatJSON, FormatJSONL, FormatCSV, FormatSQL}
+
+// ParseFormat resolves a format name, case-insensitively
+func ParseFormat(name string) (Format, error) {
+	for _, format := range Formats {
+		if strings.EqualFold(name, string(format)) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q", name)
+}
+
+// Ext is the file extension of the format, without the dot
+func (f Format) Ext() string {
+	return string(f)
+}
+
+// Writer streams the reports of one or more source files. Source names the
+// file a report came from, without its extension. Rows with errors are left
+// out of every format but CSV, which lists the raw records. Close must be
+// called once all sources are written; it flushes formats that need the
+// whole output, such as a JSON document.
+type Writer interface {
+	Write(source string, report *parser.Report) error
+	Close() error
+}
+
+// Options configures formats that need more than the reports. Only SQL uses
+// them, to import the courses into a university's semester.
+type Options struct {
+	// UniversityID names an existing university. When it is uuid.Nil, the
+	// university is upserted from UniversityName with an ID derived from it.
+	UniversityID     uuid.UUID
+	UniversityName   string
+	UniversityNameFa string
+	Year             int    // Jalali academic year of the semester
+	Term             string // fall, spring or summer
+	Timezone         string // of exam times; Asia/Tehran when empty
+}
+
+// NewWriter returns a Writer producing format on w
+func NewWriter(format Format, w io.Writer, opts Options) (Writer, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonWriter{w: w, courses: []schema.Course{}}, nil
+	case FormatJSONL:
+		return &jsonlWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatSQL:
+		return newSQLWriter(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// jsonWriter writes a schema.Document of the courses of all sources
+type jsonWriter struct {
+	w       io.Writer
+	courses []schema.Course
+}
+
+func (j *jsonWriter) Write(_ string, report *parser.Report) error {
+	j.courses = append(j.courses, report.Courses()...)
+	return nil
+}
+
+func (j *jsonWriter) Close() error {
+	data, err := json.MarshalIndent(schema.Document{Version: schema.Version, Courses: j.courses}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %w", err)
+	}
+	_, err = j.w.Write(append(data, '\n'))
+	return err
+}
+
+// jsonlLine is a course of the JSON Lines format, which has no document to
+// carry the version
+type jsonlLine struct {
+	Version int    `json:"version"`
+	Source  string `json:"source"`
+	schema.Course
+}
+
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+func (j *jsonlWriter) Write(source string, report *parser.Report) error {
+	for _, course := range report.Courses() {
+		if err := j.encoder.Encode(jsonlLine{Version: schema.Version, Source: source, Course: course}); err != nil {
+			return fmt.Errorf("error encoding course %s: %w", course.Code, err)
+		}
+	}
+	return nil
+}
+
+func (j *jsonlWriter) Close() error {
+	return nil
+}
+
+// csvColumns are the CSV header, named after the JSON fields of a record
+var csvColumns = []string{
+	"course_id", "name", "weight", "capacity", "gender", "professor", "faculty",
+	"group", "practical_weight", "enrolled", "waiting_list", "notes",
+	"cross_campus", "emergency_drop",
+	"time1", "time2", "time3", "time4", "time5",
+	"location1", "location2", "location3", "location4", "location5",
+	"time_exam", "date_exam", "date_exam_gregorian",
+}
+
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (c *csvWriter) Write(_ string, report *parser.Report) error {
+	if !c.headerWritten {
+		if err := c.w.Write(csvColumns); err != nil {
+			return err
+		}
+		c.headerWritten = true
+	}
+
+	for _, r := range report.Records() {
+		err := c.w.Write([]string{
+			r.CourseID, r.Name, r.Weight, r.Capacity, r.Gender, r.Professor, r.Faculty,
+			r.Group, r.PracticalWeight, r.Enrolled, r.WaitingList, r.Notes,
+			r.CrossCampus, r.EmergencyDrop,
+			r.Time1, r.Time2, r.Time3, r.Time4, r.Time5,
+			r.Location1, r.Location2, r.Location3, r.Location4, r.Location5,
+			r.TimeExam, r.DateExam, r.DateExamGregorian,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
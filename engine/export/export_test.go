@@ -0,0 +1,121 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/armanjr/termustat/engine/export"
+	"github.com/armanjr/termustat/engine/parser"
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rows = []parser.Row{
+	{
+		Record: &parser.Record{CourseID: "1211003_01", Name: "ریاضی عمومی 1", Weight: "4", Capacity: "45"},
+		Course: &schema.Course{Code: "1211003_01", Name: "ریاضی عمومی 1", Weight: 4, Capacity: 45, Gender: schema.GenderMixed},
+	},
+	{
+		Record: &parser.Record{CourseID: "1211005_01", Name: "ریاضی، عمومی \"3\"", Weight: "4", Capacity: "50"},
+		Course: &schema.Course{Code: "1211005_01", Name: "ریاضی، عمومی \"3\"", Weight: 4, Capacity: 50, Gender: schema.GenderMixed},
+	},
+}
+
+func reportOf(rows ...parser.Row) *parser.Report {
+	return &parser.Report{Rows: rows}
+}
+
+func write(t *testing.T, format export.Format) string {
+	var buf bytes.Buffer
+	writer, err := export.NewWriter(format, &buf, export.Options{})
+	require.NoError(t, err)
+	require.NoError(t, writer.Write("math", reportOf(rows[0])))
+	require.NoError(t, writer.Write("math2", reportOf(rows[1])))
+	require.NoError(t, writer.Close())
+	return buf.String()
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := export.ParseFormat("JSONL")
+	assert.NoError(t, err)
+	assert.Equal(t, export.FormatJSONL, format)
+
+	_, err = export.ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestJSONCombinesSources(t *testing.T) {
+	var document schema.Document
+	require.NoError(t, json.Unmarshal([]byte(write(t, export.FormatJSON)), &document))
+	assert.Equal(t, schema.Version, document.Version)
+	require.Len(t, document.Courses, 2)
+	assert.Equal(t, 4, document.Courses[1].Weight)
+}
+
+func TestJSONLWritesOneCoursePerLine(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(write(t, export.FormatJSONL)), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[1], `"version":1,"source":"math2","code":"1211005_01"`)
+}
+
+func TestCSVWritesHeaderOnce(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(write(t, export.FormatCSV)), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "course_id,name,"))
+	assert.Contains(t, lines[2], `"ریاضی، عمومی ""3"""`)
+}
+
+func TestSQLUpsertsWithStableIDs(t *testing.T) {
+	opts := export.Options{UniversityName: "University of Tabriz", Year: 1403, Term: "fall"}
+	valid := parser.Row{Index: 2, Course: &schema.Course{
+		Code: "1211003_01", Group: "01", Name: "ریاضی 'عمومی' 1", Weight: 4, Capacity: 45,
+		Gender: schema.GenderMixed, Professor: "الياسی  نيره", Faculty: "12",
+		Sessions: []schema.Session{{
+			Day: 1, Start: "10:00", End: "12:00", Location: "109- طبقه همکف", Room: "109", WeekParity: schema.WeekParityOdd,
+		}},
+		Notes: "ویژه\tورودی\\1403\nجدید",
+		Exam:  &schema.Exam{Date: "2025-06-28", DateJalali: "1404/04/07", Start: "08:00", End: "10:00"},
+	}}
+	invalid := parser.Row{
+		Index:  3,
+		Record: &parser.Record{CourseID: "1211005_01", Weight: "چهار"},
+		Issues: []parser.Issue{{Severity: parser.SeverityError, Code: parser.IssueInvalidNumber, Message: `weight "چهار" is not a number`}},
+	}
+
+	generate := func() string {
+		var buf bytes.Buffer
+		writer, err := export.NewWriter(export.FormatSQL, &buf, opts)
+		require.NoError(t, err)
+		require.NoError(t, writer.Write("math", reportOf(valid, invalid)))
+		require.NoError(t, writer.Close())
+		return buf.String()
+	}
+
+	out := generate()
+	assert.Equal(t, out, generate())
+	assert.Contains(t, out, "BEGIN;")
+	assert.Contains(t, out, "COPY import_courses (id, faculty, professor, catalog_code, code, name,")
+	assert.Contains(t, out, "\tریاضی 'عمومی' 1\t")
+	assert.Contains(t, out, "\tالیاسی نیره\n")
+	assert.Contains(t, out, "\t1211003\t1211003_01\t")
+	assert.Contains(t, out, "\tویژه\\tورودی\\\\1403\\nجدید\t")
+	assert.Contains(t, out, "\t2025-06-28 08:00\t2025-06-28 10:00\n")
+	assert.Contains(t, out, "\t1\t10:00\t12:00\t109\t109- طبقه همکف\todd\t0\n")
+	assert.Contains(t, out, "ON CONFLICT (university_id, semester_id, code) DO UPDATE SET")
+	assert.Contains(t, out, `-- skipped math row 3 1211005_01: weight "چهار" is not a number`)
+	assert.NotContains(t, out, "\t1211005_01\t")
+
+	// values are only ever copied, never part of the statements
+	statements := out[strings.LastIndex(out, "\\.\n"):]
+	assert.Contains(t, statements, "v.exam_start AT TIME ZONE p.timezone")
+	assert.NotContains(t, statements, "ریاضی")
+	assert.NotContains(t, statements, "1211003")
+}
+
+func TestSQLNeedsSemester(t *testing.T) {
+	_, err := export.NewWriter(export.FormatSQL, &bytes.Buffer{}, export.Options{UniversityName: "Tabriz"})
+	assert.Error(t, err)
+}
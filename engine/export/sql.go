@@ -0,0 +1,442 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"github.com/armanjr/termustat/engine/parser"
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/google/uuid"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// namespace derives the IDs of imported rows from their natural keys, so
+// importing a report again updates its rows instead of duplicating them
+var namespace = uuid.MustParse("071382df-2f7e-4c15-b708-f010f9567862")
+
+// sqlWriter imports the courses of all sources into the API's schema in one
+// transaction: the university and semester, then the faculties, professors
+// and catalog courses the courses name, then the courses and their times.
+// The output is a psql script that copies the rows into staging tables and
+// upserts them from there, so running it again is safe.
+type sqlWriter struct {
+	w          io.Writer
+	opts       Options
+	university uuid.UUID
+	courses    []*sqlCourse
+	byCode     map[string]int
+	skipped    []string
+}
+
+// sqlCourse is a course with the keys the API derives from it: the catalog
+// code, its code without the group, and the normalized professor name
+type sqlCourse struct {
+	schema.Course
+	catalogCode string
+	professor   string
+}
+
+func newSQLWriter(w io.Writer, opts Options) (*sqlWriter, error) {
+	if schema.TermNumber(opts.Term) == 0 || opts.Year < 1000 || opts.Year > 3000 {
+		return nil, errors.New("sql output needs the semester's year and a fall, spring or summer term")
+	}
+
+	university := opts.UniversityID
+	if university == uuid.Nil {
+		if strings.TrimSpace(opts.UniversityName) == "" {
+			return nil, errors.New("sql output needs a university ID or name")
+		}
+		university = stableID("university", strings.ToLower(strings.TrimSpace(opts.UniversityName)))
+	}
+	if opts.UniversityNameFa == "" {
+		opts.UniversityNameFa = opts.UniversityName
+	}
+	if opts.Timezone == "" {
+		opts.Timezone = "Asia/Tehran"
+	}
+
+	return &sqlWriter{
+		w:          w,
+		opts:       opts,
+		university: university,
+		byCode:     make(map[string]int),
+	}, nil
+}
+
+func (s *sqlWriter) Write(source string, report *parser.Report) error {
+	for _, row := range report.Rows {
+		if row.Course == nil {
+			var code string
+			if row.Record != nil {
+				code = " " + row.Record.CourseID
+			}
+			s.skipped = append(s.skipped, fmt.Sprintf("%s row %d%s: %s", source, row.Index, code, firstError(row)))
+			continue
+		}
+
+		catalogCode, _ := schema.SplitCourseCode(row.Course.Code)
+		course := &sqlCourse{
+			Course:      *row.Course,
+			catalogCode: catalogCode,
+			professor:   parser.NormalizeName(row.Course.Professor),
+		}
+
+		// a later report of the same section replaces the earlier one
+		if i, ok := s.byCode[course.Code]; ok {
+			s.courses[i] = course
+			continue
+		}
+		s.byCode[course.Code] = len(s.courses)
+		s.courses = append(s.courses, course)
+	}
+	return nil
+}
+
+func (s *sqlWriter) Close() error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- Courses of %d %s imported by the Termustat engine\n", s.opts.Year, s.opts.Term)
+	for _, skipped := range s.skipped {
+		fmt.Fprintf(&b, "-- skipped %s\n", strings.Join(strings.Fields(skipped), " "))
+	}
+	if len(s.courses) == 0 {
+		_, err := io.WriteString(s.w, b.String())
+		return err
+	}
+
+	b.WriteString("\nBEGIN;\n")
+	s.copyImport(&b)
+	s.copyFaculties(&b)
+	s.copyProfessors(&b)
+	s.copyCatalogCourses(&b)
+	s.copyCourses(&b)
+	s.copyCourseTimes(&b)
+	b.WriteString(upsertSQL)
+	b.WriteString("\nCOMMIT;\n")
+
+	_, err := io.WriteString(s.w, b.String())
+	return err
+}
+
+// copyImport stages the university and semester the courses are imported into
+func (s *sqlWriter) copyImport(b *strings.Builder) {
+	writeCopy(b, "import_semester", []string{
+		"university_id UUID", "university_name_en TEXT", "university_name_fa TEXT",
+		"semester_id UUID", "year INT", "term TEXT", "timezone TEXT",
+	}, [][]any{{
+		s.university,
+		strings.TrimSpace(s.opts.UniversityName),
+		strings.TrimSpace(s.opts.UniversityNameFa),
+		stableID("semester", strconv.Itoa(s.opts.Year), s.opts.Term),
+		s.opts.Year,
+		s.opts.Term,
+		s.opts.Timezone,
+	}})
+}
+
+func (s *sqlWriter) copyFaculties(b *strings.Builder) {
+	var rows [][]any
+	seen := make(map[string]bool)
+	for _, course := range s.courses {
+		key := strings.ToUpper(course.Faculty)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rows = append(rows, []any{
+			stableID("faculty", s.university.String(), key),
+			course.Faculty,
+			truncate(course.Faculty, 10),
+		})
+	}
+	writeCopy(b, "import_faculties", []string{"id UUID", "faculty TEXT", "short_code TEXT"}, rows)
+}
+
+func (s *sqlWriter) copyProfessors(b *strings.Builder) {
+	var rows [][]any
+	seen := make(map[string]bool)
+	for _, course := range s.courses {
+		if seen[course.professor] {
+			continue
+		}
+		seen[course.professor] = true
+		rows = append(rows, []any{
+			stableID("professor", s.university.String(), course.professor),
+			course.Professor,
+			course.professor,
+		})
+	}
+	writeCopy(b, "import_professors", []string{"id UUID", "name TEXT", "normalized_name TEXT"}, rows)
+}
+
+// copyCatalogCourses stages the catalog course of each course code as its
+// first section describes it, as the API does
+func (s *sqlWriter) copyCatalogCourses(b *strings.Builder) {
+	var rows [][]any
+	seen := make(map[string]bool)
+	for _, course := range s.courses {
+		if seen[course.catalogCode] {
+			continue
+		}
+		seen[course.catalogCode] = true
+		rows = append(rows, []any{
+			stableID("catalog_course", s.university.String(), course.catalogCode),
+			course.Faculty,
+			course.catalogCode,
+			course.Name,
+			course.Weight,
+		})
+	}
+	writeCopy(b, "import_catalog_courses", []string{
+		"id UUID", "faculty TEXT", "code TEXT", "name TEXT", "weight INT",
+	}, rows)
+}
+
+// copyCourses stages the courses, their exams as wall time of the university
+func (s *sqlWriter) copyCourses(b *strings.Builder) {
+	rows := make([][]any, len(s.courses))
+	for i, course := range s.courses {
+		var examStart, examEnd any
+		if course.Exam != nil {
+			examStart = course.Exam.Date + " " + course.Exam.Start
+			examEnd = course.Exam.Date + " " + course.Exam.End
+		}
+
+		rows[i] = []any{
+			s.courseID(course.Code),
+			course.Faculty,
+			course.professor,
+			course.catalogCode,
+			course.Code,
+			course.Name,
+			course.Weight,
+			course.Capacity,
+			string(course.Gender),
+			course.Group,
+			course.PracticalWeight,
+			course.Enrolled,
+			course.WaitingList,
+			course.Notes,
+			course.CrossCampus,
+			course.EmergencyDrop,
+			examStart,
+			examEnd,
+		}
+	}
+	writeCopy(b, "import_courses", []string{
+		"id UUID", "faculty TEXT", "professor TEXT", "catalog_code TEXT", "code TEXT", "name TEXT",
+		"weight INT", "capacity INT", "gender_restriction TEXT", "group_number TEXT", "practical_weight INT",
+		"enrolled INT", "waiting_list INT", "notes TEXT", "cross_campus BOOLEAN", "emergency_drop BOOLEAN",
+		"exam_start TIMESTAMP", "exam_end TIMESTAMP",
+	}, rows)
+}
+
+func (s *sqlWriter) copyCourseTimes(b *strings.Builder) {
+	var rows [][]any
+	for _, course := range s.courses {
+		for j, t := range course.Sessions {
+			rows = append(rows, []any{
+				stableID("course_time", s.courseID(course.Code).String(), strconv.Itoa(j)),
+				course.Code,
+				t.Day,
+				t.Start,
+				t.End,
+				t.Room,
+				t.Location,
+				string(t.WeekParity),
+				t.HalfTerm,
+			})
+		}
+	}
+	writeCopy(b, "import_course_times", []string{
+		"id UUID", "code TEXT", "day_of_week INT", "start_time TIME", "end_time TIME",
+		"room TEXT", "location TEXT", "week_parity TEXT", "half_term INT",
+	}, rows)
+}
+
+func (s *sqlWriter) courseID(code string) uuid.UUID {
+	return stableID("course", s.university.String(), strconv.Itoa(s.opts.Year), s.opts.Term, code)
+}
+
+// upsertSQL moves the staged rows into the API's tables: the university and
+// semester, then the faculties no existing one matches by short code or
+// Persian name, the professors no existing one or alias matches by normalized
+// name and the catalog courses of new course codes, then the courses and the
+// times of the staged courses, which replace their previous times
+const upsertSQL = `
+INSERT INTO universities (id, name_en, name_fa, is_active)
+SELECT p.university_id, p.university_name_en, p.university_name_fa, TRUE
+FROM import_semester p
+WHERE p.university_name_en <> ''
+ON CONFLICT (id) DO UPDATE SET
+    name_en = EXCLUDED.name_en,
+    name_fa = EXCLUDED.name_fa,
+    updated_at = CURRENT_TIMESTAMP;
+
+INSERT INTO semesters (id, year, term)
+SELECT p.semester_id, p.year, p.term
+FROM import_semester p
+ON CONFLICT (year, term) DO NOTHING;
+
+INSERT INTO faculties (id, university_id, name_en, name_fa, short_code, is_active)
+SELECT v.id, p.university_id, v.faculty, v.faculty, v.short_code, TRUE
+FROM import_faculties v
+CROSS JOIN import_semester p
+WHERE NOT EXISTS (
+    SELECT 1 FROM faculties f
+    WHERE f.university_id = p.university_id
+      AND (upper(f.short_code) = upper(v.faculty) OR f.name_fa = v.faculty)
+)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO professors (id, university_id, name, normalized_name)
+SELECT v.id, p.university_id, v.name, v.normalized_name
+FROM import_professors v
+CROSS JOIN import_semester p
+WHERE NOT EXISTS (
+    SELECT 1 FROM professors r
+    WHERE r.university_id = p.university_id AND r.normalized_name = v.normalized_name
+)
+AND NOT EXISTS (
+    SELECT 1 FROM professor_aliases a
+    WHERE a.university_id = p.university_id AND a.normalized_name = v.normalized_name
+)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO catalog_courses (id, university_id, faculty_id, code, name, weight)
+SELECT v.id, p.university_id, ` + facultyIDSQL + `, v.code, v.name, v.weight
+FROM import_catalog_courses v
+CROSS JOIN import_semester p
+ON CONFLICT (university_id, code) DO NOTHING;
+
+INSERT INTO courses (
+    id, university_id, faculty_id, professor_id, semester_id, catalog_course_id,
+    code, name, weight, capacity, gender_restriction, group_number, practical_weight,
+    enrolled, waiting_list, notes, cross_campus, emergency_drop, exam_start, exam_end
+)
+SELECT v.id, p.university_id, ` + facultyIDSQL + `, ` + professorIDSQL + `, sem.id, c.id,
+    v.code, v.name, v.weight, v.capacity, v.gender_restriction, v.group_number, v.practical_weight,
+    v.enrolled, v.waiting_list, v.notes, v.cross_campus, v.emergency_drop,
+    v.exam_start AT TIME ZONE p.timezone, v.exam_end AT TIME ZONE p.timezone
+FROM import_courses v
+CROSS JOIN import_semester p
+JOIN semesters sem ON sem.year = p.year AND sem.term = p.term
+JOIN catalog_courses c ON c.university_id = p.university_id AND c.code = v.catalog_code
+ON CONFLICT (university_id, semester_id, code) DO UPDATE SET
+    faculty_id = EXCLUDED.faculty_id,
+    professor_id = EXCLUDED.professor_id,
+    catalog_course_id = EXCLUDED.catalog_course_id,
+    name = EXCLUDED.name,
+    weight = EXCLUDED.weight,
+    capacity = EXCLUDED.capacity,
+    gender_restriction = EXCLUDED.gender_restriction,
+    group_number = EXCLUDED.group_number,
+    practical_weight = EXCLUDED.practical_weight,
+    enrolled = EXCLUDED.enrolled,
+    waiting_list = EXCLUDED.waiting_list,
+    notes = EXCLUDED.notes,
+    cross_campus = EXCLUDED.cross_campus,
+    emergency_drop = EXCLUDED.emergency_drop,
+    exam_start = EXCLUDED.exam_start,
+    exam_end = EXCLUDED.exam_end,
+    updated_at = CURRENT_TIMESTAMP;
+
+DELETE FROM course_times t
+USING courses c, import_semester p, semesters sem
+WHERE t.course_id = c.id
+  AND c.university_id = p.university_id
+  AND sem.year = p.year AND sem.term = p.term
+  AND c.semester_id = sem.id
+  AND c.code IN (SELECT code FROM import_courses);
+
+INSERT INTO course_times (id, course_id, day_of_week, start_time, end_time, room, location, week_parity, half_term)
+SELECT v.id, c.id, v.day_of_week, v.start_time, v.end_time, v.room, v.location, v.week_parity, v.half_term
+FROM import_course_times v
+CROSS JOIN import_semester p
+JOIN semesters sem ON sem.year = p.year AND sem.term = p.term
+JOIN courses c ON c.university_id = p.university_id AND c.semester_id = sem.id AND c.code = v.code;
+`
+
+// facultyIDSQL resolves v.faculty to a faculty of the university p
+const facultyIDSQL = `(
+        SELECT f.id FROM faculties f
+        WHERE f.university_id = p.university_id
+          AND (upper(f.short_code) = upper(v.faculty) OR f.name_fa = v.faculty)
+        ORDER BY f.created_at LIMIT 1
+    )`
+
+// professorIDSQL resolves the normalized name v.professor to a professor of
+// the university p, falling back to the names of professors merged into another
+const professorIDSQL = `COALESCE(
+        (SELECT r.id FROM professors r
+         WHERE r.university_id = p.university_id AND r.normalized_name = v.professor
+         ORDER BY r.created_at LIMIT 1),
+        (SELECT a.professor_id FROM professor_aliases a
+         WHERE a.university_id = p.university_id AND a.normalized_name = v.professor)
+    )`
+
+// writeCopy creates a staging table, dropped with the transaction, and
+// copies rows into it in PostgreSQL's text format, so that no value is ever
+// part of a statement. Columns are given as "name TYPE".
+func writeCopy(b *strings.Builder, table string, columns []string, rows [][]any) {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = strings.Fields(column)[0]
+	}
+
+	fmt.Fprintf(b, "\nCREATE TEMP TABLE %s (%s) ON COMMIT DROP;\n", table, strings.Join(columns, ", "))
+	fmt.Fprintf(b, "COPY %s (%s) FROM stdin;\n", table, strings.Join(names, ", "))
+	for _, row := range rows {
+		for i, value := range row {
+			if i > 0 {
+				b.WriteByte('\t')
+			}
+			b.WriteString(copyValue(value))
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString("\\.\n")
+}
+
+// copyEscaper escapes text for COPY's text format. PostgreSQL text cannot
+// hold NUL, so it is dropped.
+var copyEscaper = strings.NewReplacer("\\", "\\\\", "\t", "\\t", "\n", "\\n", "\r", "\\r", "\x00", "")
+
+func copyValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "\\N"
+	case string:
+		return copyEscaper.Replace(v)
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case uuid.UUID:
+		return v.String()
+	default:
+		panic(fmt.Sprintf("export: no COPY encoding for %T", value))
+	}
+}
+
+// firstError describes the first error of a row
+func firstError(row parser.Row) string {
+	for _, issue := range row.Issues {
+		if issue.Severity == parser.SeverityError {
+			return issue.Message
+		}
+	}
+	return "unreadable row"
+}
+
+func stableID(kind string, key ...string) uuid.UUID {
+	return uuid.NewSHA1(namespace, []byte(kind+":"+strings.Join(key, "/")))
+}
+
+func truncate(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) > max {
+		return string(runes[:max])
+	}
+	return value
+}
@@ -0,0 +1,198 @@
+// Package jalali converts between the Solar Hijri (Jalali) calendar used by
+// Golestan and the Gregorian calendar.
+//
+// The conversion follows the jalaali-js algorithm by Kazimierz M. Borkowski,
+// which is exact for Jalali years -61 to 3177.
+package jalali
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date is a day in the Jalali calendar
+type Date struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// breaks are the Jalali years that start a new leap cycle
+var breaks = []int{
+	-61, 9, 38, 199, 426, 686, 756, 818, 1111, 1181, 1210,
+	1635, 2060, 2097, 2192, 2262, 2324, 2394, 2456, 3178,
+}
+
+// Parse reads a Jalali date written as YYYY/MM/DD (or with '-' or '.'
+// separators), as Golestan prints exam dates
+func Parse(s string) (Date, error) {
+	parts := strings.FieldsFunc(strings.TrimSpace(s), func(r rune) bool {
+		return r == '/' || r == '-' || r == '.'
+	})
+	if len(parts) != 3 {
+		return Date{}, fmt.Errorf("invalid jalali date %q", s)
+	}
+
+	var values [3]int
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return Date{}, fmt.Errorf("invalid jalali date %q", s)
+		}
+		values[i] = value
+	}
+
+	d := Date{Year: values[0], Month: values[1], Day: values[2]}
+	if !d.Valid() {
+		return Date{}, fmt.Errorf("invalid jalali date %q", s)
+	}
+	return d, nil
+}
+
+// FromTime returns the Jalali date of t in t's own location
+func FromTime(t time.Time) Date {
+	jy, jm, jd := FromGregorian(t.Year(), int(t.Month()), t.Day())
+	return Date{Year: jy, Month: jm, Day: jd}
+}
+
+// Valid reports whether d is a real day of the Jalali calendar
+func (d Date) Valid() bool {
+	if d.Year < breaks[0] || d.Year >= breaks[len(breaks)-1] {
+		return false
+	}
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 {
+		return false
+	}
+	return d.Day <= MonthLength(d.Year, d.Month)
+}
+
+// Gregorian returns the Gregorian year, month and day of d
+func (d Date) Gregorian() (int, time.Month, int) {
+	gy, gm, gd := ToGregorian(d.Year, d.Month, d.Day)
+	return gy, time.Month(gm), gd
+}
+
+// Time returns the given wall-clock time on d in loc
+func (d Date) Time(hour, min int, loc *time.Location) time.Time {
+	gy, gm, gd := d.Gregorian()
+	return time.Date(gy, gm, gd, hour, min, 0, 0, loc)
+}
+
+// String formats d as YYYY/MM/DD
+func (d Date) String() string {
+	return fmt.Sprintf("%04d/%02d/%02d", d.Year, d.Month, d.Day)
+}
+
+// IsLeap reports whether the Jalali year has 366 days
+func IsLeap(year int) bool {
+	leap, _, _ := jalCal(year)
+	return leap == 0
+}
+
+// MonthLength returns the number of days in a Jalali month
+func MonthLength(year, month int) int {
+	switch {
+	case month <= 6:
+		return 31
+	case month <= 11:
+		return 30
+	case IsLeap(year):
+		return 30
+	default:
+		return 29
+	}
+}
+
+// ToGregorian converts a Jalali date to Gregorian
+func ToGregorian(jy, jm, jd int) (int, int, int) {
+	return d2g(j2d(jy, jm, jd))
+}
+
+// FromGregorian converts a Gregorian date to Jalali
+func FromGregorian(gy, gm, gd int) (int, int, int) {
+	return d2j(g2d(gy, gm, gd))
+}
+
+// jalCal returns the position of jy in its leap cycle (0 for leap years),
+// the Gregorian year it starts in and the March day of Farvardin 1st
+func jalCal(jy int) (leap, gy, march int) {
+	gy = jy + 621
+	leapJ := -14
+	jp := breaks[0]
+	jump := 0
+
+	for i := 1; i < len(breaks); i++ {
+		jm := breaks[i]
+		jump = jm - jp
+		if jy < jm {
+			break
+		}
+		leapJ += jump/33*8 + jump%33/4
+		jp = jm
+	}
+
+	n := jy - jp
+	leapJ += n/33*8 + (n%33+3)/4
+	if jump%33 == 4 && jump-n == 4 {
+		leapJ++
+	}
+
+	leapG := gy/4 - (gy/100+1)*3/4 - 150
+	march = 20 + leapJ - leapG
+
+	if jump-n < 6 {
+		n = n - jump + (jump+4)/33*33
+	}
+	leap = ((n+1)%33 - 1) % 4
+	if leap == -1 {
+		leap = 4
+	}
+	return leap, gy, march
+}
+
+// j2d converts a Jalali date to a Julian Day Number
+func j2d(jy, jm, jd int) int {
+	_, gy, march := jalCal(jy)
+	return g2d(gy, 3, march) + (jm-1)*31 - jm/7*(jm-7) + jd - 1
+}
+
+// d2j converts a Julian Day Number to a Jalali date
+func d2j(jdn int) (int, int, int) {
+	gy, _, _ := d2g(jdn)
+	jy := gy - 621
+	leap, _, march := jalCal(jy)
+	k := jdn - g2d(gy, 3, march)
+
+	if k >= 0 {
+		if k <= 185 {
+			return jy, 1 + k/31, k%31 + 1
+		}
+		k -= 186
+	} else {
+		jy--
+		k += 179
+		if leap == 1 {
+			k++
+		}
+	}
+	return jy, 7 + k/30, k%30 + 1
+}
+
+// g2d converts a Gregorian date to a Julian Day Number
+func g2d(gy, gm, gd int) int {
+	d := (gy+(gm-8)/6+100100)*1461/4 + (153*((gm+9)%12)+2)/5 + gd - 34840408
+	return d - (gy+100100+(gm-8)/6)/100*3/4 + 752
+}
+
+// d2g converts a Julian Day Number to a Gregorian date
+func d2g(jdn int) (int, int, int) {
+	j := 4*jdn + 139361631
+	j += (4*jdn+183187720)/146097*3/4*4 - 3908
+	i := j%1461/4*5 + 308
+	gd := i%153/5 + 1
+	gm := i/153%12 + 1
+	gy := j/1461 - 100100 + (8-gm)/6
+	return gy, gm, gd
+}
@@ -0,0 +1,58 @@
+package jalali_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armanjr/termustat/engine/jalali"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConversion(t *testing.T) {
+	tests := []struct {
+		name      string
+		jalali    [3]int
+		gregorian [3]int
+	}{
+		{"nowruz 1403", [3]int{1403, 1, 1}, [3]int{2024, 3, 20}},
+		{"nowruz 1404", [3]int{1404, 1, 1}, [3]int{2025, 3, 21}},
+		{"winter exam", [3]int{1403, 10, 15}, [3]int{2025, 1, 4}},
+		{"summer exam", [3]int{1404, 4, 7}, [3]int{2025, 6, 28}},
+		{"leap day", [3]int{1403, 12, 30}, [3]int{2025, 3, 20}},
+		{"last day of 1402", [3]int{1402, 12, 29}, [3]int{2024, 3, 19}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gy, gm, gd := jalali.ToGregorian(tt.jalali[0], tt.jalali[1], tt.jalali[2])
+			assert.Equal(t, tt.gregorian, [3]int{gy, gm, gd})
+
+			jy, jm, jd := jalali.FromGregorian(tt.gregorian[0], tt.gregorian[1], tt.gregorian[2])
+			assert.Equal(t, tt.jalali, [3]int{jy, jm, jd})
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	d, err := jalali.Parse("1403/10/15")
+	assert.NoError(t, err)
+	assert.Equal(t, jalali.Date{Year: 1403, Month: 10, Day: 15}, d)
+
+	d, err = jalali.Parse("1404.4.7")
+	assert.NoError(t, err)
+	assert.Equal(t, "1404/04/07", d.String())
+
+	for _, invalid := range []string{"", "1403/10", "1403/13/01", "1402/12/30", "1403/07/31", "abc"} {
+		_, err := jalali.Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestTimeAnchorsToLocation(t *testing.T) {
+	tehran, err := time.LoadLocation("Asia/Tehran")
+	assert.NoError(t, err)
+
+	start := jalali.Date{Year: 1403, Month: 10, Day: 15}.Time(8, 0, tehran)
+	assert.Equal(t, "2025-01-04T08:00:00+03:30", start.Format(time.RFC3339))
+	assert.Equal(t, jalali.Date{Year: 1403, Month: 10, Day: 15}, jalali.FromTime(start))
+}
@@ -0,0 +1,290 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/armanjr/termustat/engine/schema"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Severity tells whether an issue makes a row unusable (error) or only
+// worth a look (warning)
+type Severity string
+
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// IssueCode names the kind of problem an issue reports
+type IssueCode string
+
+const (
+	IssueShortRow        IssueCode = "short_row"         // fewer cells than the header
+	IssueMissingValue    IssueCode = "missing_value"     // a required column is blank
+	IssueInvalidNumber   IssueCode = "invalid_number"    // weight, capacity or a count is not a number
+	IssueUnknownGender   IssueCode = "unknown_gender"    // not مرد, زن or مختلط
+	IssueInvalidFlag     IssueCode = "invalid_flag"      // a yes/no column holds something else
+	IssueUnknownDay      IssueCode = "unknown_day"       // a session's day name is not a weekday
+	IssueInvalidTime     IssueCode = "invalid_time"      // a session's HH:MM-HH:MM range does not parse
+	IssueTooManySessions IssueCode = "too_many_sessions" // sessions past the fifth are dropped
+	IssueNoSchedule      IssueCode = "no_schedule"       // no class sessions, e.g. a thesis
+	IssueMissingExam     IssueCode = "missing_exam"      // no exam listed
+	IssueInvalidExamDate IssueCode = "invalid_exam_date" // an exam is listed without a readable date
+	IssueInvalidExamTime IssueCode = "invalid_exam_time" // the exam's HH:MM-HH:MM range does not parse
+)
+
+// Issue is a problem found in a row. Column is the column it was found in
+// and Value the offending text, when there is one.
+type Issue struct {
+	Severity Severity  `json:"severity"`
+	Code     IssueCode `json:"code"`
+	Column   Column    `json:"column,omitempty"`
+	Value    string    `json:"value,omitempty"`
+	Message  string    `json:"message"`
+}
+
+// Row is a data row of a report: its 1-based position among the rows of
+// the report's tables, header rows included, its raw cell texts, the record
+// read from them and the typed course of the record. Record is nil for rows
+// too short to read, Course for rows with errors.
+type Row struct {
+	Index  int            `json:"index"`
+	Cells  []string       `json:"cells"`
+	Record *Record        `json:"record,omitempty"`
+	Course *schema.Course `json:"course,omitempty"`
+	Issues []Issue        `json:"issues,omitempty"`
+}
+
+// HasErrors reports whether any of the row's issues is an error
+func (r *Row) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary counts a report's rows by outcome and its issues by code
+type Summary struct {
+	Rows     int               `json:"rows"`
+	Records  int               `json:"records"`
+	Clean    int               `json:"clean"`
+	Warnings int               `json:"warnings"` // rows with warnings only
+	Errors   int               `json:"errors"`   // rows with at least one error
+	Issues   map[IssueCode]int `json:"issues"`
+}
+
+// Report is a parsed report with the diagnostics of every row
+type Report struct {
+	Rows    []Row   `json:"rows"`
+	Summary Summary `json:"summary"`
+}
+
+// Records returns the records of the report's rows, including those of rows
+// with errors
+func (r *Report) Records() []Record {
+	records := make([]Record, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		if row.Record != nil {
+			records = append(records, *row.Record)
+		}
+	}
+	return records
+}
+
+// Courses returns the typed courses of the report's rows without errors
+func (r *Report) Courses() []schema.Course {
+	courses := make([]schema.Course, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		if row.Course != nil {
+			courses = append(courses, *row.Course)
+		}
+	}
+	return courses
+}
+
+func (r *Report) add(row Row) {
+	r.Rows = append(r.Rows, row)
+
+	summary := &r.Summary
+	summary.Rows++
+	if row.Record != nil {
+		summary.Records++
+	}
+	switch {
+	case row.HasErrors():
+		summary.Errors++
+	case len(row.Issues) > 0:
+		summary.Warnings++
+	default:
+		summary.Clean++
+	}
+	for _, issue := range row.Issues {
+		summary.Issues[issue.Code]++
+	}
+}
+
+var genders = map[string]schema.Gender{
+	"مرد":    schema.GenderMale,
+	"male":   schema.GenderMale,
+	"زن":     schema.GenderFemale,
+	"female": schema.GenderFemale,
+	"مختلط":  schema.GenderMixed,
+	"mixed":  schema.GenderMixed,
+}
+
+// flags are the answers of Golestan's yes/no columns
+var flags = map[string]bool{
+	"بله":         true,
+	"امکان دارد":  true,
+	"yes":         true,
+	"true":        true,
+	"":            false,
+	"خیر":         false,
+	"امکان ندارد": false,
+	"no":          false,
+	"false":       false,
+}
+
+var slotPattern = regexp.MustCompile(`^d[0-6]/`)
+
+// convert reads a record into the typed course, reporting what does not
+// convert, given the raw text of its schedule and exam columns. The course
+// is nil when any issue is an error.
+func convert(record *Record, schedule, exam string) (*schema.Course, []Issue) {
+	var issues []Issue
+	report := func(severity Severity, code IssueCode, column Column, value, format string, args ...interface{}) {
+		issues = append(issues, Issue{
+			Severity: severity,
+			Code:     code,
+			Column:   column,
+			Value:    value,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	course := &schema.Course{
+		Code:      strings.TrimSpace(record.CourseID),
+		Group:     record.Group,
+		Name:      strings.TrimSpace(record.Name),
+		Faculty:   strings.TrimSpace(record.Faculty),
+		Professor: strings.TrimSpace(record.Professor),
+		Notes:     strings.TrimSpace(record.Notes),
+		Sessions:  []schema.Session{},
+	}
+	if course.Group == "" {
+		_, course.Group = schema.SplitCourseCode(course.Code)
+	}
+
+	required := []struct {
+		column Column
+		value  string
+	}{
+		{ColumnCourseID, course.Code},
+		{ColumnName, course.Name},
+		{ColumnFaculty, course.Faculty},
+		{ColumnProfessor, course.Professor},
+	}
+	for _, field := range required {
+		if field.value == "" {
+			report(SeverityError, IssueMissingValue, field.column, "", "%s is blank", field.column)
+		}
+	}
+
+	counts := []struct {
+		column   Column
+		value    string
+		target   *int
+		severity Severity
+		min      int
+	}{
+		{ColumnWeight, record.Weight, &course.Weight, SeverityError, 1},
+		{ColumnCapacity, record.Capacity, &course.Capacity, SeverityError, 0},
+		{ColumnPracticalWeight, record.PracticalWeight, &course.PracticalWeight, SeverityWarning, 0},
+		{ColumnEnrolled, record.Enrolled, &course.Enrolled, SeverityWarning, 0},
+		{ColumnWaitingList, record.WaitingList, &course.WaitingList, SeverityWarning, 0},
+	}
+	for _, count := range counts {
+		// Golestan leaves the optional counts blank
+		value := strings.TrimSpace(count.value)
+		if value == "" && count.severity == SeverityWarning {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < count.min {
+			report(count.severity, IssueInvalidNumber, count.column, count.value, "%s %q is not a number of at least %d", count.column, count.value, count.min)
+			continue
+		}
+		*count.target = n
+	}
+
+	var ok bool
+	if course.Gender, ok = genders[NormalizeName(record.Gender)]; !ok {
+		report(SeverityError, IssueUnknownGender, ColumnGender, record.Gender, "unknown gender %q", record.Gender)
+	}
+	if course.CrossCampus, ok = flags[NormalizeName(record.CrossCampus)]; !ok {
+		report(SeverityError, IssueInvalidFlag, ColumnCrossCampus, record.CrossCampus, "%q is not yes or no", record.CrossCampus)
+	}
+	if course.EmergencyDrop, ok = flags[NormalizeName(record.EmergencyDrop)]; !ok {
+		report(SeverityError, IssueInvalidFlag, ColumnEmergencyDrop, record.EmergencyDrop, "%q is not yes or no", record.EmergencyDrop)
+	}
+
+	sessions := len(slotMarker.FindAllStringIndex(CleanText(schedule), -1))
+	if sessions == 0 {
+		report(SeverityWarning, IssueNoSchedule, ColumnSchedule, "", "no class sessions")
+	}
+	if sessions > 5 {
+		report(SeverityWarning, IssueTooManySessions, ColumnSchedule, "", "%d class sessions, only the first 5 are kept", sessions)
+	}
+	times := []string{record.Time1, record.Time2, record.Time3, record.Time4, record.Time5}
+	locations := []string{record.Location1, record.Location2, record.Location3, record.Location4, record.Location5}
+	for i, slot := range times {
+		if slot == "" {
+			continue
+		}
+		if !slotPattern.MatchString(slot) {
+			report(SeverityError, IssueUnknownDay, ColumnSchedule, slot, "unknown day in session %q", slot)
+			continue
+		}
+		session, err := schema.ParseSession(slot, NormalizeName(locations[i]))
+		if err != nil {
+			report(SeverityError, IssueInvalidTime, ColumnSchedule, slot, "session %q: %v", slot, err)
+			continue
+		}
+		course.Sessions = append(course.Sessions, session)
+	}
+
+	examColumn := ColumnSchedule
+	if exam != "" {
+		examColumn = ColumnExam
+	}
+	hasExam := strings.Contains(CleanText(schedule), "امتحان") || CleanText(exam) != ""
+	switch {
+	case !hasExam:
+		report(SeverityWarning, IssueMissingExam, examColumn, "", "no exam listed")
+	case record.DateExamGregorian == "":
+		report(SeverityError, IssueInvalidExamDate, examColumn, record.DateExam, "invalid exam date %q", record.DateExam)
+	default:
+		start, end, err := schema.ParseTimeRange(record.TimeExam)
+		if err != nil {
+			report(SeverityError, IssueInvalidExamTime, examColumn, record.TimeExam, "invalid exam time: %v", err)
+			break
+		}
+		course.Exam = &schema.Exam{
+			Date:       record.DateExamGregorian,
+			DateJalali: record.DateExam,
+			Start:      start,
+			End:        end,
+		}
+	}
+
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return nil, issues
+		}
+	}
+	return course, issues
+}
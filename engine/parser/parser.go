@@ -0,0 +1,311 @@
+package parser
+
+import (
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/armanjr/termustat/engine/jalali"
+	"github.com/armanjr/termustat/engine/schema"
+	"golang.org/x/text/unicode/norm"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Record is a course row of a Golestan report. DateExam is the Jalali exam
+// date (YYYY/MM/DD) and DateExamGregorian the same day as YYYY-MM-DD. Group is
+// the section suffix of CourseID, e.g. "01" for 1211003_01. TimeN reads
+// d<day>/HH:MM-HH:MM, optionally followed by /h1 or /h2 for sessions held in
+// one half of the term and /odd or /even for alternating weeks. LocationN is
+// the room Golestan lists for TimeN, e.g. "109- طبقه همکف سمت راست".
+type Record struct {
+	CourseID          string `json:"course_id"`
+	Name              string `json:"name"`
+	Weight            string `json:"weight"`
+	Capacity          string `json:"capacity"`
+	Gender            string `json:"gender"`
+	Professor         string `json:"professor"`
+	Faculty           string `json:"faculty"`
+	Group             string `json:"group"`
+	PracticalWeight   string `json:"practical_weight"`
+	Enrolled          string `json:"enrolled"`
+	WaitingList       string `json:"waiting_list"`
+	Notes             string `json:"notes"`
+	CrossCampus       string `json:"cross_campus"`
+	EmergencyDrop     string `json:"emergency_drop"`
+	Time1             string `json:"time1"`
+	Time2             string `json:"time2"`
+	Time3             string `json:"time3"`
+	Time4             string `json:"time4"`
+	Time5             string `json:"time5"`
+	Location1         string `json:"location1"`
+	Location2         string `json:"location2"`
+	Location3         string `json:"location3"`
+	Location4         string `json:"location4"`
+	Location5         string `json:"location5"`
+	TimeExam          string `json:"time_exam"`
+	DateExam          string `json:"date_exam"`
+	DateExamGregorian string `json:"date_exam_gregorian,omitempty"`
+}
+
+// ParseHTMLFile parses a Golestan course report stored on disk, see ParseHTML
+func ParseHTMLFile(path string, profile *Profile) (*Report, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	return ParseHTML(file, profile)
+}
+
+// ParseHTML parses a Golestan course report from r with profile, or the
+// default profile when it is nil, checking every row it reads. Columns are
+// located by the labels of the DTitle header row preceding the rows, so it
+// fails with ErrLayout when the report has no header or lacks a required
+// column. Blank rows are left out.
+func ParseHTML(r io.Reader, profile *Profile) (*Report, error) {
+	if profile == nil {
+		profile = DefaultProfile()
+	}
+
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing HTML: %w", err)
+	}
+
+	report := &Report{Summary: Summary{Issues: make(map[IssueCode]int)}}
+	var columns map[Column]int
+	var width int
+
+	doc.Find("table tr").EachWithBreak(func(i int, tr *goquery.Selection) bool {
+		// reports spanning several pages repeat the header on each
+		if tr.HasClass("DTitle") {
+			if columns, err = profile.columns(tr); err != nil {
+				return false
+			}
+			width = 0
+			for _, i := range columns {
+				if i >= width {
+					width = i + 1
+				}
+			}
+			return true
+		}
+
+		cells := tr.Find("td")
+		row := Row{Index: i + 1, Cells: make([]string, cells.Length())}
+		blank := true
+		cells.Each(func(j int, cell *goquery.Selection) {
+			row.Cells[j] = strings.TrimSpace(cell.Text())
+			if row.Cells[j] != "" {
+				blank = false
+			}
+		})
+		if columns == nil || blank {
+			return true
+		}
+		if len(row.Cells) < width {
+			row.Issues = append(row.Issues, Issue{
+				Severity: SeverityError,
+				Code:     IssueShortRow,
+				Message:  fmt.Sprintf("%d cells, the header has %d", len(row.Cells), width),
+			})
+			report.add(row)
+			return true
+		}
+
+		cell := func(column Column) string {
+			if i, ok := columns[column]; ok {
+				return cells.Eq(i).Text()
+			}
+			return ""
+		}
+
+		record := Record{
+			Faculty:         CleanText(cell(ColumnFaculty)),
+			CourseID:        CleanText(cell(ColumnCourseID)),
+			Name:            CleanText(cell(ColumnName)),
+			Weight:          CleanText(cell(ColumnWeight)),
+			PracticalWeight: CleanText(cell(ColumnPracticalWeight)),
+			Capacity:        CleanText(cell(ColumnCapacity)),
+			Enrolled:        CleanText(cell(ColumnEnrolled)),
+			WaitingList:     CleanText(cell(ColumnWaitingList)),
+			Gender:          CleanText(cell(ColumnGender)),
+			Professor:       CleanText(cell(ColumnProfessor)),
+			Notes:           CleanText(cell(ColumnNotes)),
+			CrossCampus:     CleanText(cell(ColumnCrossCampus)),
+			EmergencyDrop:   CleanText(cell(ColumnEmergencyDrop)),
+		}
+		_, record.Group = schema.SplitCourseCode(record.CourseID)
+
+		processTimeInfo(&record, cell(ColumnSchedule), cell(ColumnExam))
+		processSlotDetails(&record, cell(ColumnSchedule))
+		row.Record = &record
+		row.Course, row.Issues = convert(&record, cell(ColumnSchedule), cell(ColumnExam))
+		report.add(row)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if columns == nil {
+		return nil, fmt.Errorf("%w: no DTitle header row", ErrLayout)
+	}
+
+	return report, nil
+}
+
+func processTimeInfo(record *Record, timeStr, examStr string) {
+	examStr = strings.Replace(examStr, "تاريخ: ", "امتحان(", 1)
+	examStr = strings.Replace(examStr, " ساعت:", ") ساعت :", 1)
+	timeStr = strings.ReplaceAll(timeStr+" "+examStr, "\n", "Q")
+
+	processed := strings.NewReplacer(
+		"پنج شنبه", "d5/", "پنجشنبه", "d5/",
+		"چهار شنبه", "d4/", "چهارشنبه", "d4/",
+		"سه شنبه", "d3/", "سهشنبه", "d3/",
+		"دو شنبه", "d2/", "دوشنبه", "d2/",
+		"يك شنبه", "d1/", "يكشنبه", "d1/",
+		"شنبه", "d0/",
+		"نيمه۱ ت", "", "نيمه۲ ت", "", "فرد", "", "زوج", "",
+		"درس(ت):", "c", "درس(ع):", "c", "درس (ت):", "c", "درس (ع):", "c",
+		"حلتمرين(ت):", "c", "حلتمرین(ت):", "c", "حل تمرين(ت):", "c", "حل تمرین(ت):", "c", "حل تمرين (ت):", "c", "حل تمرین (ت):", "c",
+		"امتحان", "e",
+		"ساعت", "",
+		" ", "", "\t", "T",
+	).Replace(timeStr)
+
+	if idx := strings.Index(processed, "e"); idx != -1 {
+		processExamInfo(record, processed[idx:])
+		processed = processed[:idx]
+	}
+
+	parts := strings.Split(processed, "c")
+	for i := 1; i < len(parts) && i <= 5; i++ {
+		timeSlot := CleanText(parts[i])
+		if locIdx := strings.Index(timeSlot, "مکان"); locIdx != -1 {
+			timeSlot = timeSlot[:locIdx]
+		}
+
+		switch i {
+		case 1:
+			record.Time1 = timeSlot
+		case 2:
+			record.Time2 = timeSlot
+		case 3:
+			record.Time3 = timeSlot
+		case 4:
+			record.Time4 = timeSlot
+		case 5:
+			record.Time5 = timeSlot
+		}
+	}
+}
+
+func processExamInfo(record *Record, examStr string) {
+	if dateStart := strings.Index(examStr, "("); dateStart != -1 {
+		if dateEnd := strings.Index(examStr[dateStart:], ")"); dateEnd != -1 {
+			date := examStr[dateStart+1 : dateStart+dateEnd]
+			date = strings.ReplaceAll(date, ".", "/")
+			record.DateExam = CleanText(date)
+			if d, err := jalali.Parse(record.DateExam); err == nil {
+				gy, gm, gd := d.Gregorian()
+				record.DateExam = d.String()
+				record.DateExamGregorian = fmt.Sprintf("%04d-%02d-%02d", gy, gm, gd)
+			}
+		}
+	}
+
+	if timeStart := strings.Index(examStr, "):"); timeStart != -1 {
+		record.TimeExam = CleanText(examStr[timeStart+2:])
+	}
+}
+
+// slotMarker matches the labels processTimeInfo turns into time slots
+var slotMarker = regexp.MustCompile(`درس ?\((?:ت|ع)\):|حل ?تمرین ?\(ت\):`)
+
+// processSlotDetails reads what processTimeInfo drops from each time slot:
+// the location after "مکان:", and whether the session runs only in odd or
+// even weeks (فرد/زوج) or only in one half of the term (نیمه۱ ت/نیمه۲ ت).
+// processTimeInfo strips spaces, so these are taken from the raw text.
+func processSlotDetails(record *Record, timeStr string) {
+	text := CleanText(timeStr)
+	if idx := strings.Index(text, "امتحان"); idx != -1 {
+		text = text[:idx]
+	}
+
+	times := []*string{&record.Time1, &record.Time2, &record.Time3, &record.Time4, &record.Time5}
+	locations := []*string{&record.Location1, &record.Location2, &record.Location3, &record.Location4, &record.Location5}
+
+	markers := slotMarker.FindAllStringIndex(text, -1)
+	for i := 0; i < len(markers) && i < 5; i++ {
+		end := len(text)
+		if i+1 < len(markers) {
+			end = markers[i+1][0]
+		}
+		slot := text[markers[i][1]:end]
+
+		if locIdx := strings.Index(slot, "مکان"); locIdx != -1 {
+			location := strings.TrimPrefix(slot[locIdx+len("مکان"):], ":")
+			*locations[i] = strings.Join(strings.Fields(location), " ")
+			slot = slot[:locIdx]
+		}
+
+		if *times[i] == "" {
+			continue
+		}
+		switch {
+		case strings.Contains(slot, "نیمه1"):
+			*times[i] += "/h1"
+		case strings.Contains(slot, "نیمه2"):
+			*times[i] += "/h2"
+		}
+		switch {
+		case strings.Contains(slot, "فرد"):
+			*times[i] += "/odd"
+		case strings.Contains(slot, "زوج"):
+			*times[i] += "/even"
+		}
+	}
+}
+
+// CleanText maps Arabic/Persian digits and letters to their canonical form
+func CleanText(text string) string {
+	replacer := strings.NewReplacer(
+		"۰", "0", "۱", "1", "۲", "2", "۳", "3", "۴", "4",
+		"۵", "5", "۶", "6", "۷", "7", "۸", "8", "۹", "9",
+		"ي", "ی", "ك", "ک", "ئ", "ی", "ء", "", "٠", "0", "١", "1",
+		"٢", "2", "٣", "3", "٤", "4", "٥", "5", "٦", "6", "٧", "7",
+		"٨", "8", "٩", "9", "‌", " ", "‏", "", "\u200c", " ",
+	)
+	return strings.TrimSpace(replacer.Replace(text))
+}
+
+var arabicToPersian = map[rune]rune{
+	'ك': 'ک',
+	'ي': 'ی',
+	'ۀ': 'ه',
+	'ۂ': 'ه',
+	'ۃ': 'ه',
+	'ة': 'ه',
+}
+
+// NormalizeName maps Arabic letter variants to Persian and collapses
+// whitespace, the form names are stored and matched in, e.g. the
+// normalized_name of professors
+func NormalizeName(text string) string {
+	var builder strings.Builder
+	builder.Grow(len(text))
+
+	for _, r := range text {
+		if p, ok := arabicToPersian[r]; ok {
+			builder.WriteRune(p)
+		} else {
+			builder.WriteRune(r)
+		}
+	}
+
+	normalized := norm.NFC.String(builder.String())
+	return strings.Join(strings.Fields(normalized), " ")
+}
@@ -0,0 +1,169 @@
+package parser_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/armanjr/termustat/engine/parser"
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessHTMLFileDetails(t *testing.T) {
+	report, err := parser.ParseHTMLFile(filepath.Join("..", "courses", "all.html.sample"), nil)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(report.Rows), 3)
+
+	record := report.Rows[0].Record
+	assert.Equal(t, "1211003_01", record.CourseID)
+	assert.Equal(t, "01", record.Group)
+	assert.Equal(t, "0", record.PracticalWeight)
+	assert.Equal(t, "5", record.Enrolled)
+	assert.Equal(t, "0", record.WaitingList)
+	assert.Equal(t, "خیر", record.CrossCampus)
+	assert.Equal(t, "امکان دارد", record.EmergencyDrop)
+	assert.Equal(t, "109- طبقه همکف سمت راست", record.Location1)
+	assert.Equal(t, "109- طبقه همکف سمت راست", record.Location3)
+	assert.Equal(t, "208-طبقه اول سمت چپ", report.Rows[1].Record.Location1)
+
+	assert.Equal(t, "11", report.Rows[2].Record.Group)
+	assert.Equal(t, "بله", report.Rows[2].Record.CrossCampus)
+	assert.Empty(t, report.Rows[2].Record.Location1)
+}
+
+func TestParseHTMLDefaultProfile(t *testing.T) {
+	report, err := parser.ParseHTMLFile(filepath.Join("..", "courses", "all.html.sample"), nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, report.Rows)
+	assert.Equal(t, 0, report.Summary.Errors)
+
+	row := report.Rows[0]
+	assert.Equal(t, 2, row.Index)
+	assert.Equal(t, "1211003_01", row.Cells[6])
+	assert.Empty(t, row.Issues)
+
+	record := row.Record
+	assert.Equal(t, "12", record.Faculty)
+	assert.Equal(t, "1211003_01", record.CourseID)
+	assert.Equal(t, "01", record.Group)
+	assert.Equal(t, "4", record.Weight)
+	assert.Equal(t, "45", record.Capacity)
+	assert.Equal(t, "d1/10:00-12:00", record.Time1)
+	assert.Equal(t, "1404/04/07", record.DateExam)
+}
+
+// a layout with reordered columns, an English header and a separate exam column
+const reordered = `<table>
+<tr class="DTitle"><td>Code</td><td>Title</td><td>Units</td><td>Teacher</td><td>Faculty</td>
+<td>Capacity</td><td>Gender</td><td>Schedule</td><td>Exam</td></tr>
+<tr><td>1211003_02</td><td>ریاضی عمومی 1</td><td>3</td><td>الیاسی نیره</td><td>12</td>
+<td>40</td><td>مختلط</td><td>درس(ت): شنبه 08:00-10:00</td><td>تاريخ: 1404/04/07 ساعت: 08:00-10:00</td></tr>
+</table>`
+
+func TestParseHTMLCustomProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tabriz.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"columns": {
+		"course_id": ["Code"], "name": ["Title"], "weight": ["Units"], "professor": ["Teacher"],
+		"faculty": ["Faculty"], "capacity": ["Capacity"], "gender": ["Gender"],
+		"schedule": ["Schedule"], "exam": ["Exam"]
+	}}`), 0644))
+
+	profile, err := parser.LoadProfile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "tabriz", profile.Name)
+
+	report, err := parser.ParseHTML(strings.NewReader(reordered), profile)
+	require.NoError(t, err)
+	require.Len(t, report.Rows, 1)
+	record := report.Rows[0].Record
+	assert.Equal(t, "1211003_02", record.CourseID)
+	assert.Equal(t, "الیاسی نیره", record.Professor)
+	assert.Equal(t, "d0/08:00-10:00", record.Time1)
+
+	course := report.Rows[0].Course
+	require.NotNil(t, course)
+	assert.Equal(t, 3, course.Weight)
+	assert.Equal(t, schema.GenderMixed, course.Gender)
+	assert.Equal(t, []schema.Session{{Day: 0, Start: "08:00", End: "10:00"}}, course.Sessions)
+	assert.Equal(t, &schema.Exam{Date: "2025-06-28", DateJalali: "1404/04/07", Start: "08:00", End: "10:00"}, course.Exam)
+	assert.NoError(t, course.Validate())
+}
+
+func TestOpenProfile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tabriz.json"), []byte(`{"columns": {"course_id": ["Code"]}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(t.TempDir(), "outside.json"), []byte(`{}`), 0644))
+
+	profile, err := parser.OpenProfile(dir, "tabriz")
+	require.NoError(t, err)
+	assert.Equal(t, "tabriz", profile.Name)
+
+	for _, name := range []string{"", "default"} {
+		profile, err := parser.OpenProfile("", name)
+		require.NoError(t, err)
+		assert.Equal(t, "default", profile.Name)
+	}
+
+	for _, name := range []string{"missing", "../outside", "tabriz.json"} {
+		_, err := parser.OpenProfile(dir, name)
+		assert.ErrorIs(t, err, parser.ErrUnknownProfile, name)
+	}
+	_, err = parser.OpenProfile("", "tabriz")
+	assert.ErrorIs(t, err, parser.ErrUnknownProfile)
+}
+
+func TestParseHTMLMissingColumns(t *testing.T) {
+	_, err := parser.ParseHTML(strings.NewReader(reordered), nil)
+	require.ErrorIs(t, err, parser.ErrLayout)
+	assert.Contains(t, err.Error(), "course_id")
+
+	_, err = parser.ParseHTML(strings.NewReader("<table><tr><td>1</td></tr></table>"), nil)
+	require.ErrorIs(t, err, parser.ErrLayout)
+}
+
+func TestParseHTMLDiagnostics(t *testing.T) {
+	html := `<table>
+<tr class="DTitle"><td>دانشکده درس</td><td>شماره و گروه درس</td><td>نام درس</td><td>کل</td><td>ظرفیت</td>
+<td>جنسیت</td><td>نام استاد</td><td>زمان و مکان ارائه/ امتحان</td></tr>
+<tr><td>12</td><td>1211003_01</td><td>ریاضی عمومی 1</td><td>4</td><td>نامحدود</td>
+<td>نامعلوم</td><td>الیاسی نیره</td><td>درس(ت): جمعه 10:00-12:00 امتحان(1404.13.40) ساعت : 08:00-10:00</td></tr>
+<tr><td>12</td><td>1211005_01</td></tr>
+<tr><td></td><td></td></tr>
+</table>`
+
+	report, err := parser.ParseHTML(strings.NewReader(html), nil)
+	require.NoError(t, err)
+	require.Len(t, report.Rows, 2)
+
+	var codes []parser.IssueCode
+	for _, issue := range report.Rows[0].Issues {
+		assert.Equal(t, parser.SeverityError, issue.Severity)
+		codes = append(codes, issue.Code)
+	}
+	assert.ElementsMatch(t, []parser.IssueCode{
+		parser.IssueInvalidNumber, parser.IssueUnknownGender, parser.IssueUnknownDay, parser.IssueInvalidExamDate,
+	}, codes)
+
+	assert.Equal(t, 3, report.Rows[1].Index)
+	assert.Nil(t, report.Rows[1].Record)
+	assert.Equal(t, parser.IssueShortRow, report.Rows[1].Issues[0].Code)
+
+	assert.Equal(t, parser.Summary{
+		Rows:    2,
+		Records: 1,
+		Errors:  2,
+		Issues: map[parser.IssueCode]int{
+			parser.IssueInvalidNumber:   1,
+			parser.IssueUnknownGender:   1,
+			parser.IssueUnknownDay:      1,
+			parser.IssueInvalidExamDate: 1,
+			parser.IssueShortRow:        1,
+		},
+	}, report.Summary)
+	assert.Len(t, report.Records(), 1)
+	assert.Nil(t, report.Rows[0].Course)
+	assert.Empty(t, report.Courses())
+}
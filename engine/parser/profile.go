@@ -0,0 +1,187 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ErrLayout reports a report whose header does not fit the profile it is
+// parsed with
+var ErrLayout = errors.New("unrecognized report layout")
+
+// ErrUnknownProfile reports a profile name with no profile behind it
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// profileName keeps profile names from reaching outside the profile directory
+var profileName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Column is a field of Record a report column is read into. Schedule holds
+// the class times and locations, and the exam unless a separate Exam column
+// lists it.
+type Column string
+
+const (
+	ColumnFaculty         Column = "faculty"
+	ColumnCourseID        Column = "course_id"
+	ColumnName            Column = "name"
+	ColumnWeight          Column = "weight"
+	ColumnPracticalWeight Column = "practical_weight"
+	ColumnCapacity        Column = "capacity"
+	ColumnEnrolled        Column = "enrolled"
+	ColumnWaitingList     Column = "waiting_list"
+	ColumnGender          Column = "gender"
+	ColumnProfessor       Column = "professor"
+	ColumnSchedule        Column = "schedule"
+	ColumnExam            Column = "exam"
+	ColumnNotes           Column = "notes"
+	ColumnCrossCampus     Column = "cross_campus"
+	ColumnEmergencyDrop   Column = "emergency_drop"
+)
+
+// defaultLabels are the header labels Golestan's course reports use for
+// each column. Where a label repeats, as دانشکده درس does for the faculty's
+// code and name, the first cell is read.
+var defaultLabels = map[Column][]string{
+	ColumnFaculty:         {"دانشکده درس", "دانشکده"},
+	ColumnCourseID:        {"شماره و گروه درس", "شماره درس", "کد درس"},
+	ColumnName:            {"نام درس"},
+	ColumnWeight:          {"کل", "تعداد واحد", "واحد"},
+	ColumnPracticalWeight: {"ع", "عملی"},
+	ColumnCapacity:        {"ظرفیت"},
+	ColumnEnrolled:        {"ثبت نام شده"},
+	ColumnWaitingList:     {"تعداد لیست انتظار", "لیست انتظار"},
+	ColumnGender:          {"جنسیت"},
+	ColumnProfessor:       {"نام استاد", "استاد"},
+	ColumnSchedule:        {"زمان و مکان ارائه/ امتحان", "زمان و مکان ارائه"},
+	ColumnExam:            {"زمان امتحان", "امتحان"},
+	ColumnNotes:           {"توضیحات"},
+	ColumnCrossCampus:     {"امکان اخذ درس توسط سایر مراکز"},
+	ColumnEmergencyDrop:   {"حذف اضطراری"},
+}
+
+var defaultRequired = []Column{
+	ColumnFaculty, ColumnCourseID, ColumnName, ColumnWeight, ColumnCapacity,
+	ColumnGender, ColumnProfessor, ColumnSchedule,
+}
+
+// Profile maps the columns of a university's report layout to the labels of
+// its DTitle header row. Columns without labels fall back to the default
+// ones, and Required defaults to the columns a course cannot do without.
+type Profile struct {
+	Name     string              `json:"name"`
+	Columns  map[Column][]string `json:"columns"`
+	Required []Column            `json:"required"`
+}
+
+// DefaultProfile reads the layout of Golestan's standard course reports
+func DefaultProfile() *Profile {
+	return &Profile{Name: "default"}
+}
+
+// LoadProfile reads a profile from a JSON file, named after the file unless
+// it names itself
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading profile: %w", err)
+	}
+
+	var profile Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("error parsing profile %s: %w", path, err)
+	}
+	for column := range profile.Columns {
+		if _, ok := defaultLabels[column]; !ok {
+			return nil, fmt.Errorf("profile %s: unknown column %q", path, column)
+		}
+	}
+	for _, column := range profile.Required {
+		if _, ok := defaultLabels[column]; !ok {
+			return nil, fmt.Errorf("profile %s: unknown required column %q", path, column)
+		}
+	}
+
+	if profile.Name == "" {
+		profile.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return &profile, nil
+}
+
+// OpenProfile loads the profile called name from dir, which holds profiles
+// as <name>.json. No name, or "default", is the default profile.
+func OpenProfile(dir, name string) (*Profile, error) {
+	if name == "" || name == "default" {
+		return DefaultProfile(), nil
+	}
+	if dir == "" || !profileName.MatchString(name) {
+		return nil, fmt.Errorf("%w %q", ErrUnknownProfile, name)
+	}
+
+	profile, err := LoadProfile(filepath.Join(dir, name+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w %q", ErrUnknownProfile, name)
+	}
+	return profile, err
+}
+
+func (p *Profile) labels(column Column) []string {
+	if labels, ok := p.Columns[column]; ok && len(labels) > 0 {
+		return labels
+	}
+	return defaultLabels[column]
+}
+
+func (p *Profile) required() []Column {
+	if len(p.Required) > 0 {
+		return p.Required
+	}
+	return defaultRequired
+}
+
+// columns finds the cell of each column in a DTitle row, failing when a
+// required one is missing
+func (p *Profile) columns(header *goquery.Selection) (map[Column]int, error) {
+	cells := make(map[string]int)
+	header.Find("td").Each(func(i int, cell *goquery.Selection) {
+		label := headerLabel(cell.Text())
+		if _, ok := cells[label]; !ok {
+			cells[label] = i
+		}
+	})
+
+	found := make(map[Column]int)
+	for column := range defaultLabels {
+		for _, label := range p.labels(column) {
+			if i, ok := cells[headerLabel(label)]; ok {
+				found[column] = i
+				break
+			}
+		}
+	}
+
+	var missing []string
+	for _, column := range p.required() {
+		if _, ok := found[column]; !ok {
+			missing = append(missing, fmt.Sprintf("%s (%s)", column, strings.Join(p.labels(column), ", ")))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("%w: profile %q finds no column for %s", ErrLayout, p.Name, strings.Join(missing, "; "))
+	}
+	return found, nil
+}
+
+// headerLabel reduces a header cell to the form labels are compared in;
+// Golestan breaks words in headers at random, e.g. "ظر فيت"
+func headerLabel(text string) string {
+	return strings.Join(strings.Fields(NormalizeName(CleanText(text))), "")
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/armanjr/termustat/engine/export"
+	"github.com/armanjr/termustat/engine/parser"
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/google/uuid"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultInput is the sample report shipped with the engine. Reports are
+// kept next to it as .html files, which -in courses converts.
+var defaultInput = filepath.Join("courses", "all.html.sample")
+
+// report is a Golestan report file and the name its output is known by
+type report struct {
+	path   string
+	source string
+}
+
+// process converts the reports under -in to -format. -out is a file, "-"
+// for stdout, or a directory (an existing one, or a path ending in a
+// separator) to write one file per report into.
+func process(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("process", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	in := flags.String("in", defaultInput, "report file, or directory of .html reports")
+	out := flags.String("out", "-", `output file, directory for one file per report, or "-" for stdout`)
+	formatName := flags.String("format", "json", "output format: json, jsonl, csv or sql")
+	universityID := flags.String("university-id", "", "sql: ID of an existing university to import into")
+	university := flags.String("university", "", "sql: English name of the university, created or updated with the import")
+	universityFa := flags.String("university-fa", "", "sql: Persian name of the university")
+	termCode := flags.String("semester", "", "sql: Golestan term code of the semester, e.g. 4031 for fall 1403")
+	timezone := flags.String("tz", "Asia/Tehran", "sql: time zone of exam times")
+	profilePath := flags.String("profile", "", "JSON column profile of the reports' layout, if not Golestan's default")
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	logger := log.New(stderr, "", log.LstdFlags)
+	if flags.NArg() > 0 {
+		logger.Printf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+		return exitUsage
+	}
+
+	format, err := export.ParseFormat(*formatName)
+	if err != nil {
+		logger.Print(err)
+		return exitUsage
+	}
+
+	opts := export.Options{
+		UniversityName:   *university,
+		UniversityNameFa: *universityFa,
+		Timezone:         *timezone,
+	}
+	if *universityID != "" {
+		if opts.UniversityID, err = uuid.Parse(*universityID); err != nil {
+			logger.Printf("invalid university ID %q", *universityID)
+			return exitUsage
+		}
+	}
+	if *termCode != "" {
+		if opts.Year, opts.Term, err = schema.ParseTermCode(*termCode); err != nil {
+			logger.Print(err)
+			return exitUsage
+		}
+	}
+	// fail before reading any report when the options do not suit the format
+	if _, err := export.NewWriter(format, io.Discard, opts); err != nil {
+		logger.Print(err)
+		return exitUsage
+	}
+
+	profile := parser.DefaultProfile()
+	if *profilePath != "" {
+		if profile, err = parser.LoadProfile(*profilePath); err != nil {
+			logger.Print(err)
+			return exitUsage
+		}
+	}
+
+	reports, err := findReports(*in)
+	if err != nil {
+		logger.Print(err)
+		return exitFailure
+	}
+	if len(reports) == 0 {
+		logger.Printf("no .html reports in %s", *in)
+		return exitFailure
+	}
+
+	var failed int
+	if isDirOutput(*out) {
+		failed, err = processEach(reports, profile, format, opts, *out, logger)
+	} else {
+		failed, err = processCombined(reports, profile, format, opts, *out, stdout, logger)
+	}
+	if err != nil {
+		logger.Print(err)
+		return exitFailure
+	}
+
+	switch {
+	case failed == len(reports):
+		return exitFailure
+	case failed > 0:
+		logger.Printf("%d of %d reports failed", failed, len(reports))
+		return exitPartial
+	default:
+		return exitOK
+	}
+}
+
+// findReports lists the .html reports at path, a file or a directory
+func findReports(path string) ([]report, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+	if !info.IsDir() {
+		return []report{{path: path, source: sourceName(path)}}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading input directory: %w", err)
+	}
+
+	var reports []report
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".html") {
+			continue
+		}
+		reports = append(reports, report{
+			path:   filepath.Join(path, entry.Name()),
+			source: sourceName(entry.Name()),
+		})
+	}
+	return reports, nil
+}
+
+// sourceName names a report by its file name without the extension, or
+// the .html.sample of a sample
+func sourceName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), ".sample")
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+func isDirOutput(path string) bool {
+	if strings.HasSuffix(path, string(filepath.Separator)) || strings.HasSuffix(path, "/") {
+		return true
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// processCombined writes every report to one output, stdout for "-"
+func processCombined(reports []report, profile *parser.Profile, format export.Format, opts export.Options, out string, stdout io.Writer, logger *log.Logger) (int, error) {
+	var failed int
+	write := func(w io.Writer) error {
+		writer, err := export.NewWriter(format, w, opts)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range reports {
+			parsed, err := parseReport(r.path, profile, logger)
+			if err != nil {
+				logger.Printf("skipping %s: %v", r.path, err)
+				failed++
+				continue
+			}
+			if err := writer.Write(r.source, parsed); err != nil {
+				return fmt.Errorf("error writing %s: %w", r.source, err)
+			}
+		}
+
+		if failed == len(reports) {
+			return fmt.Errorf("none of the %d reports could be parsed", len(reports))
+		}
+		return writer.Close()
+	}
+
+	if out == "-" {
+		return failed, write(stdout)
+	}
+	return failed, writeFile(out, write)
+}
+
+// processEach writes every report to its own file in dir
+func processEach(reports []report, profile *parser.Profile, format export.Format, opts export.Options, dir string, logger *log.Logger) (int, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return 0, fmt.Errorf("error creating output directory: %w", err)
+	}
+
+	var failed int
+	for _, r := range reports {
+		parsed, err := parseReport(r.path, profile, logger)
+		if err != nil {
+			logger.Printf("skipping %s: %v", r.path, err)
+			failed++
+			continue
+		}
+
+		path := filepath.Join(dir, r.source+"."+format.Ext())
+		err = writeFile(path, func(w io.Writer) error {
+			writer, err := export.NewWriter(format, w, opts)
+			if err != nil {
+				return err
+			}
+			if err := writer.Write(r.source, parsed); err != nil {
+				return err
+			}
+			return writer.Close()
+		})
+		if err != nil {
+			return failed, fmt.Errorf("error writing %s: %w", path, err)
+		}
+		logger.Printf("%s: written to %s", r.path, path)
+	}
+
+	return failed, nil
+}
+
+// parseReport parses a report, logging the rows with errors and a summary
+func parseReport(path string, profile *parser.Profile, logger *log.Logger) (*parser.Report, error) {
+	report, err := parser.ParseHTMLFile(path, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range report.Rows {
+		for _, issue := range row.Issues {
+			if issue.Severity == parser.SeverityError {
+				logger.Printf("%s: row %d: %s: %s", path, row.Index, issue.Code, issue.Message)
+			}
+		}
+	}
+
+	summary := report.Summary
+	logger.Printf("%s: %d records, %d clean, %d with warnings, %d with errors",
+		path, summary.Records, summary.Clean, summary.Warnings, summary.Errors)
+	return report, nil
+}
+
+// writeFile writes path through a temporary file in the same directory, so
+// readers never see a partial output
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error closing output file: %w", err)
+	}
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		return fmt.Errorf("error setting output file mode: %w", err)
+	}
+	return os.Rename(file.Name(), path)
+}
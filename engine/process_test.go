@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// broken is a report without the columns of Golestan's layout
+const broken = "<table><tr><td>1</td></tr></table>"
+
+func TestProcess(t *testing.T) {
+	sample, err := os.ReadFile(defaultInput)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		reports map[string]string // reports written to a temp dir
+		args    []string          // {dir} stands for the temp dir
+		code    int
+		stdout  string            // text in stdout
+		files   map[string]string // files in the temp dir and text in each
+		stderr  string            // text in stderr
+	}{
+		{name: "sample by default", code: exitOK, stdout: `"code": "1211003_01"`},
+		{name: "help", args: []string{"-h"}, code: exitOK, stderr: "-in"},
+		{name: "unknown flag", args: []string{"-input", "courses"}, code: exitUsage},
+		{name: "extra argument", args: []string{"courses"}, code: exitUsage, stderr: "unexpected arguments: courses"},
+		{name: "unknown format", args: []string{"-format", "xml"}, code: exitUsage},
+		{name: "invalid term code", args: []string{"-format", "sql", "-university", "Isfahan", "-semester", "+031"}, code: exitUsage},
+		{name: "sql without university", args: []string{"-format", "sql", "-semester", "4031"}, code: exitUsage, stderr: "university ID or name"},
+		{name: "missing input", args: []string{"-in", "{dir}/missing"}, code: exitFailure},
+		{
+			name:    "no reports",
+			reports: map[string]string{"notes.txt": "", "all.html.sample": string(sample)},
+			args:    []string{"-in", "{dir}"},
+			code:    exitFailure,
+			stderr:  "no .html reports",
+		},
+		{
+			name:    "every report broken",
+			reports: map[string]string{"a.html": broken, "b.html": broken},
+			args:    []string{"-in", "{dir}", "-out", "{dir}/courses.json"},
+			code:    exitFailure,
+			stderr:  "none of the 2 reports could be parsed",
+		},
+		{
+			name:    "combined to stdout",
+			reports: map[string]string{"math.html": string(sample), "physics.HTML": string(sample)},
+			args:    []string{"-in", "{dir}", "-format", "jsonl"},
+			code:    exitOK,
+			stdout:  `"source":"physics"`,
+		},
+		{
+			name:    "combined to a file with a broken report",
+			reports: map[string]string{"math.html": string(sample), "broken.html": broken},
+			args:    []string{"-in", "{dir}", "-out", "{dir}/courses.csv", "-format", "csv"},
+			code:    exitPartial,
+			files:   map[string]string{"courses.csv": "1211003_01"},
+			stderr:  "1 of 2 reports failed",
+		},
+		{
+			name:    "each report to a directory",
+			reports: map[string]string{"math.html": string(sample), "broken.html": broken},
+			args:    []string{"-in", "{dir}", "-out", "{dir}/export/"},
+			code:    exitPartial,
+			files:   map[string]string{"export/math.json": `"code": "1211003_01"`},
+		},
+		{
+			name:    "a single report to a directory",
+			reports: map[string]string{"math.html": string(sample)},
+			args:    []string{"-in", "{dir}/math.html", "-out", "{dir}", "-format", "jsonl"},
+			code:    exitOK,
+			files:   map[string]string{"math.jsonl": `"source":"math"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.reports {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+			}
+			args := make([]string, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = strings.ReplaceAll(arg, "{dir}", dir)
+			}
+
+			var stdout, stderr bytes.Buffer
+			code := process(args, &stdout, &stderr)
+			assert.Equal(t, tt.code, code, stderr.String())
+			assert.Contains(t, stdout.String(), tt.stdout)
+			assert.Contains(t, stderr.String(), tt.stderr)
+			for name, text := range tt.files {
+				content, err := os.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+				assert.Contains(t, string(content), text, name)
+			}
+			if tt.code != exitOK && tt.code != exitPartial {
+				assert.Empty(t, stdout.String())
+			}
+		})
+	}
+}
+
+func TestProcessKeepsOutputOfFailedRun(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.html"), []byte(broken), 0644))
+	out := filepath.Join(dir, "courses.json")
+	require.NoError(t, os.WriteFile(out, []byte("previous"), 0644))
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitFailure, process([]string{"-in", dir, "-out", out}, &stdout, &stderr))
+
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "previous", string(content))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "no temporary files left behind")
+}
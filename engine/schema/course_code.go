@@ -0,0 +1,13 @@
+package schema
+
+import "strings"
+
+// SplitCourseCode splits a Golestan section code such as 1211003_01 into the
+// course code and the group number. Codes without a group are returned whole.
+func SplitCourseCode(code string) (string, string) {
+	code = strings.TrimSpace(code)
+	if i := strings.LastIndex(code, "_"); i != -1 {
+		return code[:i], code[i+1:]
+	}
+	return code, ""
+}
@@ -0,0 +1,284 @@
+// Package schema defines the typed JSON the engine produces for Golestan
+// courses. The API decodes and validates engine output with these same
+// types, so the two cannot drift apart.
+package schema
+
+import (
+	"fmt"
+	"github.com/armanjr/termustat/engine/jalali"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Version is the version of Document. It changes whenever a change to these
+// types would break a reader of the previous version.
+const Version = 1
+
+type Gender string
+
+const (
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+	GenderMixed  Gender = "mixed"
+)
+
+type WeekParity string
+
+const (
+	WeekParityOdd  WeekParity = "odd"
+	WeekParityEven WeekParity = "even"
+)
+
+// Document is the engine's output for one or more reports
+type Document struct {
+	Version int      `json:"version"`
+	Courses []Course `json:"courses"`
+}
+
+// Course is a course section. Code is Golestan's section code, e.g.
+// 1211003_01, and Group its suffix. Exam is nil for courses without one,
+// such as projects and theses.
+type Course struct {
+	Code            string    `json:"code"`
+	Group           string    `json:"group"`
+	Name            string    `json:"name"`
+	Faculty         string    `json:"faculty"` // Golestan's faculty code or Persian name
+	Professor       string    `json:"professor"`
+	Weight          int       `json:"weight"`
+	PracticalWeight int       `json:"practical_weight"`
+	Capacity        int       `json:"capacity"`
+	Enrolled        int       `json:"enrolled"`
+	WaitingList     int       `json:"waiting_list"`
+	Gender          Gender    `json:"gender"`
+	Notes           string    `json:"notes,omitempty"`
+	CrossCampus     bool      `json:"cross_campus"`
+	EmergencyDrop   bool      `json:"emergency_drop"`
+	Sessions        []Session `json:"sessions"`
+	Exam            *Exam     `json:"exam,omitempty"`
+}
+
+// Session is a weekly class session. Day counts from Saturday (0) to Friday
+// (6). WeekParity limits it to odd or even weeks and HalfTerm to the first
+// (1) or second (2) half of the term.
+type Session struct {
+	Day        int        `json:"day"`
+	Start      string     `json:"start"` // HH:MM
+	End        string     `json:"end"`
+	Location   string     `json:"location,omitempty"` // e.g. "109- طبقه همکف سمت راست"
+	Room       string     `json:"room,omitempty"`     // e.g. "109"
+	WeekParity WeekParity `json:"week_parity,omitempty"`
+	HalfTerm   int        `json:"half_term,omitempty"`
+}
+
+// Exam is the final exam of a course, held on the same day in both calendars
+type Exam struct {
+	Date       string `json:"date"`        // Gregorian, YYYY-MM-DD
+	DateJalali string `json:"date_jalali"` // Solar Hijri, YYYY/MM/DD
+	Start      string `json:"start"`       // HH:MM, wall time of the university
+	End        string `json:"end"`
+}
+
+// FieldError reports a field whose value the schema does not allow
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+func invalid(field, format string, args ...interface{}) error {
+	return &FieldError{Field: field, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Validate checks the document's version and each of its courses
+func (d *Document) Validate() error {
+	if d.Version != Version {
+		return invalid("version", "unsupported version %d, expected %d", d.Version, Version)
+	}
+	for i := range d.Courses {
+		if err := d.Courses[i].Validate(); err != nil {
+			return fmt.Errorf("course %q at index %d: %w", d.Courses[i].Code, i, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that a course holds what the API can store
+func (c *Course) Validate() error {
+	required := []struct {
+		field string
+		value string
+	}{
+		{"code", c.Code},
+		{"name", c.Name},
+		{"faculty", c.Faculty},
+		{"professor", c.Professor},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			return invalid(r.field, "required")
+		}
+	}
+
+	if c.Weight < 1 {
+		return invalid("weight", "must be at least 1")
+	}
+	counts := []struct {
+		field string
+		value int
+	}{
+		{"practical_weight", c.PracticalWeight},
+		{"capacity", c.Capacity},
+		{"enrolled", c.Enrolled},
+		{"waiting_list", c.WaitingList},
+	}
+	for _, count := range counts {
+		if count.value < 0 {
+			return invalid(count.field, "must not be negative")
+		}
+	}
+
+	switch c.Gender {
+	case GenderMale, GenderFemale, GenderMixed:
+	default:
+		return invalid("gender", "unknown gender %q", c.Gender)
+	}
+
+	for i := range c.Sessions {
+		if err := c.Sessions[i].Validate(); err != nil {
+			return fmt.Errorf("session %d: %w", i+1, err)
+		}
+	}
+	if c.Exam != nil {
+		if err := c.Exam.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Session) Validate() error {
+	if s.Day < 0 || s.Day > 6 {
+		return invalid("day", "must be 0 to 6")
+	}
+	if _, _, err := ParseTimeRange(s.Start + "-" + s.End); err != nil {
+		return invalid("start", "%v", err)
+	}
+	switch s.WeekParity {
+	case "", WeekParityOdd, WeekParityEven:
+	default:
+		return invalid("week_parity", "unknown week parity %q", s.WeekParity)
+	}
+	if s.HalfTerm < 0 || s.HalfTerm > 2 {
+		return invalid("half_term", "must be 0, 1 or 2")
+	}
+	return nil
+}
+
+func (e *Exam) Validate() error {
+	date, err := time.Parse("2006-01-02", e.Date)
+	if err != nil {
+		return invalid("exam.date", "not a YYYY-MM-DD date")
+	}
+	jalaliDate, err := jalali.Parse(e.DateJalali)
+	if err != nil {
+		return invalid("exam.date_jalali", "not a YYYY/MM/DD date")
+	}
+	if jalali.FromTime(date) != jalaliDate {
+		return invalid("exam.date_jalali", "%s is not %s", e.DateJalali, e.Date)
+	}
+	if _, _, err := ParseTimeRange(e.Start + "-" + e.End); err != nil {
+		return invalid("exam.start", "%v", err)
+	}
+	return nil
+}
+
+// String writes the session in the engine's slot notation, as in the times
+// of the API's course requests: d<day>/HH:MM-HH:MM, followed by /h1 or /h2
+// and /odd or /even when they apply
+func (s Session) String() string {
+	slot := fmt.Sprintf("d%d/%s-%s", s.Day, s.Start, s.End)
+	if s.HalfTerm > 0 {
+		slot += "/h" + strconv.Itoa(s.HalfTerm)
+	}
+	if s.WeekParity != "" {
+		slot += "/" + string(s.WeekParity)
+	}
+	return slot
+}
+
+// ParseSession reads a session in slot notation, see Session.String, held
+// at location
+func ParseSession(slot, location string) (Session, error) {
+	parts := strings.Split(slot, "/")
+	if len(parts) < 2 {
+		return Session{}, fmt.Errorf("invalid time format")
+	}
+
+	var s Session
+	for _, qualifier := range parts[2:] {
+		switch qualifier {
+		case "odd", "even":
+			s.WeekParity = WeekParity(qualifier)
+		case "h1":
+			s.HalfTerm = 1
+		case "h2":
+			s.HalfTerm = 2
+		default:
+			return Session{}, fmt.Errorf("invalid time qualifier %q", qualifier)
+		}
+	}
+
+	day, err := strconv.Atoi(strings.TrimPrefix(parts[0], "d"))
+	if !strings.HasPrefix(parts[0], "d") || err != nil || day < 0 || day > 6 {
+		return Session{}, fmt.Errorf("invalid day %q", parts[0])
+	}
+	s.Day = day
+
+	if s.Start, s.End, err = ParseTimeRange(parts[1]); err != nil {
+		return Session{}, err
+	}
+
+	s.Location = strings.TrimSpace(location)
+	s.Room = RoomFromLocation(s.Location)
+	return s, nil
+}
+
+// ParseTimeRange reads HH:MM-HH:MM, returning both ends as HH:MM
+func ParseTimeRange(value string) (string, string, error) {
+	bounds := strings.Split(value, "-")
+	if len(bounds) != 2 {
+		return "", "", fmt.Errorf("invalid time range %q", value)
+	}
+
+	start, err := time.Parse("15:04", strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid start time %q", bounds[0])
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(bounds[1]))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid end time %q", bounds[1])
+	}
+	if end.Before(start) {
+		return "", "", fmt.Errorf("end time %s before start time %s", bounds[1], bounds[0])
+	}
+
+	return start.Format("15:04"), end.Format("15:04"), nil
+}
+
+// RoomFromLocation takes the room number Golestan puts before the dash in
+// locations like "109- طبقه همکف سمت راست"
+func RoomFromLocation(location string) string {
+	room := location
+	if i := strings.Index(location, "-"); i != -1 {
+		room = strings.TrimSpace(location[:i])
+	}
+	if utf8.RuneCountInString(room) > 50 {
+		return ""
+	}
+	return room
+}
@@ -0,0 +1,118 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSessionRoundTrip(t *testing.T) {
+	session, err := schema.ParseSession("d3/08:00-10:00/h2/even", "204- ساختمان مرکزی")
+	require.NoError(t, err)
+	assert.Equal(t, schema.Session{
+		Day: 3, Start: "08:00", End: "10:00", Location: "204- ساختمان مرکزی", Room: "204",
+		WeekParity: schema.WeekParityEven, HalfTerm: 2,
+	}, session)
+	assert.Equal(t, "d3/08:00-10:00/h2/even", session.String())
+
+	for _, slot := range []string{"d7/08:00-10:00", "x1/08:00-10:00", "d1/10:00-08:00", "d1/08:00-10:00/weekly"} {
+		_, err := schema.ParseSession(slot, "")
+		assert.Error(t, err, slot)
+	}
+}
+
+func TestDocumentValidate(t *testing.T) {
+	course := schema.Course{
+		Code: "1211003_01", Name: "ریاضی عمومی 1", Faculty: "12", Professor: "الیاسی نیره",
+		Weight: 4, Capacity: 45, Gender: schema.GenderMixed,
+		Exam: &schema.Exam{Date: "2025-06-28", DateJalali: "1404/04/07", Start: "08:00", End: "10:00"},
+	}
+	document := schema.Document{Version: schema.Version, Courses: []schema.Course{course}}
+	assert.NoError(t, document.Validate())
+
+	document.Version = schema.Version + 1
+	assert.Error(t, document.Validate())
+
+	document.Version = schema.Version
+	document.Courses[0].Gender = "مختلط"
+	assert.ErrorContains(t, document.Validate(), "gender")
+
+	document.Courses[0] = course
+	document.Courses[0].Exam = &schema.Exam{Date: "2025-06-29", DateJalali: "1404/04/07", Start: "08:00", End: "10:00"}
+	assert.ErrorContains(t, document.Validate(), "exam.date_jalali")
+}
+
+func TestParseTermCode(t *testing.T) {
+	tests := []struct {
+		code    string
+		year    int
+		term    string
+		wantErr bool
+	}{
+		{code: "4031", year: 1403, term: "fall"},
+		{code: "4032", year: 1403, term: "spring"},
+		{code: "3993", year: 1399, term: "summer"},
+		{code: "4034", wantErr: true},
+		{code: "4030", wantErr: true},
+		{code: "40311", wantErr: true},
+		{code: "403", wantErr: true},
+		{code: "40x1", wantErr: true},
+		{code: "-031", wantErr: true},
+		{code: "+031", wantErr: true},
+		{code: " 031", wantErr: true},
+		{code: "۴۰۳۱", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			year, term, err := schema.ParseTermCode(tt.code)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.year, year)
+			assert.Equal(t, tt.term, term)
+			assert.Equal(t, tt.code, schema.TermCode(year, term))
+		})
+	}
+}
+
+func TestTermCode(t *testing.T) {
+	assert.Equal(t, "4031", schema.TermCode(1403, "fall"))
+	assert.Equal(t, "0003", schema.TermCode(1000, "summer"))
+	assert.Empty(t, schema.TermCode(1403, "winter"))
+	assert.Empty(t, schema.TermCode(2003, "fall"))
+	assert.Empty(t, schema.TermCode(999, "fall"))
+}
+
+func TestSplitCourseCode(t *testing.T) {
+	tests := []struct {
+		code  string
+		base  string
+		group string
+	}{
+		{code: "1211003_01", base: "1211003", group: "01"},
+		{code: " 1211003_01 ", base: "1211003", group: "01"},
+		{code: "1211003", base: "1211003"},
+		{code: "12_11003_02", base: "12_11003", group: "02"},
+		{code: "1211003_", base: "1211003"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			base, group := schema.SplitCourseCode(tt.code)
+			assert.Equal(t, tt.base, base)
+			assert.Equal(t, tt.group, group)
+		})
+	}
+}
+
+func TestRoomFromLocation(t *testing.T) {
+	assert.Equal(t, "109", schema.RoomFromLocation("109- طبقه همکف سمت راست"))
+	assert.Equal(t, "208", schema.RoomFromLocation("208-طبقه اول سمت چپ"))
+	assert.Equal(t, "آمفی تئاتر", schema.RoomFromLocation("آمفی تئاتر"))
+	assert.Equal(t, "", schema.RoomFromLocation(""))
+}
@@ -0,0 +1,49 @@
+package schema
+
+import "fmt"
+
+// termNumbers are Golestan's numbers for the terms of an academic year
+var termNumbers = map[string]int{
+	"fall":   1,
+	"spring": 2,
+	"summer": 3,
+}
+
+// TermNumber returns Golestan's number for a term, fall, spring or summer,
+// or 0 for any other term
+func TermNumber(term string) int {
+	return termNumbers[term]
+}
+
+// TermCode returns the Golestan term code of a term: the last three digits of
+// its academic year followed by the term number, e.g. 4031 for the fall of
+// 1403-1404. Only years 1000 to 1999 have a code; others return "".
+func TermCode(year int, term string) string {
+	number := TermNumber(term)
+	if number == 0 || year < 1000 || year > 1999 {
+		return ""
+	}
+	return fmt.Sprintf("%03d%d", year%1000, number)
+}
+
+// ParseTermCode reads a Golestan term code, four ASCII digits, into its
+// academic year and term
+func ParseTermCode(code string) (int, string, error) {
+	if len(code) != 4 {
+		return 0, "", fmt.Errorf("invalid term code %q", code)
+	}
+	value := 0
+	for _, digit := range []byte(code) {
+		if digit < '0' || digit > '9' {
+			return 0, "", fmt.Errorf("invalid term code %q", code)
+		}
+		value = value*10 + int(digit-'0')
+	}
+
+	for term, number := range termNumbers {
+		if value%10 == number {
+			return 1000 + value/10, term, nil
+		}
+	}
+	return 0, "", fmt.Errorf("invalid term code %q", code)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"github.com/armanjr/termustat/engine/parser"
+	"github.com/armanjr/termustat/engine/schema"
+	"github.com/gin-gonic/gin"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func serve(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	addr := flags.String("addr", ":80", "address to listen on")
+	profiles := flags.String("profiles", "", `directory of JSON column profiles, chosen per upload by the "profile" field`)
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	s := &server{profiles: *profiles}
+	router := gin.New()
+	router.POST("/process", s.processUploadedFile)
+	log.Println("Starting engine...")
+	if err := router.Run(*addr); err != nil {
+		log.Println("Failed to start engine server", err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// server holds what the handlers share: the directory of column profiles,
+// named <profile>.json
+type server struct {
+	profiles string
+}
+
+func (s *server) processUploadedFile(c *gin.Context) {
+	profile, err := s.profile(c.PostForm("profile"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "No file uploaded",
+		})
+		return
+	}
+
+	if !strings.HasSuffix(strings.ToLower(file.Filename), ".html") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Only HTML files are allowed",
+		})
+		return
+	}
+
+	tempDir, err := os.MkdirTemp("", "course_processing")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to create temporary directory",
+		})
+		return
+	}
+	defer os.RemoveAll(tempDir)
+
+	filePath := filepath.Join(tempDir, file.Filename)
+	if err := c.SaveUploadedFile(file, filePath); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to save uploaded file",
+		})
+		return
+	}
+
+	report, err := parser.ParseHTMLFile(filePath, profile)
+	if errors.Is(err, parser.ErrLayout) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to process HTML file: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "File processed successfully",
+		"version": schema.Version,
+		"courses": report.Courses(),
+		"rows":    report.Rows,
+		"summary": report.Summary,
+	})
+}
+
+// profile loads the named column profile, the default one for no name
+func (s *server) profile(name string) (*parser.Profile, error) {
+	return parser.OpenProfile(s.profiles, name)
+}